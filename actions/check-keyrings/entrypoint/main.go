@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+)
+
+func main() {
+	var (
+		githubToken   string
+		warnDays      int
+		failOnWarning bool
+		updateDir     string
+	)
+
+	flag.StringVar(&githubToken, "github-token", "", "Github access token")
+	flag.IntVar(&warnDays, "warn-days", 30, "Report keys that expire within this many days as expiring")
+	flag.BoolVar(&failOnWarning, "fail-on-warning", false, "Fail when any keyring has a warning, not only when one cannot verify new signatures")
+	flag.StringVar(&updateDir, "update-dir", "", "OPTIONAL, refresh the committed keys in this keyrings directory with the pinned keys each dependency publishes, instead of reporting")
+	flag.Parse()
+
+	factory := dependency.NewDependencyFactory(githubToken)
+	defer factory.Close()
+
+	if updateDir != "" {
+		err := updateKeyrings(factory, updateDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	reports := factory.CheckKeyrings(time.Now(), time.Duration(warnDays)*24*time.Hour)
+
+	failed := false
+	for _, report := range reports {
+		warnings := report.Warnings()
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "WARNING: %s\n", warning)
+		}
+
+		if !report.Healthy() || (failOnWarning && len(warnings) > 0) {
+			failed = true
+		}
+	}
+
+	output, err := json.Marshal(reports)
+	if err != nil {
+		fmt.Printf("Error: failed to marshal keyring reports: %s", err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Println(string(output))
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// updateKeyrings writes the pinned keys every dependency publishes to
+// dir/<dependency>/<fingerprint>.asc, where they are committed from.
+func updateKeyrings(factory dependency.DepFactory, dir string) error {
+	for _, name := range factory.KeyringDependencies() {
+		keys, err := factory.PublishedKeys(name)
+		if err != nil {
+			return fmt.Errorf("could not get the keys of %s: %w", name, err)
+		}
+
+		if len(keys) == 0 {
+			continue
+		}
+
+		err = os.MkdirAll(filepath.Join(dir, name), os.ModePerm)
+		if err != nil {
+			return err
+		}
+
+		for fingerprint, key := range keys {
+			err = os.WriteFile(filepath.Join(dir, name, fingerprint+".asc"), []byte(key), 0644)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
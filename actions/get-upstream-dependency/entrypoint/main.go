@@ -8,18 +8,30 @@ import (
 	"os"
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 )
 
 func main() {
 	var (
-		githubToken string
-		name        string
-		version     string
+		githubToken         string
+		name                string
+		version             string
+		requireVerification bool
+		checksumExceptions  string
+		cpeDictionary       string
+		licenseOverrides    string
+		licenseConfidence   float64
 	)
 
 	flag.StringVar(&githubToken, "github-token", "", "Github access token")
 	flag.StringVar(&name, "name", "", "Dependency name")
 	flag.StringVar(&version, "version", "", "Dependency version")
+	flag.StringVar(&checksumExceptions, "checksum-exceptions", "", "Checksum exceptions file to use instead of the embedded one")
+	flag.StringVar(&cpeDictionary, "cpe-dictionary", "", "NVD CPE dictionary file to report CPEs with an unknown vendor and product against")
+	flag.StringVar(&licenseOverrides, "license-overrides", "", "License overrides file to use instead of the embedded one")
+	flag.Float64Var(&licenseConfidence, "license-confidence-threshold", 0, "Exclude license files matched with a confidence, from 0 to 1, below this threshold")
+	flag.BoolVar(&requireVerification, "require-verification", false, "Fail if the version could not be verified against a signature or upstream checksum")
 	flag.Parse()
 
 	if name == "" || version == "" {
@@ -27,16 +39,50 @@ func main() {
 		os.Exit(1)
 	}
 
-	output, err := getDepVersion(githubToken, name, version)
+	output, err := getDepVersion(githubToken, name, version, checksumExceptions, cpeDictionary, licenseOverrides, licenseConfidence, requireVerification)
 	if err != nil {
-		log.Fatal(err)
+		log.Print(err)
+		os.Exit(depErrors.ExitCode(err))
 	}
 
 	fmt.Println(output)
 }
 
-func getDepVersion(githubToken, name, version string) (string, error) {
-	dep, err := dependency.NewDependencyFactory(githubToken).NewDependency(name)
+func getDepVersion(githubToken, name, version, checksumExceptions, cpeDictionary, licenseOverrides string, licenseConfidence float64, requireVerification bool) (string, error) {
+	var options []dependency.DepFactoryOption
+	if checksumExceptions != "" {
+		exceptions, err := dependency.LoadChecksumExceptions(checksumExceptions)
+		if err != nil {
+			return "", fmt.Errorf("failed to load checksum exceptions: %w", err)
+		}
+		options = append(options, dependency.WithChecksumExceptions(exceptions))
+	}
+
+	if licenseOverrides != "" {
+		overrides, err := dependency.LoadLicenseOverrides(licenseOverrides)
+		if err != nil {
+			return "", fmt.Errorf("failed to load license overrides: %w", err)
+		}
+		options = append(options, dependency.WithLicenseOverrides(overrides))
+	}
+
+	if licenseConfidence > 0 {
+		options = append(options, dependency.WithLicenseConfidenceThreshold(float32(licenseConfidence)))
+	}
+
+	var dictionary *cpe.Dictionary
+	if cpeDictionary != "" {
+		loaded, err := cpe.LoadDictionary(cpeDictionary)
+		if err != nil {
+			return "", fmt.Errorf("failed to load cpe dictionary: %w", err)
+		}
+		dictionary = &loaded
+	}
+
+	factory := dependency.NewDependencyFactory(githubToken, options...)
+	defer factory.Close()
+
+	dep, err := factory.NewDependency(name)
 	if err != nil {
 		return "", fmt.Errorf("failed to create dependency: %w", err)
 	}
@@ -46,6 +92,19 @@ func getDepVersion(githubToken, name, version string) (string, error) {
 		return "", fmt.Errorf("failed to get version '%s': %w", version, err)
 	}
 
+	if requireVerification && !depVersion.Verification.Verified() {
+		reason := "no verification recorded"
+		if depVersion.Verification != nil {
+			reason = depVersion.Verification.SkipReason
+		}
+		return "", depErrors.UnverifiedError{Version: version, Reason: reason}
+	}
+
+	err = dependency.CheckCPE(depVersion, dictionary)
+	if err != nil {
+		log.Printf("WARNING: %s %s: %s", name, version, err)
+	}
+
 	output, err := json.Marshal(depVersion)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal dependency version: %w", err)
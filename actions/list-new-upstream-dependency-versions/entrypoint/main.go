@@ -7,16 +7,22 @@ import (
 	"os"
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 )
 
+// maxCacheSize bounds the directory given with -cache-dir.
+const maxCacheSize = 2 << 30
+
 func main() {
 	var (
 		githubToken string
 		name        string
+		cacheDir    string
 	)
 
 	flag.StringVar(&githubToken, "github-token", "", "Github access token")
 	flag.StringVar(&name, "name", "", "Dependency name")
+	flag.StringVar(&cacheDir, "cache-dir", "", "Directory to keep upstream responses in between runs")
 	flag.Parse()
 
 	if name == "" {
@@ -24,17 +30,25 @@ func main() {
 		os.Exit(1)
 	}
 
-	output, err := getNewVersions(githubToken, name)
+	output, err := getNewVersions(githubToken, name, cacheDir)
 	if err != nil {
 		fmt.Printf("Error: %s", err.Error())
-		os.Exit(1)
+		os.Exit(depErrors.ExitCode(err))
 	}
 
 	fmt.Println(output)
 }
 
-func getNewVersions(githubToken, name string) (string, error) {
-	dep, err := dependency.NewDependencyFactory(githubToken).NewDependency(name)
+func getNewVersions(githubToken, name, cacheDir string) (string, error) {
+	var options []dependency.DepFactoryOption
+	if cacheDir != "" {
+		options = append(options, dependency.WithHTTPCache(cacheDir, maxCacheSize))
+	}
+
+	factory := dependency.NewDependencyFactory(githubToken, options...)
+	defer factory.Close()
+
+	dep, err := factory.NewDependency(name)
 	if err != nil {
 		return "", fmt.Errorf("failed to create dependency: %w", err)
 	}
@@ -90,6 +90,8 @@ func testAcceptance(t *testing.T, when spec.G, it spec.S) {
 
 						assert.Equal(version, depVersion.Version)
 						assert.Len(depVersion.SHA256, 64, "SHA256 did not have 64 characters for %s %s", depName, version)
+						assert.Equal("sha256:"+depVersion.SHA256, depVersion.Checksum)
+						assert.Equal(depVersion.SHA256, depVersion.Checksums["sha256"])
 						assert.NotEmpty(depVersion.URI)
 
 						parsedVersion, err := semver.NewVersion(strings.TrimPrefix(version, "go"))
@@ -110,7 +112,7 @@ func testAcceptance(t *testing.T, when spec.G, it spec.S) {
 						}
 					} else {
 						for i := 0; i < len(depVersions)-1; i++ {
-							assert.True(depVersions[i].ReleaseDate.After(*depVersions[i+1].ReleaseDate) || depVersions[i].ReleaseDate.Equal(*depVersions[i+1].ReleaseDate), fmt.Sprintf("failed with %s and %s", depVersions[i], depVersions[i+1]))
+							assert.True(depVersions[i].ReleaseDate.After(*depVersions[i+1].ReleaseDate) || depVersions[i].ReleaseDate.Equal(*depVersions[i+1].ReleaseDate), fmt.Sprintf("failed with %v and %v", depVersions[i], depVersions[i+1]))
 						}
 					}
 				})
@@ -0,0 +1,110 @@
+package dependency
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultBatchWorkers is the number of versions GetDependencyVersions
+// resolves at once when WithWorkers is not given.
+const DefaultBatchWorkers = 4
+
+type DepVersionResult struct {
+	Version    string
+	DepVersion DepVersion
+	Err        error
+}
+
+// BatchProgress is called once per resolved version. Calls are serialized, so
+// implementations do not need their own locking.
+type BatchProgress func(completed, total int, result DepVersionResult)
+
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	workers  int
+	progress BatchProgress
+}
+
+func WithWorkers(workers int) BatchOption {
+	return func(c *batchConfig) {
+		c.workers = workers
+	}
+}
+
+func WithProgress(progress BatchProgress) BatchOption {
+	return func(c *batchConfig) {
+		c.progress = progress
+	}
+}
+
+// GetDependencyVersions resolves the given versions of a dependency using a
+// bounded pool of workers. Results are returned in the same order as the
+// versions; a failure to resolve one version is reported in its result and
+// does not stop the others. The returned error is only set when the
+// dependency is not supported.
+//
+// All workers share a single Dependency value. The dependency
+// implementations hold no mutable state of their own and the shared
+// collaborators (web client, Github client, cache) are safe for concurrent
+// use, so upstream indexes held in the factory's cache are fetched once for
+// the whole batch.
+func (d DepFactory) GetDependencyVersions(name string, versions []string, options ...BatchOption) ([]DepVersionResult, error) {
+	dep, err := d.NewDependency(name)
+	if err != nil {
+		return nil, err
+	}
+
+	config := batchConfig{workers: DefaultBatchWorkers}
+	for _, option := range options {
+		option(&config)
+	}
+
+	if config.workers < 1 {
+		return nil, fmt.Errorf("batch workers must be at least 1, got %d", config.workers)
+	}
+
+	results := make([]DepVersionResult, len(versions))
+	indexes := make(chan int)
+
+	var (
+		wg            sync.WaitGroup
+		progressMutex sync.Mutex
+		completed     int
+	)
+
+	for i := 0; i < config.workers && i < len(versions); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				version := versions[index]
+				depVersion, err := dep.GetDependencyVersion(version)
+				if err != nil {
+					err = fmt.Errorf("could not get version %s: %w", version, err)
+				}
+
+				results[index] = DepVersionResult{
+					Version:    version,
+					DepVersion: depVersion,
+					Err:        err,
+				}
+
+				progressMutex.Lock()
+				completed++
+				if config.progress != nil {
+					config.progress(completed, len(versions), results[index])
+				}
+				progressMutex.Unlock()
+			}
+		}()
+	}
+
+	for index := range versions {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, nil
+}
@@ -0,0 +1,117 @@
+package dependency_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+)
+
+func TestBatch(t *testing.T) {
+	spec.Run(t, "Batch", testBatch, spec.Report(report.Terminal{}))
+}
+
+func testBatch(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert               = assert.New(t)
+		require              = require.New(t)
+		fakeChecksummer      *dependencyfakes.FakeChecksummer
+		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
+		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
+		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
+		factory              dependency.DepFactory
+	)
+
+	it.Before(func() {
+		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
+		fakeChecksummer.GetChecksumsStub = func(path string, _ ...string) (map[string]string, error) {
+			return map[string]string{"sha256": "sha-of-" + path}, nil
+		}
+		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchStub = func(url string, _ ...internal.RequestOption) (string, error) {
+			return url, nil
+		}
+		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
+		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
+
+		factory = dependency.NewCustomDependencyFactory(fakeChecksummer, nil, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator,
+			dependency.WithArtifactStore(fakeArtifactStore),
+			dependency.WithCache(internal.NewCache(time.Minute)),
+		)
+
+		fakeWebClient.GetReturns([]byte(`[
+  {"number": "2.1.4", "created_at": "2020-01-05T18:19:06.369Z", "sha": "some-sha-2.1.4"},
+  {"number": "2.1.3", "created_at": "2019-12-26T00:00:00.000Z", "sha": "some-sha-2.1.3"},
+  {"number": "2.1.2", "created_at": "2019-12-20T00:00:00.000Z", "sha": "some-sha-2.1.2"}
+]`), nil)
+		fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT"}, nil)
+	})
+
+	when("GetDependencyVersions", func() {
+		it("resolves every version in order and fetches the index once", func() {
+			results, err := factory.GetDependencyVersions("bundler", []string{"2.1.2", "2.1.4", "2.1.3"}, dependency.WithWorkers(2))
+			require.NoError(err)
+			require.Len(results, 3)
+
+			for i, version := range []string{"2.1.2", "2.1.4", "2.1.3"} {
+				assert.Equal(version, results[i].Version)
+				assert.NoError(results[i].Err)
+				assert.Equal(version, results[i].DepVersion.Version)
+				assert.Equal("sha-of-"+results[i].DepVersion.URI, results[i].DepVersion.SHA256)
+			}
+
+			assert.Equal(1, fakeWebClient.GetCallCount())
+			assert.Equal(3, fakeLicenseRetriever.LookupLicensesCallCount())
+		})
+
+		it("reports a failure per version without stopping the batch", func() {
+			results, err := factory.GetDependencyVersions("bundler", []string{"2.1.4", "9.9.9"})
+			require.NoError(err)
+			require.Len(results, 2)
+
+			assert.NoError(results[0].Err)
+			assert.Equal("2.1.4", results[0].DepVersion.Version)
+
+			assert.EqualError(results[1].Err, "could not get version 9.9.9: could not find version 9.9.9")
+			assert.Equal(dependency.DepVersion{}, results[1].DepVersion)
+		})
+
+		it("reports progress for each resolved version", func() {
+			var completedCounts []int
+			results, err := factory.GetDependencyVersions("bundler", []string{"2.1.2", "2.1.3", "2.1.4"},
+				dependency.WithProgress(func(completed, total int, result dependency.DepVersionResult) {
+					assert.Equal(3, total)
+					assert.NoError(result.Err)
+					completedCounts = append(completedCounts, completed)
+				}),
+			)
+			require.NoError(err)
+			require.Len(results, 3)
+
+			assert.Equal([]int{1, 2, 3}, completedCounts)
+		})
+
+		when("the dependency is not supported", func() {
+			it("returns an error", func() {
+				_, err := factory.GetDependencyVersions("some-unsupported-dependency", []string{"1.0.0"})
+				assert.EqualError(err, "dependency type 'some-unsupported-dependency' is not supported")
+			})
+		})
+
+		when("the number of workers is invalid", func() {
+			it("returns an error", func() {
+				_, err := factory.GetDependencyVersions("bundler", []string{"2.1.4"}, dependency.WithWorkers(0))
+				assert.EqualError(err, "batch workers must be at least 1, got 0")
+			})
+		})
+	})
+}
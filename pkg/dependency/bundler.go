@@ -5,20 +5,29 @@ import (
 	"fmt"
 	"regexp"
 	"time"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
+const bundlerReleasesURL = "https://rubygems.org/api/v1/versions/bundler.json"
+
 type Bundler struct {
 	checksummer      Checksummer
 	fileSystem       FileSystem
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
 }
 
 type BundlerRelease struct {
-	Version string `json:"number"`
-	Date    string `json:"created_at"`
-	SHA     string `json:"sha"`
+	Version  string   `json:"number"`
+	Date     string   `json:"created_at"`
+	SHA      string   `json:"sha"`
+	Licenses []string `json:"licenses"`
 }
 
 func (b Bundler) GetAllVersionRefs() ([]string, error) {
@@ -48,31 +57,55 @@ func (b Bundler) GetDependencyVersion(version string) (DepVersion, error) {
 
 	depURL := b.getDependencyURL(version)
 
-	licenses, err := b.licenseRetriever.LookupLicenses("bundler", depURL)
-	if err != nil {
-		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
-	}
-
 	for _, release := range bundlerReleases {
 		if release.Version == version {
 			releaseDate, err := time.Parse(time.RFC3339Nano, release.Date)
 			if err != nil {
-				return DepVersion{}, fmt.Errorf("could not parse release date: %w", err)
+				return DepVersion{}, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 			}
+
+			artifactPath, err := b.artifactStore.Fetch(depURL, verifySHA256(release.SHA)...)
+			if err != nil {
+				return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+			}
+			defer b.artifactStore.Release(artifactPath)
+
+			verification := checksumVerification(VerificationSHA256, bundlerReleasesURL)
+			if release.SHA == "" {
+				verification = skippedVerification("rubygems publishes no sha for this version")
+			}
+
+			licenses, licenseExpression, licenseSources, err := resolveLicenses(b.licenseRetriever, "bundler", artifactPath,
+				declaredLicenses(LicenseSourceRubyGems, release.Licenses),
+			)
+			if err != nil {
+				return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
+			}
+
+			checksums, err := b.checksummer.GetChecksums(artifactPath, checksumAlgorithms...)
+			if err != nil {
+				return DepVersion{}, fmt.Errorf("could not get checksums: %w", err)
+			}
+
 			return DepVersion{
-				Version:         version,
-				URI:             depURL,
-				SHA256:          release.SHA,
-				ReleaseDate:     &releaseDate,
-				DeprecationDate: nil,
-				CPE:             fmt.Sprintf("cpe:2.3:a:bundler:bundler:%s:*:*:*:*:ruby:*:*", version),
-				PURL:            b.purlGenerator.Generate("bundler", version, release.SHA, depURL),
-				Licenses:        licenses,
+				Version:           version,
+				URI:               depURL,
+				SHA256:            checksums["sha256"],
+				Checksum:          prefixedChecksum(checksums),
+				Checksums:         checksums,
+				ReleaseDate:       &releaseDate,
+				DeprecationDate:   nil,
+				CPE:               cpe.Application("bundler", "bundler", version).WithTargetSoftware("ruby").String(),
+				PURL:              b.purlGenerator.Generate(purl.Gem("bundler", version).WithSource(checksums["sha256"], depURL)),
+				Licenses:          licenses,
+				LicenseExpression: licenseExpression,
+				LicenseSources:    licenseSources,
+				Verification:      verification,
 			}, nil
 		}
 	}
 
-	return DepVersion{}, fmt.Errorf("could not find version %s", version)
+	return DepVersion{}, depErrors.VersionNotFoundError{Version: version}
 }
 
 func (b Bundler) GetReleaseDate(version string) (*time.Time, error) {
@@ -85,17 +118,17 @@ func (b Bundler) GetReleaseDate(version string) (*time.Time, error) {
 		if release.Version == version {
 			releaseDate, err := time.Parse(time.RFC3339Nano, release.Date)
 			if err != nil {
-				return nil, fmt.Errorf("could not parse release date: %w", err)
+				return nil, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 			}
 			return &releaseDate, nil
 		}
 	}
 
-	return nil, fmt.Errorf("could not find release date for version %s", version)
+	return nil, fmt.Errorf("could not find release date: %w", depErrors.VersionNotFoundError{Version: version})
 }
 
 func (b Bundler) getAllReleases() ([]BundlerRelease, error) {
-	body, err := b.webClient.Get("https://rubygems.org/api/v1/versions/bundler.json")
+	body, err := cachedGet(b.cache, b.webClient, bundlerReleasesURL)
 	if err != nil {
 		return nil, fmt.Errorf("could not get release index: %w", err)
 	}
@@ -103,7 +136,7 @@ func (b Bundler) getAllReleases() ([]BundlerRelease, error) {
 	var bundlerReleases []BundlerRelease
 	err = json.Unmarshal(body, &bundlerReleases)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal response: %w\n%s", err, body)
+		return nil, fmt.Errorf("could not unmarshal response: %w\n%s", depErrors.ParseError{Err: err}, body)
 	}
 
 	return bundlerReleases, nil
@@ -11,6 +11,7 @@ import (
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 func TestBundler(t *testing.T) {
@@ -24,6 +25,7 @@ func testBundler(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer      *dependencyfakes.FakeChecksummer
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		bundler              dependency.Dependency
@@ -33,11 +35,13 @@ func testBundler(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		bundler, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("bundler")
+		bundler, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("bundler")
 		require.NoError(err)
 	})
 
@@ -274,27 +278,77 @@ func testBundler(t *testing.T, when spec.G, it spec.S) {
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/bundler@2.1.3?checksum=9b9a9a&download_url=https://rubygems.org")
 
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "9b9a9a5685121403eda1ae148ed3a34c86418f2a2beec7df82a45d4baca0e5d2", "sha512": "some-sha512"}, nil)
+
 			actualDepVersion, err := bundler.GetDependencyVersion("2.1.3")
 			require.NoError(err)
 
-			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
+			assert.Equal(0, fakeLicenseRetriever.LookupLicensesCallCount())
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
+			assert.Equal(purl.Gem("bundler", "2.1.3").WithSource("9b9a9a5685121403eda1ae148ed3a34c86418f2a2beec7df82a45d4baca0e5d2", "https://rubygems.org/downloads/bundler-2.1.3.gem"), fakePURLGenerator.GenerateArgsForCall(0))
 			expectedReleaseDate := time.Date(2020, 01, 02, 12, 29, 43, 745000000, time.UTC)
 			expectedDepVersion := dependency.DepVersion{
-				Version:         "2.1.3",
-				URI:             "https://rubygems.org/downloads/bundler-2.1.3.gem",
-				SHA256:          "9b9a9a5685121403eda1ae148ed3a34c86418f2a2beec7df82a45d4baca0e5d2",
-				ReleaseDate:     &expectedReleaseDate,
-				DeprecationDate: nil,
-				CPE:             "cpe:2.3:a:bundler:bundler:2.1.3:*:*:*:*:ruby:*:*",
-				PURL:            "pkg:generic/bundler@2.1.3?checksum=9b9a9a&download_url=https://rubygems.org",
-				Licenses:        []string{"MIT", "MIT-2"},
+				Version:  "2.1.3",
+				URI:      "https://rubygems.org/downloads/bundler-2.1.3.gem",
+				SHA256:   "9b9a9a5685121403eda1ae148ed3a34c86418f2a2beec7df82a45d4baca0e5d2",
+				Checksum: "sha256:9b9a9a5685121403eda1ae148ed3a34c86418f2a2beec7df82a45d4baca0e5d2",
+				Checksums: map[string]string{
+					"sha256": "9b9a9a5685121403eda1ae148ed3a34c86418f2a2beec7df82a45d4baca0e5d2",
+					"sha512": "some-sha512",
+				},
+				ReleaseDate:       &expectedReleaseDate,
+				DeprecationDate:   nil,
+				CPE:               "cpe:2.3:a:bundler:bundler:2.1.3:*:*:*:*:ruby:*:*",
+				PURL:              "pkg:generic/bundler@2.1.3?checksum=9b9a9a&download_url=https://rubygems.org",
+				Licenses:          []string{"MIT"},
+				LicenseExpression: "MIT",
+				LicenseSources:    map[string]dependency.LicenseSource{"MIT": dependency.LicenseSourceRubyGems},
+				Verification:      &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://rubygems.org/api/v1/versions/bundler.json"},
 			}
 			assert.Equal(expectedDepVersion, actualDepVersion)
 
 			url, _ := fakeWebClient.GetArgsForCall(0)
 			assert.Equal("https://rubygems.org/api/v1/versions/bundler.json", url)
 		})
+
+		when("rubygems does not declare a license it can be normalized from", func() {
+			it("scans the gem for license files", func() {
+				fakeWebClient.GetReturns([]byte(`[{
+  "number": "2.1.3",
+  "created_at": "2020-01-02T12:29:43.745Z",
+  "sha": "9b9a9a5685121403eda1ae148ed3a34c86418f2a2beec7df82a45d4baca0e5d2",
+  "licenses": ["Some Custom License"]
+}]`), nil)
+				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
+
+				actualDepVersion, err := bundler.GetDependencyVersion("2.1.3")
+				require.NoError(err)
+
+				require.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
+				name, path := fakeLicenseRetriever.LookupLicensesArgsForCall(0)
+				assert.Equal("bundler", name)
+				assert.Equal("some-artifact-path", path)
+
+				assert.Equal([]string{"MIT", "MIT-2"}, actualDepVersion.Licenses)
+				assert.Equal(map[string]dependency.LicenseSource{"MIT": dependency.LicenseSourceFileScan, "MIT-2": dependency.LicenseSourceFileScan}, actualDepVersion.LicenseSources)
+			})
+		})
+
+		when("rubygems publishes no sha for the version", func() {
+			it("records the verification as skipped", func() {
+				fakeWebClient.GetReturns([]byte(`[{
+  "number": "2.1.3",
+  "created_at": "2020-01-02T12:29:43.745Z",
+  "licenses": ["MIT"]
+}]`), nil)
+
+				actualDepVersion, err := bundler.GetDependencyVersion("2.1.3")
+				require.NoError(err)
+
+				assert.Equal(&dependency.Verification{Method: dependency.VerificationNone, SkipReason: "rubygems publishes no sha for this version"}, actualDepVersion.Verification)
+				assert.False(actualDepVersion.Verification.Verified())
+			})
+		})
 	})
 
 	when("GetReleaseDate", func() {
@@ -0,0 +1,56 @@
+package dependency
+
+import (
+	"fmt"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+)
+
+// cachedGet fetches url through the web client, reusing the response body of
+// a previous request for the same url while it is held by the cache. Requests
+// made under different URL policies do not share responses, since a body
+// fetched under one policy may not be allowed under another. The returned
+// slice is shared and must not be modified.
+func cachedGet(cache Cache, webClient WebClient, url string) ([]byte, error) {
+	key := url
+	if policyClient, ok := webClient.(urlPolicyWebClient); ok {
+		key = fmt.Sprintf("%s policy:%+v", url, policyClient.policy)
+	}
+
+	value, err := cache.Memoize(key, func() (interface{}, error) {
+		return webClient.Get(url)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]byte), nil
+}
+
+// cachedReleaseTags returns the releases of the given Github repository. Each
+// caller gets its own copy of the slice, so it is safe to sort or modify.
+func cachedReleaseTags(cache Cache, githubClient GithubClient, org, repo string) ([]internal.GithubRelease, error) {
+	value, err := cache.Memoize(fmt.Sprintf("github-releases:%s/%s", org, repo), func() (interface{}, error) {
+		return githubClient.GetReleaseTags(org, repo)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	releases := value.([]internal.GithubRelease)
+	return append([]internal.GithubRelease(nil), releases...), nil
+}
+
+// cachedTags returns the tags of the given Github repository. Each caller gets
+// its own copy of the slice, so it is safe to sort or modify.
+func cachedTags(cache Cache, githubClient GithubClient, org, repo string) ([]string, error) {
+	value, err := cache.Memoize(fmt.Sprintf("github-tags:%s/%s", org, repo), func() (interface{}, error) {
+		return githubClient.GetTags(org, repo)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := value.([]string)
+	return append([]string(nil), tags...), nil
+}
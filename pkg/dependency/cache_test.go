@@ -0,0 +1,69 @@
+package dependency_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+)
+
+func TestCache(t *testing.T) {
+	spec.Run(t, "cache", testCache, spec.Report(report.Terminal{}))
+}
+
+func testCache(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert        = assert.New(t)
+		require       = require.New(t)
+		fakeWebClient *dependencyfakes.FakeWebClient
+		cache         dependency.Cache
+	)
+
+	it.Before(func() {
+		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeWebClient.GetReturns([]byte(`[{"number": "2.1.4", "created_at": "2020-01-05T18:19:06.369Z"}]`), nil)
+		cache = internal.NewCache(time.Minute)
+	})
+
+	newBundler := func(policy dependency.URLPolicy) dependency.Dependency {
+		factory := dependency.NewCustomDependencyFactory(nil, nil, nil, fakeWebClient, nil, nil,
+			dependency.WithCache(cache),
+			dependency.WithURLPolicy("bundler", policy),
+		)
+
+		bundler, err := factory.NewDependency("bundler")
+		require.NoError(err)
+		return bundler
+	}
+
+	when("dependencies share a cache", func() {
+		it("shares responses fetched under the same URL policy", func() {
+			policy := dependency.URLPolicy{Hosts: []string{"rubygems.org"}}
+
+			_, err := newBundler(policy).GetAllVersionRefs()
+			require.NoError(err)
+			_, err = newBundler(policy).GetAllVersionRefs()
+			require.NoError(err)
+
+			assert.Equal(1, fakeWebClient.GetCallCount())
+		})
+
+		it("does not share responses fetched under different URL policies", func() {
+			_, err := newBundler(dependency.URLPolicy{Hosts: []string{"rubygems.org"}, AllowPrivateNetworks: true}).GetAllVersionRefs()
+			require.NoError(err)
+			_, err = newBundler(dependency.URLPolicy{Hosts: []string{"rubygems.org"}}).GetAllVersionRefs()
+			require.NoError(err)
+
+			require.Equal(2, fakeWebClient.GetCallCount())
+			_, options := fakeWebClient.GetArgsForCall(1)
+			assert.Len(options, 1)
+		})
+	})
+}
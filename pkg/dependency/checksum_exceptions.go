@@ -0,0 +1,49 @@
+package dependency
+
+import (
+	"fmt"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+)
+
+// ChecksumException exempts a dependency version whose upstream checksum is
+// missing or wrong from being verified against it.
+type ChecksumException = internal.ChecksumException
+
+// ChecksumExceptions is the registry consulted by every dependency before
+// verifying an artifact against its upstream checksum.
+type ChecksumExceptions = internal.ChecksumExceptions
+
+// LoadChecksumExceptions reads a checksum exceptions file in the format of
+// the embedded internal/checksum_exceptions.yml.
+func LoadChecksumExceptions(path string) (ChecksumExceptions, error) {
+	return internal.LoadChecksumExceptions(path)
+}
+
+// WithChecksumExceptions replaces the embedded checksum exceptions.
+func WithChecksumExceptions(exceptions ChecksumExceptions) DepFactoryOption {
+	return func(d *DepFactory) {
+		d.checksumExceptions = exceptions
+	}
+}
+
+// exceptionChecksums computes the checksums of the artifact at path, which
+// exception exempts from being verified against its upstream checksum. The
+// artifact is still verified against the sha256 pinned by exception, if any.
+func exceptionChecksums(checksummer Checksummer, exception ChecksumException, path string) (map[string]string, *Verification, error) {
+	verification := skippedVerification(exception.Reason)
+	if exception.SHA256 != "" {
+		err := checksummer.VerifySHA256(path, exception.SHA256)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not verify pinned sha256: %w", err)
+		}
+		verification = &Verification{Method: VerificationPinnedSHA256, SkipReason: exception.Reason}
+	}
+
+	checksums, err := checksummer.GetChecksums(path, checksumAlgorithms...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get SHA256: %w", err)
+	}
+
+	return checksums, verification, nil
+}
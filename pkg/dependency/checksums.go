@@ -0,0 +1,27 @@
+package dependency
+
+import (
+	"fmt"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+)
+
+// checksumAlgorithms are computed for every file downloaded while resolving a
+// DepVersion.
+var checksumAlgorithms = []string{"sha256", "sha512"}
+
+// prefixedChecksum returns the SHA256 of the given checksums in the
+// "sha256:<digest>" form used by DepVersion.Checksum.
+func prefixedChecksum(checksums map[string]string) string {
+	return fmt.Sprintf("sha256:%s", checksums["sha256"])
+}
+
+// verifySHA256 returns the options for fetching an artifact whose SHA256 is
+// published upstream, so that the download is checked against it. A
+// download resumed part way through is only accepted once it matches.
+func verifySHA256(sha string) []internal.RequestOption {
+	if sha == "" {
+		return nil
+	}
+	return []internal.RequestOption{internal.WithExpectedSHA256(sha)}
+}
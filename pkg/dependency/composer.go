@@ -1,13 +1,19 @@
 package dependency
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Composer struct {
@@ -17,10 +23,13 @@ type Composer struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
+	keyring          Keyring
 }
 
 func (c Composer) GetAllVersionRefs() ([]string, error) {
-	releases, err := c.githubClient.GetReleaseTags("composer", "composer")
+	releases, err := cachedReleaseTags(c.cache, c.githubClient, "composer", "composer")
 	if err != nil {
 		return nil, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -29,7 +38,7 @@ func (c Composer) GetAllVersionRefs() ([]string, error) {
 	for _, release := range releases {
 		version, err := semver.NewVersion(release.TagName)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse version: %w", err)
+			return nil, fmt.Errorf("failed to parse version: %w", depErrors.ParseError{Err: err})
 		}
 		if version.Prerelease() != "" {
 			continue
@@ -40,7 +49,7 @@ func (c Composer) GetAllVersionRefs() ([]string, error) {
 }
 
 func (c Composer) GetDependencyVersion(version string) (DepVersion, error) {
-	releases, err := c.githubClient.GetReleaseTags("composer", "composer")
+	releases, err := cachedReleaseTags(c.cache, c.githubClient, "composer", "composer")
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -55,11 +64,11 @@ func (c Composer) GetDependencyVersion(version string) (DepVersion, error) {
 		}
 	}
 
-	return DepVersion{}, fmt.Errorf("could not find composer version %s", version)
+	return DepVersion{}, depErrors.VersionNotFoundError{Dependency: "composer", Version: version}
 }
 
 func (c Composer) GetReleaseDate(version string) (*time.Time, error) {
-	releases, err := c.githubClient.GetReleaseTags("composer", "composer")
+	releases, err := cachedReleaseTags(c.cache, c.githubClient, "composer", "composer")
 	if err != nil {
 		return nil, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -70,7 +79,7 @@ func (c Composer) GetReleaseDate(version string) (*time.Time, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("could not find release date for version %s", version)
+	return nil, fmt.Errorf("could not find release date: %w", depErrors.VersionNotFoundError{Version: version})
 }
 
 func (c Composer) createDependencyVersion(release internal.GithubRelease) (DepVersion, error) {
@@ -80,29 +89,48 @@ func (c Composer) createDependencyVersion(release internal.GithubRelease) (DepVe
 	}
 
 	depURL := c.dependencyURL(release.TagName)
-	licenses, err := c.licenseRetriever.LookupLicenses("composer", depURL)
+	artifactPath, err := c.artifactStore.Fetch(depURL, verifySHA256(sha)...)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer c.artifactStore.Release(artifactPath)
+
+	verification, err := c.verifySignature(release.TagName, artifactPath)
+	if err != nil {
+		return DepVersion{}, err
+	}
+
+	licenses, err := c.getLicenses(release.TagName, artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not find license metadata: %w", err)
 	}
 
 	v, err := semver.NewVersion(release.TagName)
 	if err != nil {
-		return DepVersion{}, fmt.Errorf("failed to parse version: %w", err)
+		return DepVersion{}, fmt.Errorf("failed to parse version: %w", depErrors.ParseError{Err: err})
 	}
 
 	version := *v
 	version, _ = version.SetPrerelease("")
 	version, _ = version.SetMetadata("")
 
+	checksums, err := c.checksummer.GetChecksums(artifactPath, checksumAlgorithms...)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not get checksums: %w", err)
+	}
+
 	return DepVersion{
 		Version:         release.TagName,
 		URI:             depURL,
-		SHA256:          sha,
+		SHA256:          checksums["sha256"],
+		Checksum:        prefixedChecksum(checksums),
+		Checksums:       checksums,
 		ReleaseDate:     &release.PublishedDate,
 		DeprecationDate: nil,
-		CPE:             fmt.Sprintf("cpe:2.3:a:getcomposer:composer:%s:*:*:*:*:*:*:*", version.String()),
-		PURL:            c.purlGenerator.Generate("composer", release.TagName, sha, depURL),
+		CPE:             cpe.Application("getcomposer", "composer", version.String()).String(),
+		PURL:            c.purlGenerator.Generate(purl.Composer("composer", "composer", release.TagName).WithSource(checksums["sha256"], depURL)),
 		Licenses:        licenses,
+		Verification:    verification,
 	}, nil
 }
 
@@ -119,6 +147,56 @@ func (c Composer) getDependencySHA(version string) (string, error) {
 	return depSHA, nil
 }
 
+// verifySignature verifies the composer.phar at path against its signature.
+// Releases that were published without a signature are only checked against
+// their SHA256 file, which is recorded with the reason so that
+// --require-verification rejects them.
+func (c Composer) verifySignature(version, path string) (*Verification, error) {
+	signatureURL := c.signatureURL(version)
+	signature, err := c.webClient.Get(signatureURL)
+	if err != nil {
+		var httpErr depErrors.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return fallbackVerification(VerificationSHA256, c.shaURL(version), "getcomposer.org publishes no signature for this release"), nil
+		}
+		return nil, fmt.Errorf("could not get dependency signature: %w", err)
+	}
+
+	keyring, err := fetchKeys(c.cache, c.webClient, c.keyring)
+	if err != nil {
+		return nil, fmt.Errorf("could not get composer GPG key: %w", err)
+	}
+
+	fingerprint, err := c.checksummer.VerifyASC(string(signature), path, keyring)
+	if err != nil {
+		return nil, fmt.Errorf("dependency signature verification failed: %w", err)
+	}
+
+	return signatureVerification(signatureURL, fingerprint), nil
+}
+
+// getLicenses reads the licenses from the LICENSE and composer.json embedded
+// in the composer.phar at path, or from the source tarball of the release tag
+// when the phar does not embed them.
+func (c Composer) getLicenses(version, path string) ([]string, error) {
+	licenses, err := c.licenseRetriever.LookupLicenses("composer", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(licenses) > 0 {
+		return licenses, nil
+	}
+
+	sourcePath, err := c.artifactStore.Fetch(c.sourceURL(version))
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch source: %w", err)
+	}
+	defer c.artifactStore.Release(sourcePath)
+
+	return c.licenseRetriever.LookupLicenses("composer", sourcePath)
+}
+
 func (c Composer) dependencyURL(version string) string {
 	return fmt.Sprintf("https://getcomposer.org/download/%s/composer.phar", version)
 }
@@ -126,3 +204,11 @@ func (c Composer) dependencyURL(version string) string {
 func (c Composer) shaURL(version string) string {
 	return fmt.Sprintf("https://getcomposer.org/download/%s/composer.phar.sha256sum", version)
 }
+
+func (c Composer) signatureURL(version string) string {
+	return fmt.Sprintf("https://getcomposer.org/download/%s/composer.phar.asc", version)
+}
+
+func (c Composer) sourceURL(version string) string {
+	return fmt.Sprintf("https://github.com/composer/composer/archive/refs/tags/%s.tar.gz", version)
+}
@@ -2,6 +2,8 @@ package dependency_test
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
 )
 
@@ -27,6 +30,7 @@ func testComposer(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeGithubClient     *dependencyfakes.FakeGithubClient
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		composer             dependency.Dependency
@@ -37,11 +41,13 @@ func testComposer(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		composer, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("composer")
+		composer, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("composer")
 		require.NoError(err)
 	})
 
@@ -111,28 +117,58 @@ func testComposer(t *testing.T, when spec.G, it spec.S) {
 			}, nil)
 			fakeWebClient.GetReturnsOnCall(0,
 				[]byte(`aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  composer.phar`), nil)
+			fakeWebClient.GetReturnsOnCall(1, []byte("some-signature"), nil)
+			fakeWebClient.GetReturnsOnCall(2, []byte("some-key"), nil)
+			fakeChecksummer.VerifyASCReturns("some-fingerprint", nil)
 
-			fakeLicenseRetriever.LookupLicensesReturns([]string{}, nil)
+			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/composer@1.0.1?checksum=aaaaaaaa&download_url=https://getcomposer.org")
 
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "sha512": "some-sha512"}, nil)
+
 			actualDep, err := composer.GetDependencyVersion("1.0.1")
 			require.NoError(err)
 
 			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
+			dependencyNameArg, artifactPathArg := fakeLicenseRetriever.LookupLicensesArgsForCall(0)
+			assert.Equal("composer", dependencyNameArg)
+			assert.Equal("some-artifact-path", artifactPathArg)
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 			expectedReleaseDate := time.Date(2020, 6, 29, 0, 0, 0, 0, time.UTC)
 			expectedDep := dependency.DepVersion{
-				Version:         "1.0.1",
-				URI:             "https://getcomposer.org/download/1.0.1/composer.phar",
-				SHA256:          "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Version:  "1.0.1",
+				URI:      "https://getcomposer.org/download/1.0.1/composer.phar",
+				SHA256:   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Checksum: "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Checksums: map[string]string{
+					"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: nil,
 				CPE:             "cpe:2.3:a:getcomposer:composer:1.0.1:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/composer@1.0.1?checksum=aaaaaaaa&download_url=https://getcomposer.org",
-				Licenses:        []string{},
+				Licenses:        []string{"MIT"},
+				Verification: &dependency.Verification{
+					Method:      dependency.VerificationPGP,
+					SourceURL:   "https://getcomposer.org/download/1.0.1/composer.phar.asc",
+					Fingerprint: "some-fingerprint",
+				},
 			}
 			assert.Equal(expectedDep, actualDep)
 
+			urlArg, _ := fakeWebClient.GetArgsForCall(1)
+			assert.Equal("https://getcomposer.org/download/1.0.1/composer.phar.asc", urlArg)
+
+			urlArg, _ = fakeWebClient.GetArgsForCall(2)
+			assert.Equal("https://keys.openpgp.org/vks/v1/by-fingerprint/161DFBE342889F01DDAC4E61CBB3D576F2A0946F", urlArg)
+
+			signatureArg, pathArg, keyringArg := fakeChecksummer.VerifyASCArgsForCall(0)
+			assert.Equal("some-signature", signatureArg)
+			assert.Equal("some-artifact-path", pathArg)
+			assert.Equal([]string{"161DFBE342889F01DDAC4E61CBB3D576F2A0946F"}, keyringArg.KeyURLs[0].Fingerprints)
+			assert.Equal([]string{"some-key"}, keyringArg.Keys)
+
 			orgArg, repoArg := fakeGithubClient.GetReleaseTagsArgsForCall(0)
 			assert.Equal("composer", orgArg)
 			assert.Equal("composer", repoArg)
@@ -155,6 +191,63 @@ func testComposer(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("the release has no signature", func() {
+			it("records the verification against the SHA256 file and why the signature was skipped", func() {
+				fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{{TagName: "1.0.1"}}, nil)
+				fakeWebClient.GetReturnsOnCall(0,
+					[]byte(`aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  composer.phar`), nil)
+				fakeWebClient.GetReturnsOnCall(1, nil, fmt.Errorf("could not get: %w", depErrors.HTTPError{StatusCode: http.StatusNotFound}))
+
+				depVersion, err := composer.GetDependencyVersion("1.0.1")
+				require.NoError(err)
+
+				assert.Equal(&dependency.Verification{
+					Method:     dependency.VerificationSHA256,
+					SourceURL:  "https://getcomposer.org/download/1.0.1/composer.phar.sha256sum",
+					SkipReason: "getcomposer.org publishes no signature for this release",
+				}, depVersion.Verification)
+				assert.False(depVersion.Verification.Verified())
+				assert.Equal(0, fakeChecksummer.VerifyASCCallCount())
+			})
+		})
+
+		when("the signature does not verify", func() {
+			it("returns an error", func() {
+				fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{{TagName: "1.0.1"}}, nil)
+				fakeWebClient.GetReturnsOnCall(0,
+					[]byte(`aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  composer.phar`), nil)
+				fakeChecksummer.VerifyASCReturns("", depErrors.SignatureInvalidError{Reason: "some-reason"})
+
+				_, err := composer.GetDependencyVersion("1.0.1")
+				assert.True(errors.Is(err, depErrors.SignatureInvalidError{Reason: "some-reason"}))
+			})
+		})
+
+		when("the phar does not embed its licenses", func() {
+			it("reads the licenses from the source tarball of the tag", func() {
+				fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{{TagName: "1.0.1"}}, nil)
+				fakeWebClient.GetReturnsOnCall(0,
+					[]byte(`aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  composer.phar`), nil)
+				fakeArtifactStore.FetchReturnsOnCall(1, "some-source-path", nil)
+				fakeLicenseRetriever.LookupLicensesReturnsOnCall(0, []string{}, nil)
+				fakeLicenseRetriever.LookupLicensesReturnsOnCall(1, []string{"MIT"}, nil)
+
+				depVersion, err := composer.GetDependencyVersion("1.0.1")
+				require.NoError(err)
+
+				assert.Equal([]string{"MIT"}, depVersion.Licenses)
+
+				urlArg, _ := fakeArtifactStore.FetchArgsForCall(1)
+				assert.Equal("https://github.com/composer/composer/archive/refs/tags/1.0.1.tar.gz", urlArg)
+
+				dependencyNameArg, artifactPathArg := fakeLicenseRetriever.LookupLicensesArgsForCall(1)
+				assert.Equal("composer", dependencyNameArg)
+				assert.Equal("some-source-path", artifactPathArg)
+
+				assert.Equal("some-source-path", fakeArtifactStore.ReleaseArgsForCall(0))
+			})
+		})
+
 		when("the licenses cannot be found", func() {
 			it("returns an error", func() {
 				fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{
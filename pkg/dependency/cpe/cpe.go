@@ -0,0 +1,208 @@
+package cpe
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// formattedString is the CPE 2.3 formatted string grammar from NISTIR 7695,
+// as published in the NVD CPE dictionary schema.
+var formattedString = regexp.MustCompile(`^cpe:2\.3:[aho*\-](:(((\?*|\*?)([a-zA-Z0-9\-._]|(\\[\\*?!"#$%&'()+,/:;<=>@\[\]^` + "`" + `{|}~]))+(\?*|\*?))|[*\-])){5}(:(([a-zA-Z]{2,3}(-([a-zA-Z]{2}|[0-9]{3}))?)|[*\-]))(:(((\?*|\*?)([a-zA-Z0-9\-._]|(\\[\\*?!"#$%&'()+,/:;<=>@\[\]^` + "`" + `{|}~]))+(\?*|\*?))|[*\-])){4}$`)
+
+const (
+	PartApplication      = "a"
+	PartOperatingSystem  = "o"
+	PartHardware         = "h"
+	formattedStringStart = "cpe:2.3:"
+)
+
+// CPE is a CPE 2.3 name. Its attributes hold unescaped values, and empty
+// attributes match any value.
+type CPE struct {
+	Part            string
+	Vendor          string
+	Product         string
+	Version         string
+	Update          string
+	Edition         string
+	Language        string
+	SoftwareEdition string
+	TargetSoftware  string
+	TargetHardware  string
+	Other           string
+}
+
+// Application returns the CPE of an application.
+func Application(vendor, product, version string) CPE {
+	return CPE{Part: PartApplication, Vendor: vendor, Product: product, Version: version}
+}
+
+// WithTargetSoftware returns the CPE of the application built for the given
+// software environment, such as a language runtime.
+func (c CPE) WithTargetSoftware(targetSoftware string) CPE {
+	c.TargetSoftware = targetSoftware
+	return c
+}
+
+// String returns the CPE bound to a formatted string, with every character
+// that the grammar does not allow unquoted escaped.
+func (c CPE) String() string {
+	values := []string{c.Part, c.Vendor, c.Product, c.Version, c.Update, c.Edition, c.Language, c.SoftwareEdition, c.TargetSoftware, c.TargetHardware, c.Other}
+
+	var bound []string
+	for _, value := range values {
+		bound = append(bound, bindValue(value))
+	}
+
+	return formattedStringStart + strings.Join(bound, ":")
+}
+
+// Validate returns an error if the CPE does not bind to a valid formatted
+// string, or does not name a vendor and product.
+func (c CPE) Validate() error {
+	if c.Vendor == "" || c.Product == "" {
+		return InvalidError{CPE: c.String(), Reason: "vendor and product are required"}
+	}
+
+	return Validate(c.String())
+}
+
+// Validate returns an error if cpe is not a valid CPE 2.3 formatted string.
+func Validate(cpe string) error {
+	if cpe == "" {
+		return MissingError{}
+	}
+
+	if !formattedString.MatchString(cpe) {
+		return InvalidError{CPE: cpe, Reason: "does not match the CPE 2.3 formatted string grammar"}
+	}
+
+	return nil
+}
+
+// Parse returns the CPE of a formatted string.
+func Parse(cpe string) (CPE, error) {
+	err := Validate(cpe)
+	if err != nil {
+		return CPE{}, err
+	}
+
+	fields := splitFields(strings.TrimPrefix(cpe, formattedStringStart))
+	if len(fields) != 11 {
+		return CPE{}, InvalidError{CPE: cpe, Reason: fmt.Sprintf("has %d attributes instead of 11", len(fields))}
+	}
+
+	for i := range fields {
+		fields[i] = unbindValue(fields[i])
+	}
+
+	return CPE{
+		Part:            fields[0],
+		Vendor:          fields[1],
+		Product:         fields[2],
+		Version:         fields[3],
+		Update:          fields[4],
+		Edition:         fields[5],
+		Language:        fields[6],
+		SoftwareEdition: fields[7],
+		TargetSoftware:  fields[8],
+		TargetHardware:  fields[9],
+		Other:           fields[10],
+	}, nil
+}
+
+// MissingError is returned for dependencies that have no CPE.
+type MissingError struct{}
+
+func (e MissingError) Error() string {
+	return "no cpe"
+}
+
+// InvalidError is returned for CPEs that are not valid CPE 2.3 names.
+type InvalidError struct {
+	CPE    string
+	Reason string
+}
+
+func (e InvalidError) Error() string {
+	return fmt.Sprintf("cpe %s is invalid: %s", e.CPE, e.Reason)
+}
+
+// UnknownProductError is returned for CPEs whose vendor and product are not
+// in the CPE dictionary, which the NVD does not file vulnerabilities against.
+type UnknownProductError struct {
+	Vendor  string
+	Product string
+}
+
+func (e UnknownProductError) Error() string {
+	return fmt.Sprintf("cpe vendor and product %s:%s are not in the cpe dictionary", e.Vendor, e.Product)
+}
+
+func bindValue(value string) string {
+	if value == "" {
+		return "*"
+	}
+
+	var bound strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			bound.WriteRune(r)
+		case r == ' ':
+			// Words are separated with underscores in CPE names
+			bound.WriteRune('_')
+		default:
+			bound.WriteRune('\\')
+			bound.WriteRune(r)
+		}
+	}
+
+	return bound.String()
+}
+
+func unbindValue(value string) string {
+	if value == "*" {
+		return ""
+	}
+
+	var (
+		unbound strings.Builder
+		escaped bool
+	)
+	for _, r := range value {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		unbound.WriteRune(r)
+	}
+
+	return unbound.String()
+}
+
+// splitFields splits a formatted string on the colons that are not escaped.
+func splitFields(cpe string) []string {
+	var (
+		fields  []string
+		field   strings.Builder
+		escaped bool
+	)
+	for _, r := range cpe {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ':':
+			fields = append(fields, field.String())
+			field.Reset()
+			continue
+		}
+		field.WriteRune(r)
+	}
+
+	return append(fields, field.String())
+}
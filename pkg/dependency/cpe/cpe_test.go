@@ -0,0 +1,96 @@
+package cpe_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+)
+
+func TestCPE(t *testing.T) {
+	spec.Run(t, "cpe", testCPE, spec.Report(report.Terminal{}))
+}
+
+func testCPE(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	when("String", func() {
+		it("binds an application to a formatted string", func() {
+			assert.Equal("cpe:2.3:a:nginx:nginx:1.19.0:*:*:*:*:*:*:*", cpe.Application("nginx", "nginx", "1.19.0").String())
+		})
+
+		it("leaves the characters the grammar allows unquoted", func() {
+			assert.Equal("cpe:2.3:a:microsoft:asp.net_core:3.1:*:*:*:*:*:*:*", cpe.Application("microsoft", "asp.net_core", "3.1").String())
+			assert.Equal("cpe:2.3:a:python-poetry:poetry:1.1.4:*:*:*:*:python:*:*", cpe.Application("python-poetry", "poetry", "1.1.4").WithTargetSoftware("python").String())
+		})
+
+		it("escapes every other character", func() {
+			assert.Equal(`cpe:2.3:a:icu-project:international_components_for_unicode:69.1:*:*:*:*:c\/c\+\+:*:*`,
+				cpe.Application("icu-project", "international_components_for_unicode", "69.1").WithTargetSoftware("c/c++").String())
+			assert.Equal(`cpe:2.3:a:some-vendor:some\:product:1.0.0\+build\*:*:*:*:*:*:*:*`, cpe.Application("some-vendor", "some:product", "1.0.0+build*").String())
+		})
+
+		it("separates words with underscores", func() {
+			assert.Equal("cpe:2.3:a:apache:http_server:2.4.43:*:*:*:*:*:*:*", cpe.Application("apache", "http server", "2.4.43").String())
+		})
+	})
+
+	when("Validate", func() {
+		it("accepts valid CPEs", func() {
+			assert.NoError(cpe.Application("nodejs", "node.js", "14.0.0").Validate())
+			assert.NoError(cpe.Validate(`cpe:2.3:a:icu-project:international_components_for_unicode:69.1:*:*:*:*:c\/c\+\+:*:*`))
+			assert.NoError(cpe.Validate("cpe:2.3:a:haxx:curl:-:*:*:*:*:*:*:*"))
+		})
+
+		it("requires a vendor and product", func() {
+			err := cpe.Application("", "node.js", "14.0.0").Validate()
+			assert.Equal(cpe.InvalidError{CPE: "cpe:2.3:a:*:node.js:14.0.0:*:*:*:*:*:*:*", Reason: "vendor and product are required"}, err)
+		})
+
+		it("reports a missing CPE", func() {
+			assert.Equal(cpe.MissingError{}, cpe.Validate(""))
+		})
+
+		it("rejects strings that do not match the grammar", func() {
+			for _, invalid := range []string{
+				"cpe:/a:nginx:nginx:1.19.0",
+				"cpe:2.3:a:nginx:nginx:1.19.0",
+				"cpe:2.3:a:nginx:nginx:1.19.0:*:*:*:*:*:*:*:*",
+				"cpe:2.3:x:nginx:nginx:1.19.0:*:*:*:*:*:*:*",
+				"cpe:2.3:a:some vendor:nginx:1.19.0:*:*:*:*:*:*:*",
+				"cpe:2.3:a:icu-project:icu:69.1:*:*:*:*:c/c++:*:*",
+			} {
+				assert.IsType(cpe.InvalidError{}, cpe.Validate(invalid), invalid)
+			}
+		})
+	})
+
+	when("Parse", func() {
+		it("returns the unescaped attributes", func() {
+			parsed, err := cpe.Parse(`cpe:2.3:a:icu-project:international_components_for_unicode:69.1:*:*:*:*:c\/c\+\+:*:*`)
+			require.NoError(err)
+
+			assert.Equal(cpe.Application("icu-project", "international_components_for_unicode", "69.1").WithTargetSoftware("c/c++"), parsed)
+		})
+
+		it("splits on unescaped colons only", func() {
+			parsed, err := cpe.Parse(`cpe:2.3:a:some-vendor:some\:product:1.0.0:*:*:*:*:*:*:*`)
+			require.NoError(err)
+
+			assert.Equal("some:product", parsed.Product)
+			assert.Equal("1.0.0", parsed.Version)
+		})
+
+		it("returns an error for invalid CPEs", func() {
+			_, err := cpe.Parse("cpe:2.3:a:nginx")
+			assert.IsType(cpe.InvalidError{}, err)
+		})
+	})
+}
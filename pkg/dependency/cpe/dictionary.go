@@ -0,0 +1,176 @@
+package cpe
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Dictionary is the set of vendor and product pairs of the NVD CPE
+// dictionary.
+type Dictionary struct {
+	products map[string]struct{}
+}
+
+// LoadDictionary reads a locally downloaded NVD CPE dictionary: either the
+// official-cpe-dictionary_v2.3.xml feed, or a response of the NVD CPE API
+// (https://services.nvd.nist.gov/rest/json/cpes/2.0). Either may be
+// gzipped.
+func LoadDictionary(path string) (Dictionary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Dictionary{}, fmt.Errorf("could not open cpe dictionary: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	magic, _ := reader.Peek(2)
+
+	var content io.Reader = reader
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return Dictionary{}, fmt.Errorf("could not decompress cpe dictionary: %w", err)
+		}
+		defer gzipReader.Close()
+		content = gzipReader
+	}
+
+	return ReadDictionary(content)
+}
+
+// ReadDictionary reads an NVD CPE dictionary in either of the formats
+// LoadDictionary supports.
+func ReadDictionary(content io.Reader) (Dictionary, error) {
+	reader := bufio.NewReader(content)
+
+	var (
+		names []string
+		err   error
+	)
+	switch firstByte(reader) {
+	case '<':
+		names, err = readXMLDictionary(reader)
+	case '{':
+		names, err = readJSONDictionary(reader)
+	default:
+		return Dictionary{}, fmt.Errorf("could not read cpe dictionary: not an NVD xml feed or json response")
+	}
+	if err != nil {
+		return Dictionary{}, fmt.Errorf("could not read cpe dictionary: %w", err)
+	}
+
+	dictionary := NewDictionary()
+	for _, name := range names {
+		cpe, err := Parse(name)
+		if err != nil {
+			// The dictionary holds a few names that predate the grammar,
+			// which are of no use for lookups.
+			continue
+		}
+		dictionary.Add(cpe.Vendor, cpe.Product)
+	}
+
+	return dictionary, nil
+}
+
+// NewDictionary returns an empty dictionary.
+func NewDictionary() Dictionary {
+	return Dictionary{products: map[string]struct{}{}}
+}
+
+// Add adds a vendor and product pair to the dictionary.
+func (d Dictionary) Add(vendor, product string) {
+	d.products[productKey(vendor, product)] = struct{}{}
+}
+
+// Len returns the number of vendor and product pairs in the dictionary.
+func (d Dictionary) Len() int {
+	return len(d.products)
+}
+
+// Check returns an UnknownProductError if the vendor and product of cpe are
+// not in the dictionary.
+func (d Dictionary) Check(cpe CPE) error {
+	if _, ok := d.products[productKey(cpe.Vendor, cpe.Product)]; !ok {
+		return UnknownProductError{Vendor: cpe.Vendor, Product: cpe.Product}
+	}
+
+	return nil
+}
+
+// productKey is case insensitive, like CPE name matching.
+func productKey(vendor, product string) string {
+	return strings.ToLower(vendor) + ":" + strings.ToLower(product)
+}
+
+func firstByte(reader *bufio.Reader) byte {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0
+		}
+
+		switch b {
+		case ' ', '\t', '\r', '\n', 0xef, 0xbb, 0xbf: // whitespace and the UTF-8 byte order mark
+			continue
+		}
+
+		_ = reader.UnreadByte()
+		return b
+	}
+}
+
+func readXMLDictionary(reader io.Reader) ([]string, error) {
+	var names []string
+
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "cpe23-item" {
+			continue
+		}
+
+		for _, attr := range element.Attr {
+			if attr.Name.Local == "name" {
+				names = append(names, attr.Value)
+			}
+		}
+	}
+}
+
+func readJSONDictionary(reader io.Reader) ([]string, error) {
+	var response struct {
+		Products []struct {
+			CPE struct {
+				CPEName string `json:"cpeName"`
+			} `json:"cpe"`
+		} `json:"products"`
+	}
+
+	err := json.NewDecoder(reader).Decode(&response)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, product := range response.Products {
+		names = append(names, product.CPE.CPEName)
+	}
+
+	return names, nil
+}
@@ -0,0 +1,99 @@
+package cpe_test
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+)
+
+func TestDictionary(t *testing.T) {
+	spec.Run(t, "dictionary", testDictionary, spec.Report(report.Terminal{}))
+}
+
+func testDictionary(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	const xmlDictionary = `<?xml version='1.0' encoding='UTF-8'?>
+<cpe-list xmlns:cpe-23="http://scap.nist.gov/schema/cpe-extension/2.3" xmlns="http://cpe.mitre.org/dictionary/2.0">
+  <cpe-item name="cpe:/a:nginx:nginx:1.19.0">
+    <title xml:lang="en-US">Nginx 1.19.0</title>
+    <cpe-23:cpe23-item name="cpe:2.3:a:nginx:nginx:1.19.0:*:*:*:*:*:*:*"/>
+  </cpe-item>
+  <cpe-item name="cpe:/a:icu-project:international_components_for_unicode:69.1">
+    <cpe-23:cpe23-item name="cpe:2.3:a:icu-project:international_components_for_unicode:69.1:*:*:*:*:c\/c\+\+:*:*"/>
+  </cpe-item>
+  <cpe-item name="cpe:/a:some:invalid">
+    <cpe-23:cpe23-item name="cpe:2.3:a:some invalid"/>
+  </cpe-item>
+</cpe-list>`
+
+	const jsonDictionary = `{
+  "resultsPerPage": 1,
+  "format": "NVD_CPE",
+  "version": "2.0",
+  "products": [
+    {"cpe": {"deprecated": false, "cpeName": "cpe:2.3:a:haxx:curl:7.79.1:*:*:*:*:*:*:*"}}
+  ]
+}`
+
+	when("ReadDictionary", func() {
+		it("reads the vendors and products of the NVD xml feed", func() {
+			dictionary, err := cpe.ReadDictionary(strings.NewReader(xmlDictionary))
+			require.NoError(err)
+
+			assert.Equal(2, dictionary.Len())
+			assert.NoError(dictionary.Check(cpe.Application("nginx", "nginx", "1.21.0")))
+			assert.NoError(dictionary.Check(cpe.Application("ICU-Project", "international_components_for_unicode", "70.1")))
+			assert.Equal(cpe.UnknownProductError{Vendor: "haxx", Product: "curl"}, dictionary.Check(cpe.Application("haxx", "curl", "7.79.1")))
+		})
+
+		it("reads the vendors and products of an NVD CPE API response", func() {
+			dictionary, err := cpe.ReadDictionary(strings.NewReader(jsonDictionary))
+			require.NoError(err)
+
+			assert.Equal(1, dictionary.Len())
+			assert.NoError(dictionary.Check(cpe.Application("haxx", "curl", "7.80.0")))
+		})
+
+		it("returns an error for other files", func() {
+			_, err := cpe.ReadDictionary(strings.NewReader("some-content"))
+			assert.EqualError(err, "could not read cpe dictionary: not an NVD xml feed or json response")
+		})
+	})
+
+	when("LoadDictionary", func() {
+		it("reads a gzipped dictionary", func() {
+			path := filepath.Join(t.TempDir(), "official-cpe-dictionary_v2.3.xml.gz")
+			file, err := os.Create(path)
+			require.NoError(err)
+
+			writer := gzip.NewWriter(file)
+			_, err = writer.Write([]byte(xmlDictionary))
+			require.NoError(err)
+			require.NoError(writer.Close())
+			require.NoError(file.Close())
+
+			dictionary, err := cpe.LoadDictionary(path)
+			require.NoError(err)
+
+			assert.Equal(2, dictionary.Len())
+		})
+
+		it("returns an error when the file does not exist", func() {
+			_, err := cpe.LoadDictionary(filepath.Join(t.TempDir(), "missing.xml"))
+			assert.ErrorContains(err, "could not open cpe dictionary")
+		})
+	})
+}
@@ -0,0 +1,21 @@
+package dependency
+
+import (
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+)
+
+// CheckCPE returns why the NVD could not match vulnerabilities to
+// depVersion: it has no CPE, its CPE is not a valid CPE 2.3 name, or, when a
+// dictionary is given, the dictionary does not know its vendor and product.
+func CheckCPE(depVersion DepVersion, dictionary *cpe.Dictionary) error {
+	parsed, err := cpe.Parse(depVersion.CPE)
+	if err != nil {
+		return err
+	}
+
+	if dictionary == nil {
+		return nil
+	}
+
+	return dictionary.Check(parsed)
+}
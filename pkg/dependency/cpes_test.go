@@ -0,0 +1,52 @@
+package dependency_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+)
+
+func TestCPEs(t *testing.T) {
+	spec.Run(t, "cpes", testCPEs, spec.Report(report.Terminal{}))
+}
+
+func testCPEs(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert     = assert.New(t)
+		dictionary cpe.Dictionary
+	)
+
+	it.Before(func() {
+		dictionary = cpe.NewDictionary()
+		dictionary.Add("nginx", "nginx")
+	})
+
+	when("CheckCPE", func() {
+		it("accepts a valid CPE", func() {
+			assert.NoError(dependency.CheckCPE(dependency.DepVersion{CPE: "cpe:2.3:a:nginx:nginx:1.19.0:*:*:*:*:*:*:*"}, nil))
+		})
+
+		it("accepts a CPE whose vendor and product are in the dictionary", func() {
+			assert.NoError(dependency.CheckCPE(dependency.DepVersion{CPE: "cpe:2.3:a:nginx:nginx:1.19.0:*:*:*:*:*:*:*"}, &dictionary))
+		})
+
+		it("reports a missing CPE", func() {
+			assert.Equal(cpe.MissingError{}, dependency.CheckCPE(dependency.DepVersion{}, &dictionary))
+		})
+
+		it("reports an invalid CPE", func() {
+			err := dependency.CheckCPE(dependency.DepVersion{CPE: "cpe:2.3:a:some vendor:product"}, nil)
+			assert.IsType(cpe.InvalidError{}, err)
+		})
+
+		it("reports a CPE whose vendor and product are not in the dictionary", func() {
+			err := dependency.CheckCPE(dependency.DepVersion{CPE: "cpe:2.3:a:haxx:curl:7.79.1:*:*:*:*:*:*:*"}, &dictionary)
+			assert.Equal(cpe.UnknownProductError{Vendor: "haxx", Product: "curl"}, err)
+		})
+	})
+}
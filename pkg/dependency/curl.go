@@ -5,12 +5,14 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
-	"os"
-	"path/filepath"
 	"sort"
 	"time"
 
 	"github.com/Masterminds/semver"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Curl struct {
@@ -18,6 +20,9 @@ type Curl struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
+	keyring          Keyring
 }
 
 type CurlRelease struct {
@@ -61,7 +66,7 @@ func (c Curl) GetDependencyVersion(version string) (DepVersion, error) {
 		}
 	}
 
-	return DepVersion{}, fmt.Errorf("could not find version %s", version)
+	return DepVersion{}, depErrors.VersionNotFoundError{Version: version}
 }
 
 func (c Curl) GetReleaseDate(version string) (*time.Time, error) {
@@ -76,11 +81,11 @@ func (c Curl) GetReleaseDate(version string) (*time.Time, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("could not find release date for version %s", version)
+	return nil, fmt.Errorf("could not find release date: %w", depErrors.VersionNotFoundError{Version: version})
 }
 
 func (c Curl) getAllReleases() ([]CurlRelease, error) {
-	body, err := c.webClient.Get("https://curl.se/docs/releases.csv")
+	body, err := cachedGet(c.cache, c.webClient, "https://curl.se/docs/releases.csv")
 	if err != nil {
 		return nil, fmt.Errorf("could not get release csv: %w", err)
 	}
@@ -99,7 +104,7 @@ func (c Curl) getAllReleases() ([]CurlRelease, error) {
 
 		version, err := semver.NewVersion(release[CurlVersionIndex])
 		if err != nil {
-			return nil, fmt.Errorf("could not parse version: %w", err)
+			return nil, fmt.Errorf("could not parse version: %w", depErrors.ParseError{Err: err})
 		}
 
 		if !c.versionHasDownload(*version) {
@@ -108,7 +113,7 @@ func (c Curl) getAllReleases() ([]CurlRelease, error) {
 
 		date, err := time.Parse("2006-01-02", release[CurlDateIndex])
 		if err != nil {
-			return nil, fmt.Errorf("could not parse date: %w", err)
+			return nil, fmt.Errorf("could not parse date: %w", depErrors.ParseError{Err: err})
 		}
 
 		curlReleases = append(curlReleases, CurlRelease{
@@ -122,61 +127,62 @@ func (c Curl) getAllReleases() ([]CurlRelease, error) {
 }
 
 func (c Curl) createDependencyVersion(release CurlRelease) (DepVersion, error) {
-	sha, err := c.getDependencySHA(release)
+	artifactPath, err := c.artifactStore.Fetch(c.dependencyURL(release))
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer c.artifactStore.Release(artifactPath)
+
+	checksums, verification, err := c.getDependencyChecksums(release, artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get curl sha: %w", err)
 	}
+	sha := checksums["sha256"]
 
 	depURL := c.dependencyURL(release)
-	licenses, err := c.licenseRetriever.LookupLicenses("curl", depURL)
+	licenses, err := c.licenseRetriever.LookupLicenses("curl", artifactPath)
 
 	return DepVersion{
-		Version:     release.Version,
-		URI:         depURL,
-		SHA256:      sha,
-		ReleaseDate: &release.Date,
-		CPE:         fmt.Sprintf("cpe:2.3:a:haxx:curl:%s:*:*:*:*:*:*:*", release.Version),
-		PURL:        c.purlGenerator.Generate("curl", release.Version, sha, depURL),
-		Licenses:    licenses,
+		Version:      release.Version,
+		URI:          depURL,
+		SHA256:       sha,
+		Checksum:     prefixedChecksum(checksums),
+		Checksums:    checksums,
+		ReleaseDate:  &release.Date,
+		CPE:          cpe.Application("haxx", "curl", release.Version).String(),
+		PURL:         c.purlGenerator.Generate(purl.Generic("curl", release.Version).WithSource(sha, depURL)),
+		Licenses:     licenses,
+		Verification: verification,
 	}, nil
 }
 
-func (c Curl) getDependencySHA(release CurlRelease) (string, error) {
-	dependencyURL := c.dependencyURL(release)
-	dependencyOutputDir, err := os.MkdirTemp("", "curl")
-	if err != nil {
-		return "", fmt.Errorf("failed to create temp directory: %w", err)
-	}
-	dependencyOutputPath := filepath.Join(dependencyOutputDir, filepath.Base(dependencyURL))
-
-	err = c.webClient.Download(dependencyURL, dependencyOutputPath)
-	if err != nil {
-		return "", fmt.Errorf("could not download dependency: %w", err)
-	}
-
+func (c Curl) getDependencyChecksums(release CurlRelease, dependencyOutputPath string) (map[string]string, *Verification, error) {
+	verification := skippedVerification("curl does not publish signatures for versions before 7.30.0")
 	if c.hasSignatureFile(release) {
-		curlGPGKey, err := c.webClient.Get("https://daniel.haxx.se/mykey.asc")
+		keyring, err := fetchKeys(c.cache, c.webClient, c.keyring)
 		if err != nil {
-			return "", fmt.Errorf("could not get curl GPG key: %w", err)
+			return nil, nil, fmt.Errorf("could not get curl GPG key: %w", err)
 		}
 
-		dependencySignature, err := c.webClient.Get(c.dependencySignatureURL(release.Version))
+		signatureURL := c.dependencySignatureURL(release.Version)
+		dependencySignature, err := c.webClient.Get(signatureURL)
 		if err != nil {
-			return "", fmt.Errorf("could not get dependency signature: %w", err)
+			return nil, nil, fmt.Errorf("could not get dependency signature: %w", err)
 		}
 
-		err = c.checksummer.VerifyASC(string(dependencySignature), dependencyOutputPath, string(curlGPGKey))
+		fingerprint, err := c.checksummer.VerifyASC(string(dependencySignature), dependencyOutputPath, keyring)
 		if err != nil {
-			return "", fmt.Errorf("dependency signature verification failed: %w", err)
+			return nil, nil, fmt.Errorf("dependency signature verification failed: %w", err)
 		}
+		verification = signatureVerification(signatureURL, fingerprint)
 	}
 
-	dependencySHA, err := c.checksummer.GetSHA256(dependencyOutputPath)
+	checksums, err := c.checksummer.GetChecksums(dependencyOutputPath, checksumAlgorithms...)
 	if err != nil {
-		return "", fmt.Errorf("could not get SHA256: %w", err)
+		return nil, nil, fmt.Errorf("could not get SHA256: %w", err)
 	}
 
-	return dependencySHA, nil
+	return checksums, verification, nil
 }
 
 func (c Curl) hasSignatureFile(release CurlRelease) bool {
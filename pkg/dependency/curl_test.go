@@ -22,6 +22,7 @@ func testCurl(t *testing.T, when spec.G, it spec.S) {
 		require              = require.New(t)
 		fakeChecksummer      *dependencyfakes.FakeChecksummer
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		curl                 dependency.Dependency
@@ -29,12 +30,15 @@ func testCurl(t *testing.T, when spec.G, it spec.S) {
 
 	it.Before(func() {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
+		fakeChecksummer.VerifyASCReturns("some-fingerprint", nil)
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		curl, err = dependency.NewCustomDependencyFactory(fakeChecksummer, nil, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("curl")
+		curl, err = dependency.NewCustomDependencyFactory(fakeChecksummer, nil, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("curl")
 		require.NoError(err)
 	})
 
@@ -73,7 +77,7 @@ func testCurl(t *testing.T, when spec.G, it spec.S) {
 `), nil)
 			fakeWebClient.GetReturnsOnCall(1, []byte("some-gpg-key"), nil)
 			fakeWebClient.GetReturnsOnCall(2, []byte("some-signature"), nil)
-			fakeChecksummer.GetSHA256Returns("some-source-sha", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha", "sha512": "some-sha512"}, nil)
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/curl@7.73.0?checksum=some-source-sha&download_url=https://curl.se")
 
@@ -85,13 +89,19 @@ func testCurl(t *testing.T, when spec.G, it spec.S) {
 
 			expectedReleaseDate := time.Date(2020, 10, 14, 0, 0, 0, 0, time.UTC)
 			expectedDep := dependency.DepVersion{
-				Version:     "7.73.0",
-				URI:         "https://curl.se/download/curl-7.73.0.tar.gz",
-				SHA256:      "some-source-sha",
-				ReleaseDate: &expectedReleaseDate,
-				CPE:         "cpe:2.3:a:haxx:curl:7.73.0:*:*:*:*:*:*:*",
-				PURL:        "pkg:generic/curl@7.73.0?checksum=some-source-sha&download_url=https://curl.se",
-				Licenses:    []string{"MIT", "MIT-2"},
+				Version:  "7.73.0",
+				URI:      "https://curl.se/download/curl-7.73.0.tar.gz",
+				SHA256:   "some-source-sha",
+				Checksum: "sha256:some-source-sha",
+				Checksums: map[string]string{
+					"sha256": "some-source-sha",
+					"sha512": "some-sha512",
+				},
+				ReleaseDate:  &expectedReleaseDate,
+				CPE:          "cpe:2.3:a:haxx:curl:7.73.0:*:*:*:*:*:*:*",
+				PURL:         "pkg:generic/curl@7.73.0?checksum=some-source-sha&download_url=https://curl.se",
+				Licenses:     []string{"MIT", "MIT-2"},
+				Verification: &dependency.Verification{Method: dependency.VerificationPGP, SourceURL: "https://curl.se/download/curl-7.73.0.tar.gz.asc", Fingerprint: "some-fingerprint"},
 			}
 
 			assert.Equal(expectedDep, actualDep)
@@ -105,12 +115,12 @@ func testCurl(t *testing.T, when spec.G, it spec.S) {
 			urlArg, _ = fakeWebClient.GetArgsForCall(2)
 			assert.Equal("https://curl.se/download/curl-7.73.0.tar.gz.asc", urlArg)
 
-			urlArg, _, _ = fakeWebClient.DownloadArgsForCall(0)
+			urlArg, _ = fakeArtifactStore.FetchArgsForCall(0)
 			assert.Equal("https://curl.se/download/curl-7.73.0.tar.gz", urlArg)
 
 			releaseAssetSignatureArg, _, curlGPGKeyArg := fakeChecksummer.VerifyASCArgsForCall(0)
 			assert.Equal("some-signature", releaseAssetSignatureArg)
-			assert.Equal([]string{"some-gpg-key"}, curlGPGKeyArg)
+			assert.Equal([]string{"some-gpg-key"}, curlGPGKeyArg.Keys)
 		})
 
 		when("the version is older then 7.3.30", func() {
@@ -121,7 +131,7 @@ func testCurl(t *testing.T, when spec.G, it spec.S) {
 64;7.29.0;52;2013-02-06;7.8 years;78;2941;35;4491;10;305;
 65;7.28.1;52;2012-11-20;8.1 years;41;2982;31;4522;3;308;
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-source-sha", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/curl@7.29.0?checksum=some-source-sha&download_url=https://curl.se")
 
@@ -133,13 +143,19 @@ func testCurl(t *testing.T, when spec.G, it spec.S) {
 
 				expectedReleaseDate := time.Date(2013, 02, 06, 0, 0, 0, 0, time.UTC)
 				expectedDep := dependency.DepVersion{
-					Version:     "7.29.0",
-					URI:         "https://curl.se/download/archeology/curl-7.29.0.tar.gz",
-					SHA256:      "some-source-sha",
-					ReleaseDate: &expectedReleaseDate,
-					CPE:         "cpe:2.3:a:haxx:curl:7.29.0:*:*:*:*:*:*:*",
-					PURL:        "pkg:generic/curl@7.29.0?checksum=some-source-sha&download_url=https://curl.se",
-					Licenses:    []string{"MIT", "MIT-2"},
+					Version:  "7.29.0",
+					URI:      "https://curl.se/download/archeology/curl-7.29.0.tar.gz",
+					SHA256:   "some-source-sha",
+					Checksum: "sha256:some-source-sha",
+					Checksums: map[string]string{
+						"sha256": "some-source-sha",
+						"sha512": "some-sha512",
+					},
+					ReleaseDate:  &expectedReleaseDate,
+					CPE:          "cpe:2.3:a:haxx:curl:7.29.0:*:*:*:*:*:*:*",
+					PURL:         "pkg:generic/curl@7.29.0?checksum=some-source-sha&download_url=https://curl.se",
+					Licenses:     []string{"MIT", "MIT-2"},
+					Verification: &dependency.Verification{Method: dependency.VerificationNone, SkipReason: "curl does not publish signatures for versions before 7.30.0"},
 				}
 
 				assert.Equal(expectedDep, actualDep)
@@ -17,35 +17,59 @@ type Dependency interface {
 }
 
 type DepVersion struct {
-	Version         string     `json:"version"`
-	URI             string     `json:"uri"`
-	SHA256          string     `json:"sha256"`
-	ReleaseDate     *time.Time `json:"release_date,omitempty"`
-	DeprecationDate *time.Time `json:"deprecation_date,omitempty"`
-	CPE             string     `json:"cpe"`
-	PURL            string     `json:"purl"`
-	Licenses        []string   `json:"licenses"`
+	Version         string            `json:"version"`
+	URI             string            `json:"uri"`
+	SHA256          string            `json:"sha256"`
+	Checksum        string            `json:"checksum,omitempty"`
+	Checksums       map[string]string `json:"checksums,omitempty"`
+	ReleaseDate     *time.Time        `json:"release_date,omitempty"`
+	DeprecationDate *time.Time        `json:"deprecation_date,omitempty"`
+	CPE             string            `json:"cpe"`
+	PURL            string            `json:"purl"`
+	Licenses        []string          `json:"licenses"`
+	// LicenseExpression is the SPDX license expression of the version, when
+	// it is known how its licenses combine.
+	LicenseExpression string `json:"license_expression,omitempty"`
+	// LicenseSources records how each of the licenses was found.
+	LicenseSources map[string]LicenseSource `json:"license_sources,omitempty"`
+	Artifacts      []Artifact               `json:"artifacts,omitempty"`
+	Verification   *Verification            `json:"verification,omitempty"`
+}
+
+// Artifact is a prebuilt, platform-specific distribution of a dependency
+// version published by the upstream alongside its source. Arch uses the OCI
+// platform names (amd64, arm64), with the variant for 32-bit ARM (arm/v6,
+// arm/v7), and Libc is only set when the artifact is linked against a
+// specific C library. Checksum is prefixed with its
+// algorithm, e.g. "sha512:...".
+type Artifact struct {
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Libc     string `json:"libc,omitempty"`
+	URI      string `json:"uri"`
+	Checksum string `json:"checksum"`
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Checksummer
 type Checksummer interface {
-	VerifyASC(asc, path string, pgpKeys ...string) error
+	VerifyASC(asc, path string, keyring Keyring) (fingerprint string, err error)
 	VerifyMD5(path, md5 string) error
 	VerifySHA1(path, sha string) error
 	VerifySHA256(path, sha string) error
 	VerifySHA512(path, sha string) error
 	GetSHA256(path string) (string, error)
+	GetChecksums(path string, algorithms ...string) (map[string]string, error)
 	SplitPGPKeys(block string) []string
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . LicenseRetriever
 type LicenseRetriever interface {
-	LookupLicenses(dependencyName, sourceURL string) ([]string, error)
+	LookupLicenses(dependencyName, artifactPath string) ([]string, error)
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . PURLGenerator
 type PURLGenerator interface {
-	Generate(dependencyName, dependencyVersion, sourceSHA, sourceURL string) string
+	Generate(pkg purl.Package) string
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . FileSystem
@@ -62,6 +86,7 @@ type GithubClient interface {
 	DownloadSourceTarball(org, repo, version, outputPath string) (url string, err error)
 	GetTagCommit(org, repo, version string) (internal.GithubTagCommit, error)
 	GetReleaseDate(org, repo, tag string) (*time.Time, error)
+	GetLicense(org, repo, ref string) (string, error)
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . WebClient
@@ -70,6 +95,25 @@ type WebClient interface {
 	Get(url string, options ...internal.RequestOption) ([]byte, error)
 }
 
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . Cache
+type Cache interface {
+	Memoize(key string, fetch func() (interface{}, error)) (interface{}, error)
+}
+
+// ArtifactStore holds local copies of downloaded artifacts so that
+// checksums, signatures and licenses are all computed from a single download.
+// Every path returned by Fetch must be given back to Release.
+//
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . ArtifactStore
+type ArtifactStore interface {
+	Fetch(url string, options ...internal.RequestOption) (path string, err error)
+	Release(path string)
+}
+
+// DefaultCacheTTL bounds how long upstream indexes fetched by a
+// DepFactory created with NewDependencyFactory are reused.
+const DefaultCacheTTL = 15 * time.Minute
+
 type DepFactory struct {
 	checksummer      Checksummer
 	fileSystem       FileSystem
@@ -77,35 +121,215 @@ type DepFactory struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
+
+	tempDirs           internal.TempDirs
+	maxDownloadSizes   map[string]int64
+	urlPolicies        map[string]URLPolicy
+	keyrings           map[string]Keyring
+	checksumExceptions ChecksumExceptions
+	licenseOverrides   LicenseOverrides
+
+	webClientOptions        []internal.WebClientOption
+	licenseRetrieverOptions []licenses.LicenseRetrieverOption
 }
 
-func NewCustomDependencyFactory(checksum Checksummer, fileSystem FileSystem, githubClient GithubClient, webClient WebClient, licenseRetriever LicenseRetriever, purlGenerator PURLGenerator) DepFactory {
-	return DepFactory{
-		checksummer:      checksum,
-		fileSystem:       fileSystem,
-		githubClient:     githubClient,
-		webClient:        webClient,
-		licenseRetriever: licenseRetriever,
-		purlGenerator:    purlGenerator,
+type DepFactoryOption func(*DepFactory)
+
+// WithCache shares the given cache between all the dependencies created by
+// the factory, so upstream indexes are fetched once per cache entry rather
+// than once per call.
+func WithCache(cache Cache) DepFactoryOption {
+	return func(d *DepFactory) {
+		d.cache = cache
+	}
+}
+
+// WithArtifactStore replaces the store artifacts are downloaded into. By
+// default artifacts are downloaded through the factory's web client into a
+// temporary directory.
+func WithArtifactStore(artifactStore ArtifactStore) DepFactoryOption {
+	return func(d *DepFactory) {
+		d.artifactStore = artifactStore
+	}
+}
+
+// WithHTTPCache keeps upstream responses in dir so that they are reused
+// between runs. Responses are revalidated with the upstream unless they are
+// versioned artifacts, and the least recently used ones are removed once the
+// cache grows beyond maxSize bytes. It only applies to factories created
+// with NewDependencyFactory.
+func WithHTTPCache(dir string, maxSize int64) DepFactoryOption {
+	return func(d *DepFactory) {
+		httpCache := internal.NewHTTPCache(dir, maxSize, internal.DefaultImmutableURLs...)
+		d.webClientOptions = append(d.webClientOptions, internal.WithHTTPCache(httpCache))
+	}
+}
+
+// DefaultMaxDownloadSize bounds the size of files downloaded by factories
+// created with NewDependencyFactory unless WithMaxDownloadSize is given.
+const DefaultMaxDownloadSize = 1 << 30
+
+// WithMaxDownloadSize replaces the maximum size of downloaded files. A size
+// of zero or less lifts the limit. It only applies to factories created with
+// NewDependencyFactory.
+func WithMaxDownloadSize(size int64) DepFactoryOption {
+	return func(d *DepFactory) {
+		d.webClientOptions = append(d.webClientOptions, internal.WithMaxDownloadSize(size))
+	}
+}
+
+// WithDependencyMaxDownloadSize overrides the maximum size of the artifacts
+// fetched for the named dependency.
+func WithDependencyMaxDownloadSize(name string, size int64) DepFactoryOption {
+	return func(d *DepFactory) {
+		maxDownloadSizes := map[string]int64{}
+		for n, s := range d.maxDownloadSizes {
+			maxDownloadSizes[n] = s
+		}
+		maxDownloadSizes[name] = size
+		d.maxDownloadSizes = maxDownloadSizes
+	}
+}
+
+// RetryPolicy controls how requests to upstreams are retried when they fail
+// with a network error or a 5xx or 429 response.
+type RetryPolicy = internal.RetryPolicy
+
+// DefaultRetryPolicy is used by factories created with NewDependencyFactory
+// unless WithRetryPolicy is given.
+var DefaultRetryPolicy = internal.DefaultRetryPolicy
+
+// WithRetryPolicy replaces the policy used to retry failed requests. It only
+// applies to factories created with NewDependencyFactory.
+func WithRetryPolicy(policy RetryPolicy) DepFactoryOption {
+	return func(d *DepFactory) {
+		d.webClientOptions = append(d.webClientOptions, internal.WithRetryPolicy(policy))
+	}
+}
+
+// WithHostRetryPolicy overrides the retry policy for requests to host. It
+// only applies to factories created with NewDependencyFactory.
+func WithHostRetryPolicy(host string, policy RetryPolicy) DepFactoryOption {
+	return func(d *DepFactory) {
+		d.webClientOptions = append(d.webClientOptions, internal.WithHostRetryPolicy(host, policy))
+	}
+}
+
+// WithSecrets removes the given secrets from the errors returned by the
+// factory's web client. The GitHub access token is always removed. It only
+// applies to factories created with NewDependencyFactory.
+func WithSecrets(secrets ...string) DepFactoryOption {
+	return func(d *DepFactory) {
+		d.webClientOptions = append(d.webClientOptions, internal.WithSecrets(secrets...))
+	}
+}
+
+// WithLicenseConfidenceThreshold excludes the license files matched with a
+// confidence, from 0 to 1, below threshold when scanning artifacts. It only
+// applies to factories created with NewDependencyFactory.
+func WithLicenseConfidenceThreshold(threshold float32) DepFactoryOption {
+	return func(d *DepFactory) {
+		d.licenseRetrieverOptions = append(d.licenseRetrieverOptions, licenses.WithConfidenceThreshold(threshold))
+	}
+}
+
+func NewCustomDependencyFactory(checksum Checksummer, fileSystem FileSystem, githubClient GithubClient, webClient WebClient, licenseRetriever LicenseRetriever, purlGenerator PURLGenerator, options ...DepFactoryOption) DepFactory {
+	tempDirs := internal.NewTempDirs()
+
+	factory := DepFactory{
+		checksummer:        checksum,
+		fileSystem:         fileSystem,
+		githubClient:       githubClient,
+		webClient:          webClient,
+		licenseRetriever:   licenseRetriever,
+		purlGenerator:      purlGenerator,
+		cache:              internal.NewCache(0),
+		artifactStore:      internal.NewArtifactStore(webClient, tempDirs),
+		tempDirs:           tempDirs,
+		keyrings:           internal.DefaultKeyrings(),
+		checksumExceptions: internal.DefaultChecksumExceptions(),
+		licenseOverrides:   internal.DefaultLicenseOverrides(),
 	}
+
+	for _, option := range options {
+		option(&factory)
+	}
+
+	return factory
 }
 
-func NewDependencyFactory(accessToken string) DepFactory {
+func NewDependencyFactory(accessToken string, options ...DepFactoryOption) DepFactory {
 	checksummer := internal.NewChecksummer()
 	fileSystem := internal.NewFileSystem()
-	webClient := internal.NewWebClient()
-	githubClient := internal.NewGithubClient(webClient, accessToken)
-	licenseRetriever := licenses.NewLicenseRetriever()
 	purlGenerator := purl.NewPURLGenerator()
 
-	return DepFactory{
-		checksummer:      checksummer,
-		fileSystem:       fileSystem,
-		githubClient:     githubClient,
-		webClient:        webClient,
-		licenseRetriever: licenseRetriever,
-		purlGenerator:    purlGenerator,
+	factory := DepFactory{
+		checksummer:        checksummer,
+		fileSystem:         fileSystem,
+		purlGenerator:      purlGenerator,
+		cache:              internal.NewCache(DefaultCacheTTL),
+		tempDirs:           internal.NewTempDirs(),
+		urlPolicies:        defaultURLPolicies,
+		keyrings:           internal.DefaultKeyrings(),
+		checksumExceptions: internal.DefaultChecksumExceptions(),
+		licenseOverrides:   internal.DefaultLicenseOverrides(),
+		webClientOptions: []internal.WebClientOption{
+			internal.WithRetryPolicy(DefaultRetryPolicy),
+			internal.WithMaxDownloadSize(DefaultMaxDownloadSize),
+			internal.WithURLPolicy(URLPolicy{}),
+			internal.WithSecrets(accessToken),
+		},
 	}
+
+	for _, option := range options {
+		option(&factory)
+	}
+
+	factory.licenseRetriever = licenses.NewLicenseRetriever(factory.licenseRetrieverOptions...)
+
+	webClient := internal.NewWebClient(factory.webClientOptions...)
+	factory.webClient = webClient
+	factory.githubClient = internal.NewGithubClient(webClient, accessToken)
+
+	if factory.artifactStore == nil {
+		factory.artifactStore = internal.NewArtifactStore(webClient, factory.tempDirs)
+	}
+
+	return factory
+}
+
+// Close removes any temporary directories still left behind by the
+// dependencies created by the factory.
+func (d DepFactory) Close() error {
+	return d.tempDirs.RemoveAll()
+}
+
+// artifactStoreFor returns the artifact store for the named dependency,
+// applying its maximum download size and URL policy if it has them.
+func (d DepFactory) artifactStoreFor(name string) ArtifactStore {
+	artifactStore := d.artifactStore
+
+	if maxSize, ok := d.maxDownloadSizes[name]; ok {
+		artifactStore = sizeLimitedArtifactStore{ArtifactStore: artifactStore, maxSize: maxSize}
+	}
+
+	if policy, ok := d.urlPolicies[name]; ok {
+		artifactStore = urlPolicyArtifactStore{ArtifactStore: artifactStore, policy: policy}
+	}
+
+	return artifactStore
+}
+
+// sizeLimitedArtifactStore fetches artifacts with a maximum download size.
+type sizeLimitedArtifactStore struct {
+	ArtifactStore
+	maxSize int64
+}
+
+func (s sizeLimitedArtifactStore) Fetch(url string, options ...internal.RequestOption) (string, error) {
+	return s.ArtifactStore.Fetch(url, append(options, internal.WithMaxSize(s.maxSize))...)
 }
 
 func (d DepFactory) SupportsDependency(name string) bool {
@@ -119,144 +343,200 @@ func (d DepFactory) SupportsDependency(name string) bool {
 }
 
 func (d DepFactory) NewDependency(name string) (Dependency, error) {
+	dependency, err := d.newDependency(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return licenseOverridingDependency{Dependency: dependency, name: name, overrides: d.licenseOverrides}, nil
+}
+
+func (d DepFactory) newDependency(name string) (Dependency, error) {
+	artifactStore := d.artifactStoreFor(name)
+	webClient := d.webClientFor(name)
+
 	switch name {
 	case "apc", "apcu":
 		return Pecl{
 			productName:      name,
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
 		}, nil
 	case "bundler":
 		return Bundler{
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
 		}, nil
 	case "composer":
 		return Composer{
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
 			githubClient:     d.githubClient,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
+			keyring:          d.keyrings["composer"],
 		}, nil
 	case "curl":
 		return Curl{
 			checksummer:      d.checksummer,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
+			keyring:          d.keyrings["curl"],
 		}, nil
 	case "dotnet-aspnetcore":
 		return DotnetASPNETCore{
 			checksummer:      d.checksummer,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
 		}, nil
 	case "dotnet-runtime":
 		return DotnetRuntime{
 			checksummer:      d.checksummer,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
 		}, nil
 	case "dotnet-sdk":
 		return DotnetSDK{
 			checksummer:      d.checksummer,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
 		}, nil
 	case "go":
 		return Go{
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
 		}, nil
 	case "httpd":
 		return Httpd{
-			checksummer:      d.checksummer,
-			fileSystem:       d.fileSystem,
-			webClient:        d.webClient,
-			licenseRetriever: d.licenseRetriever,
-			purlGenerator:    d.purlGenerator,
+			checksummer:        d.checksummer,
+			fileSystem:         d.fileSystem,
+			webClient:          webClient,
+			licenseRetriever:   d.licenseRetriever,
+			purlGenerator:      d.purlGenerator,
+			cache:              d.cache,
+			artifactStore:      artifactStore,
+			checksumExceptions: d.checksumExceptions,
+			keyring:            d.keyrings["httpd"],
 		}, nil
 	case "icu":
 		return ICU{
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
 			githubClient:     d.githubClient,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
+			tempDirs:         d.tempDirs,
+			keyring:          d.keyrings["icu"],
 		}, nil
 	case "nginx":
 		return Nginx{
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
 			githubClient:     d.githubClient,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
+			keyring:          d.keyrings["nginx"],
 		}, nil
 	case "node":
 		return Node{
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
 		}, nil
 	case "php":
 		return Php{
-			checksummer:      d.checksummer,
-			fileSystem:       d.fileSystem,
-			webClient:        d.webClient,
-			licenseRetriever: d.licenseRetriever,
-			purlGenerator:    d.purlGenerator,
+			checksummer:        d.checksummer,
+			fileSystem:         d.fileSystem,
+			webClient:          webClient,
+			licenseRetriever:   d.licenseRetriever,
+			purlGenerator:      d.purlGenerator,
+			cache:              d.cache,
+			artifactStore:      artifactStore,
+			checksumExceptions: d.checksumExceptions,
 		}, nil
 	case "pip", "pipenv", "poetry":
 		return PyPi{
 			productName:      name,
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
 		}, nil
 	case "python":
 		return Python{
-			checksummer:      d.checksummer,
-			fileSystem:       d.fileSystem,
-			webClient:        d.webClient,
-			licenseRetriever: d.licenseRetriever,
-			purlGenerator:    d.purlGenerator,
+			checksummer:        d.checksummer,
+			fileSystem:         d.fileSystem,
+			webClient:          webClient,
+			licenseRetriever:   d.licenseRetriever,
+			purlGenerator:      d.purlGenerator,
+			cache:              d.cache,
+			artifactStore:      artifactStore,
+			checksumExceptions: d.checksumExceptions,
 		}, nil
 	case "ruby":
 		return Ruby{
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
 		}, nil
 	case "rust":
 		return Rust{
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
 			githubClient:     d.githubClient,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
+			keyring:          d.keyrings["rust"],
 		}, nil
 	case "tini":
 		return Tini{
@@ -264,15 +544,22 @@ func (d DepFactory) NewDependency(name string) (Dependency, error) {
 			githubClient:     d.githubClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
+			tempDirs:         d.tempDirs,
 		}, nil
 	case "yarn":
 		return Yarn{
 			checksummer:      d.checksummer,
 			fileSystem:       d.fileSystem,
 			githubClient:     d.githubClient,
-			webClient:        d.webClient,
+			webClient:        webClient,
 			licenseRetriever: d.licenseRetriever,
 			purlGenerator:    d.purlGenerator,
+			cache:            d.cache,
+			artifactStore:    artifactStore,
+			tempDirs:         d.tempDirs,
+			keyring:          d.keyrings["yarn"],
 		}, nil
 	default:
 		return nil, fmt.Errorf("dependency type '%s' is not supported", name)
@@ -0,0 +1,118 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dependencyfakes
+
+import (
+	"sync"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+)
+
+type FakeCache struct {
+	MemoizeStub        func(string, func() (interface{}, error)) (interface{}, error)
+	memoizeMutex       sync.RWMutex
+	memoizeArgsForCall []struct {
+		arg1 string
+		arg2 func() (interface{}, error)
+	}
+	memoizeReturns struct {
+		result1 interface{}
+		result2 error
+	}
+	memoizeReturnsOnCall map[int]struct {
+		result1 interface{}
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCache) Memoize(arg1 string, arg2 func() (interface{}, error)) (interface{}, error) {
+	fake.memoizeMutex.Lock()
+	ret, specificReturn := fake.memoizeReturnsOnCall[len(fake.memoizeArgsForCall)]
+	fake.memoizeArgsForCall = append(fake.memoizeArgsForCall, struct {
+		arg1 string
+		arg2 func() (interface{}, error)
+	}{arg1, arg2})
+	stub := fake.MemoizeStub
+	fakeReturns := fake.memoizeReturns
+	fake.recordInvocation("Memoize", []interface{}{arg1, arg2})
+	fake.memoizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCache) MemoizeCallCount() int {
+	fake.memoizeMutex.RLock()
+	defer fake.memoizeMutex.RUnlock()
+	return len(fake.memoizeArgsForCall)
+}
+
+func (fake *FakeCache) MemoizeCalls(stub func(string, func() (interface{}, error)) (interface{}, error)) {
+	fake.memoizeMutex.Lock()
+	defer fake.memoizeMutex.Unlock()
+	fake.MemoizeStub = stub
+}
+
+func (fake *FakeCache) MemoizeArgsForCall(i int) (string, func() (interface{}, error)) {
+	fake.memoizeMutex.RLock()
+	defer fake.memoizeMutex.RUnlock()
+	argsForCall := fake.memoizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCache) MemoizeReturns(result1 interface{}, result2 error) {
+	fake.memoizeMutex.Lock()
+	defer fake.memoizeMutex.Unlock()
+	fake.MemoizeStub = nil
+	fake.memoizeReturns = struct {
+		result1 interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCache) MemoizeReturnsOnCall(i int, result1 interface{}, result2 error) {
+	fake.memoizeMutex.Lock()
+	defer fake.memoizeMutex.Unlock()
+	fake.MemoizeStub = nil
+	if fake.memoizeReturnsOnCall == nil {
+		fake.memoizeReturnsOnCall = make(map[int]struct {
+			result1 interface{}
+			result2 error
+		})
+	}
+	fake.memoizeReturnsOnCall[i] = struct {
+		result1 interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCache) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.memoizeMutex.RLock()
+	defer fake.memoizeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCache) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ dependency.Cache = new(FakeCache)
@@ -8,6 +8,20 @@ import (
 )
 
 type FakeChecksummer struct {
+	GetChecksumsStub        func(string, ...string) (map[string]string, error)
+	getChecksumsMutex       sync.RWMutex
+	getChecksumsArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	getChecksumsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	getChecksumsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
 	GetSHA256Stub        func(string) (string, error)
 	getSHA256Mutex       sync.RWMutex
 	getSHA256ArgsForCall []struct {
@@ -32,18 +46,20 @@ type FakeChecksummer struct {
 	splitPGPKeysReturnsOnCall map[int]struct {
 		result1 []string
 	}
-	VerifyASCStub        func(string, string, ...string) error
+	VerifyASCStub        func(string, string, dependency.Keyring) (string, error)
 	verifyASCMutex       sync.RWMutex
 	verifyASCArgsForCall []struct {
 		arg1 string
 		arg2 string
-		arg3 []string
+		arg3 dependency.Keyring
 	}
 	verifyASCReturns struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	verifyASCReturnsOnCall map[int]struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	VerifyMD5Stub        func(string, string) error
 	verifyMD5Mutex       sync.RWMutex
@@ -97,21 +113,87 @@ type FakeChecksummer struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeChecksummer) GetChecksums(arg1 string, arg2 ...string) (map[string]string, error) {
+	fake.getChecksumsMutex.Lock()
+	ret, specificReturn := fake.getChecksumsReturnsOnCall[len(fake.getChecksumsArgsForCall)]
+	fake.getChecksumsArgsForCall = append(fake.getChecksumsArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2})
+	stub := fake.GetChecksumsStub
+	fakeReturns := fake.getChecksumsReturns
+	fake.recordInvocation("GetChecksums", []interface{}{arg1, arg2})
+	fake.getChecksumsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeChecksummer) GetChecksumsCallCount() int {
+	fake.getChecksumsMutex.RLock()
+	defer fake.getChecksumsMutex.RUnlock()
+	return len(fake.getChecksumsArgsForCall)
+}
+
+func (fake *FakeChecksummer) GetChecksumsCalls(stub func(string, ...string) (map[string]string, error)) {
+	fake.getChecksumsMutex.Lock()
+	defer fake.getChecksumsMutex.Unlock()
+	fake.GetChecksumsStub = stub
+}
+
+func (fake *FakeChecksummer) GetChecksumsArgsForCall(i int) (string, []string) {
+	fake.getChecksumsMutex.RLock()
+	defer fake.getChecksumsMutex.RUnlock()
+	argsForCall := fake.getChecksumsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeChecksummer) GetChecksumsReturns(result1 map[string]string, result2 error) {
+	fake.getChecksumsMutex.Lock()
+	defer fake.getChecksumsMutex.Unlock()
+	fake.GetChecksumsStub = nil
+	fake.getChecksumsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeChecksummer) GetChecksumsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.getChecksumsMutex.Lock()
+	defer fake.getChecksumsMutex.Unlock()
+	fake.GetChecksumsStub = nil
+	if fake.getChecksumsReturnsOnCall == nil {
+		fake.getChecksumsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.getChecksumsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeChecksummer) GetSHA256(arg1 string) (string, error) {
 	fake.getSHA256Mutex.Lock()
 	ret, specificReturn := fake.getSHA256ReturnsOnCall[len(fake.getSHA256ArgsForCall)]
 	fake.getSHA256ArgsForCall = append(fake.getSHA256ArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.GetSHA256Stub
+	fakeReturns := fake.getSHA256Returns
 	fake.recordInvocation("GetSHA256", []interface{}{arg1})
 	fake.getSHA256Mutex.Unlock()
-	if fake.GetSHA256Stub != nil {
-		return fake.GetSHA256Stub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getSHA256Returns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -166,15 +248,16 @@ func (fake *FakeChecksummer) SplitPGPKeys(arg1 string) []string {
 	fake.splitPGPKeysArgsForCall = append(fake.splitPGPKeysArgsForCall, struct {
 		arg1 string
 	}{arg1})
+	stub := fake.SplitPGPKeysStub
+	fakeReturns := fake.splitPGPKeysReturns
 	fake.recordInvocation("SplitPGPKeys", []interface{}{arg1})
 	fake.splitPGPKeysMutex.Unlock()
-	if fake.SplitPGPKeysStub != nil {
-		return fake.SplitPGPKeysStub(arg1)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.splitPGPKeysReturns
 	return fakeReturns.result1
 }
 
@@ -220,24 +303,25 @@ func (fake *FakeChecksummer) SplitPGPKeysReturnsOnCall(i int, result1 []string)
 	}{result1}
 }
 
-func (fake *FakeChecksummer) VerifyASC(arg1 string, arg2 string, arg3 ...string) error {
+func (fake *FakeChecksummer) VerifyASC(arg1 string, arg2 string, arg3 dependency.Keyring) (string, error) {
 	fake.verifyASCMutex.Lock()
 	ret, specificReturn := fake.verifyASCReturnsOnCall[len(fake.verifyASCArgsForCall)]
 	fake.verifyASCArgsForCall = append(fake.verifyASCArgsForCall, struct {
 		arg1 string
 		arg2 string
-		arg3 []string
+		arg3 dependency.Keyring
 	}{arg1, arg2, arg3})
+	stub := fake.VerifyASCStub
+	fakeReturns := fake.verifyASCReturns
 	fake.recordInvocation("VerifyASC", []interface{}{arg1, arg2, arg3})
 	fake.verifyASCMutex.Unlock()
-	if fake.VerifyASCStub != nil {
-		return fake.VerifyASCStub(arg1, arg2, arg3...)
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.verifyASCReturns
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeChecksummer) VerifyASCCallCount() int {
@@ -246,40 +330,43 @@ func (fake *FakeChecksummer) VerifyASCCallCount() int {
 	return len(fake.verifyASCArgsForCall)
 }
 
-func (fake *FakeChecksummer) VerifyASCCalls(stub func(string, string, ...string) error) {
+func (fake *FakeChecksummer) VerifyASCCalls(stub func(string, string, dependency.Keyring) (string, error)) {
 	fake.verifyASCMutex.Lock()
 	defer fake.verifyASCMutex.Unlock()
 	fake.VerifyASCStub = stub
 }
 
-func (fake *FakeChecksummer) VerifyASCArgsForCall(i int) (string, string, []string) {
+func (fake *FakeChecksummer) VerifyASCArgsForCall(i int) (string, string, dependency.Keyring) {
 	fake.verifyASCMutex.RLock()
 	defer fake.verifyASCMutex.RUnlock()
 	argsForCall := fake.verifyASCArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeChecksummer) VerifyASCReturns(result1 error) {
+func (fake *FakeChecksummer) VerifyASCReturns(result1 string, result2 error) {
 	fake.verifyASCMutex.Lock()
 	defer fake.verifyASCMutex.Unlock()
 	fake.VerifyASCStub = nil
 	fake.verifyASCReturns = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeChecksummer) VerifyASCReturnsOnCall(i int, result1 error) {
+func (fake *FakeChecksummer) VerifyASCReturnsOnCall(i int, result1 string, result2 error) {
 	fake.verifyASCMutex.Lock()
 	defer fake.verifyASCMutex.Unlock()
 	fake.VerifyASCStub = nil
 	if fake.verifyASCReturnsOnCall == nil {
 		fake.verifyASCReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 string
+			result2 error
 		})
 	}
 	fake.verifyASCReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakeChecksummer) VerifyMD5(arg1 string, arg2 string) error {
@@ -289,15 +376,16 @@ func (fake *FakeChecksummer) VerifyMD5(arg1 string, arg2 string) error {
 		arg1 string
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.VerifyMD5Stub
+	fakeReturns := fake.verifyMD5Returns
 	fake.recordInvocation("VerifyMD5", []interface{}{arg1, arg2})
 	fake.verifyMD5Mutex.Unlock()
-	if fake.VerifyMD5Stub != nil {
-		return fake.VerifyMD5Stub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.verifyMD5Returns
 	return fakeReturns.result1
 }
 
@@ -350,15 +438,16 @@ func (fake *FakeChecksummer) VerifySHA1(arg1 string, arg2 string) error {
 		arg1 string
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.VerifySHA1Stub
+	fakeReturns := fake.verifySHA1Returns
 	fake.recordInvocation("VerifySHA1", []interface{}{arg1, arg2})
 	fake.verifySHA1Mutex.Unlock()
-	if fake.VerifySHA1Stub != nil {
-		return fake.VerifySHA1Stub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.verifySHA1Returns
 	return fakeReturns.result1
 }
 
@@ -411,15 +500,16 @@ func (fake *FakeChecksummer) VerifySHA256(arg1 string, arg2 string) error {
 		arg1 string
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.VerifySHA256Stub
+	fakeReturns := fake.verifySHA256Returns
 	fake.recordInvocation("VerifySHA256", []interface{}{arg1, arg2})
 	fake.verifySHA256Mutex.Unlock()
-	if fake.VerifySHA256Stub != nil {
-		return fake.VerifySHA256Stub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.verifySHA256Returns
 	return fakeReturns.result1
 }
 
@@ -472,15 +562,16 @@ func (fake *FakeChecksummer) VerifySHA512(arg1 string, arg2 string) error {
 		arg1 string
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.VerifySHA512Stub
+	fakeReturns := fake.verifySHA512Returns
 	fake.recordInvocation("VerifySHA512", []interface{}{arg1, arg2})
 	fake.verifySHA512Mutex.Unlock()
-	if fake.VerifySHA512Stub != nil {
-		return fake.VerifySHA512Stub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.verifySHA512Returns
 	return fakeReturns.result1
 }
 
@@ -529,6 +620,8 @@ func (fake *FakeChecksummer) VerifySHA512ReturnsOnCall(i int, result1 error) {
 func (fake *FakeChecksummer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.getChecksumsMutex.RLock()
+	defer fake.getChecksumsMutex.RUnlock()
 	fake.getSHA256Mutex.RLock()
 	defer fake.getSHA256Mutex.RUnlock()
 	fake.splitPGPKeysMutex.RLock()
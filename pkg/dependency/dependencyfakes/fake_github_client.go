@@ -43,6 +43,21 @@ type FakeGithubClient struct {
 		result1 string
 		result2 error
 	}
+	GetLicenseStub        func(string, string, string) (string, error)
+	getLicenseMutex       sync.RWMutex
+	getLicenseArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getLicenseReturns struct {
+		result1 string
+		result2 error
+	}
+	getLicenseReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	GetReleaseAssetStub        func(string, string, string, string) ([]byte, error)
 	getReleaseAssetMutex       sync.RWMutex
 	getReleaseAssetArgsForCall []struct {
@@ -131,15 +146,16 @@ func (fake *FakeGithubClient) DownloadReleaseAsset(arg1 string, arg2 string, arg
 		arg4 string
 		arg5 string
 	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.DownloadReleaseAssetStub
+	fakeReturns := fake.downloadReleaseAssetReturns
 	fake.recordInvocation("DownloadReleaseAsset", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.downloadReleaseAssetMutex.Unlock()
-	if fake.DownloadReleaseAssetStub != nil {
-		return fake.DownloadReleaseAssetStub(arg1, arg2, arg3, arg4, arg5)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.downloadReleaseAssetReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -197,15 +213,16 @@ func (fake *FakeGithubClient) DownloadSourceTarball(arg1 string, arg2 string, ar
 		arg3 string
 		arg4 string
 	}{arg1, arg2, arg3, arg4})
+	stub := fake.DownloadSourceTarballStub
+	fakeReturns := fake.downloadSourceTarballReturns
 	fake.recordInvocation("DownloadSourceTarball", []interface{}{arg1, arg2, arg3, arg4})
 	fake.downloadSourceTarballMutex.Unlock()
-	if fake.DownloadSourceTarballStub != nil {
-		return fake.DownloadSourceTarballStub(arg1, arg2, arg3, arg4)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.downloadSourceTarballReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -254,6 +271,72 @@ func (fake *FakeGithubClient) DownloadSourceTarballReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeGithubClient) GetLicense(arg1 string, arg2 string, arg3 string) (string, error) {
+	fake.getLicenseMutex.Lock()
+	ret, specificReturn := fake.getLicenseReturnsOnCall[len(fake.getLicenseArgsForCall)]
+	fake.getLicenseArgsForCall = append(fake.getLicenseArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetLicenseStub
+	fakeReturns := fake.getLicenseReturns
+	fake.recordInvocation("GetLicense", []interface{}{arg1, arg2, arg3})
+	fake.getLicenseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeGithubClient) GetLicenseCallCount() int {
+	fake.getLicenseMutex.RLock()
+	defer fake.getLicenseMutex.RUnlock()
+	return len(fake.getLicenseArgsForCall)
+}
+
+func (fake *FakeGithubClient) GetLicenseCalls(stub func(string, string, string) (string, error)) {
+	fake.getLicenseMutex.Lock()
+	defer fake.getLicenseMutex.Unlock()
+	fake.GetLicenseStub = stub
+}
+
+func (fake *FakeGithubClient) GetLicenseArgsForCall(i int) (string, string, string) {
+	fake.getLicenseMutex.RLock()
+	defer fake.getLicenseMutex.RUnlock()
+	argsForCall := fake.getLicenseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeGithubClient) GetLicenseReturns(result1 string, result2 error) {
+	fake.getLicenseMutex.Lock()
+	defer fake.getLicenseMutex.Unlock()
+	fake.GetLicenseStub = nil
+	fake.getLicenseReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeGithubClient) GetLicenseReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getLicenseMutex.Lock()
+	defer fake.getLicenseMutex.Unlock()
+	fake.GetLicenseStub = nil
+	if fake.getLicenseReturnsOnCall == nil {
+		fake.getLicenseReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getLicenseReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeGithubClient) GetReleaseAsset(arg1 string, arg2 string, arg3 string, arg4 string) ([]byte, error) {
 	fake.getReleaseAssetMutex.Lock()
 	ret, specificReturn := fake.getReleaseAssetReturnsOnCall[len(fake.getReleaseAssetArgsForCall)]
@@ -263,15 +346,16 @@ func (fake *FakeGithubClient) GetReleaseAsset(arg1 string, arg2 string, arg3 str
 		arg3 string
 		arg4 string
 	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetReleaseAssetStub
+	fakeReturns := fake.getReleaseAssetReturns
 	fake.recordInvocation("GetReleaseAsset", []interface{}{arg1, arg2, arg3, arg4})
 	fake.getReleaseAssetMutex.Unlock()
-	if fake.GetReleaseAssetStub != nil {
-		return fake.GetReleaseAssetStub(arg1, arg2, arg3, arg4)
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getReleaseAssetReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -328,15 +412,16 @@ func (fake *FakeGithubClient) GetReleaseDate(arg1 string, arg2 string, arg3 stri
 		arg2 string
 		arg3 string
 	}{arg1, arg2, arg3})
+	stub := fake.GetReleaseDateStub
+	fakeReturns := fake.getReleaseDateReturns
 	fake.recordInvocation("GetReleaseDate", []interface{}{arg1, arg2, arg3})
 	fake.getReleaseDateMutex.Unlock()
-	if fake.GetReleaseDateStub != nil {
-		return fake.GetReleaseDateStub(arg1, arg2, arg3)
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getReleaseDateReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -392,15 +477,16 @@ func (fake *FakeGithubClient) GetReleaseTags(arg1 string, arg2 string) ([]intern
 		arg1 string
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.GetReleaseTagsStub
+	fakeReturns := fake.getReleaseTagsReturns
 	fake.recordInvocation("GetReleaseTags", []interface{}{arg1, arg2})
 	fake.getReleaseTagsMutex.Unlock()
-	if fake.GetReleaseTagsStub != nil {
-		return fake.GetReleaseTagsStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getReleaseTagsReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -457,15 +543,16 @@ func (fake *FakeGithubClient) GetTagCommit(arg1 string, arg2 string, arg3 string
 		arg2 string
 		arg3 string
 	}{arg1, arg2, arg3})
+	stub := fake.GetTagCommitStub
+	fakeReturns := fake.getTagCommitReturns
 	fake.recordInvocation("GetTagCommit", []interface{}{arg1, arg2, arg3})
 	fake.getTagCommitMutex.Unlock()
-	if fake.GetTagCommitStub != nil {
-		return fake.GetTagCommitStub(arg1, arg2, arg3)
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getTagCommitReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -521,15 +608,16 @@ func (fake *FakeGithubClient) GetTags(arg1 string, arg2 string) ([]string, error
 		arg1 string
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.GetTagsStub
+	fakeReturns := fake.getTagsReturns
 	fake.recordInvocation("GetTags", []interface{}{arg1, arg2})
 	fake.getTagsMutex.Unlock()
-	if fake.GetTagsStub != nil {
-		return fake.GetTagsStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.getTagsReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
@@ -585,6 +673,8 @@ func (fake *FakeGithubClient) Invocations() map[string][][]interface{} {
 	defer fake.downloadReleaseAssetMutex.RUnlock()
 	fake.downloadSourceTarballMutex.RLock()
 	defer fake.downloadSourceTarballMutex.RUnlock()
+	fake.getLicenseMutex.RLock()
+	defer fake.getLicenseMutex.RUnlock()
 	fake.getReleaseAssetMutex.RLock()
 	defer fake.getReleaseAssetMutex.RUnlock()
 	fake.getReleaseDateMutex.RLock()
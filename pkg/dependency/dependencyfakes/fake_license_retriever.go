@@ -33,15 +33,16 @@ func (fake *FakeLicenseRetriever) LookupLicenses(arg1 string, arg2 string) ([]st
 		arg1 string
 		arg2 string
 	}{arg1, arg2})
+	stub := fake.LookupLicensesStub
+	fakeReturns := fake.lookupLicensesReturns
 	fake.recordInvocation("LookupLicenses", []interface{}{arg1, arg2})
 	fake.lookupLicensesMutex.Unlock()
-	if fake.LookupLicensesStub != nil {
-		return fake.LookupLicensesStub(arg1, arg2)
+	if stub != nil {
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	fakeReturns := fake.lookupLicensesReturns
 	return fakeReturns.result1, fakeReturns.result2
 }
 
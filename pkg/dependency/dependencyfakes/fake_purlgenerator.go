@@ -5,16 +5,14 @@ import (
 	"sync"
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type FakePURLGenerator struct {
-	GenerateStub        func(string, string, string, string) string
+	GenerateStub        func(purl.Package) string
 	generateMutex       sync.RWMutex
 	generateArgsForCall []struct {
-		arg1 string
-		arg2 string
-		arg3 string
-		arg4 string
+		arg1 purl.Package
 	}
 	generateReturns struct {
 		result1 string
@@ -26,24 +24,22 @@ type FakePURLGenerator struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePURLGenerator) Generate(arg1 string, arg2 string, arg3 string, arg4 string) string {
+func (fake *FakePURLGenerator) Generate(arg1 purl.Package) string {
 	fake.generateMutex.Lock()
 	ret, specificReturn := fake.generateReturnsOnCall[len(fake.generateArgsForCall)]
 	fake.generateArgsForCall = append(fake.generateArgsForCall, struct {
-		arg1 string
-		arg2 string
-		arg3 string
-		arg4 string
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("Generate", []interface{}{arg1, arg2, arg3, arg4})
+		arg1 purl.Package
+	}{arg1})
+	stub := fake.GenerateStub
+	fakeReturns := fake.generateReturns
+	fake.recordInvocation("Generate", []interface{}{arg1})
 	fake.generateMutex.Unlock()
-	if fake.GenerateStub != nil {
-		return fake.GenerateStub(arg1, arg2, arg3, arg4)
+	if stub != nil {
+		return stub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	fakeReturns := fake.generateReturns
 	return fakeReturns.result1
 }
 
@@ -53,17 +49,17 @@ func (fake *FakePURLGenerator) GenerateCallCount() int {
 	return len(fake.generateArgsForCall)
 }
 
-func (fake *FakePURLGenerator) GenerateCalls(stub func(string, string, string, string) string) {
+func (fake *FakePURLGenerator) GenerateCalls(stub func(purl.Package) string) {
 	fake.generateMutex.Lock()
 	defer fake.generateMutex.Unlock()
 	fake.GenerateStub = stub
 }
 
-func (fake *FakePURLGenerator) GenerateArgsForCall(i int) (string, string, string, string) {
+func (fake *FakePURLGenerator) GenerateArgsForCall(i int) purl.Package {
 	fake.generateMutex.RLock()
 	defer fake.generateMutex.RUnlock()
 	argsForCall := fake.generateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+	return argsForCall.arg1
 }
 
 func (fake *FakePURLGenerator) GenerateReturns(result1 string) {
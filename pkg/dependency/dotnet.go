@@ -3,8 +3,6 @@ package dependency
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
@@ -12,15 +10,33 @@ import (
 	"github.com/Masterminds/semver"
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 const (
-	DotnetReleaseIndexURL = "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/releases-index.json"
-	DotnetChannelURL      = "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/%s/releases.json"
-	DotnetLinuxFileRID    = "linux-x64"
-	DotnetUbuntuFileRID   = "ubuntu-x64"
+	DotnetReleaseIndexURL       = "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/releases-index.json"
+	DotnetChannelURL            = "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/%s/releases.json"
+	DotnetLinuxFileRID          = "linux-x64"
+	DotnetUbuntuFileRID         = "ubuntu-x64"
+	DotnetLinuxARM64FileRID     = "linux-arm64"
+	DotnetLinuxMuslFileRID      = "linux-musl-x64"
+	DotnetLinuxMuslARM64FileRID = "linux-musl-arm64"
 )
 
+// dotnetPlatforms are the runtime identifiers published as platform
+// artifacts, in the order they are listed in a DepVersion.
+var dotnetPlatforms = []struct {
+	rid  string
+	os   string
+	arch string
+	libc string
+}{
+	{rid: DotnetLinuxFileRID, os: "linux", arch: "amd64", libc: "glibc"},
+	{rid: DotnetLinuxARM64FileRID, os: "linux", arch: "arm64", libc: "glibc"},
+	{rid: DotnetLinuxMuslFileRID, os: "linux", arch: "amd64", libc: "musl"},
+	{rid: DotnetLinuxMuslARM64FileRID, os: "linux", arch: "arm64", libc: "musl"},
+}
+
 type DotnetChannel struct {
 	EOLDate  string                 `json:"eol-date"`
 	Releases []DotnetChannelRelease `json:"releases"`
@@ -65,6 +81,9 @@ type dotnetType interface {
 	getReleaseVersions(release DotnetChannelRelease) []string
 	versionShouldBeIgnored(version string) bool
 	getCPE(version string) (string, error)
+	// getPackageName returns the NuGet package that advisories about the
+	// dependency are filed against.
+	getPackageName() string
 }
 
 type dotnet struct {
@@ -73,6 +92,8 @@ type dotnet struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
 	name             string
 }
 
@@ -111,10 +132,18 @@ func (d dotnet) GetDependencyVersion(version string) (DepVersion, error) {
 		return DepVersion{}, fmt.Errorf("could not get release file: %w", err)
 	}
 
-	sha256, err := d.getReleaseFileSHA(releaseFile)
+	artifactPath, err := d.artifactStore.Fetch(releaseFile.URL)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer d.artifactStore.Release(artifactPath)
+
+	channelURL := fmt.Sprintf(DotnetChannelURL, d.dotnetType.getChannelVersion(version))
+	checksums, verification, err := d.getReleaseFileChecksums(releaseFile, channelURL, artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get sha: %w", err)
 	}
+	sha256 := checksums["sha256"]
 
 	releaseDate, err := d.dotnetType.getReleaseDate(channel, version)
 	if err != nil {
@@ -126,24 +155,28 @@ func (d dotnet) GetDependencyVersion(version string) (DepVersion, error) {
 		return DepVersion{}, fmt.Errorf("could not get cpe: %w", err)
 	}
 
-	licenses, err := d.licenseRetriever.LookupLicenses(d.name, releaseFile.URL)
+	licenses, err := d.licenseRetriever.LookupLicenses(d.name, artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get licenses: %w", err)
 	}
 
 	depVersion := DepVersion{
-		Version:     version,
-		URI:         releaseFile.URL,
-		SHA256:      sha256,
-		ReleaseDate: releaseDate,
-		CPE:         cpe,
-		PURL:        d.purlGenerator.Generate(d.name, version, sha256, releaseFile.URL),
-		Licenses:    licenses,
+		Version:      version,
+		URI:          releaseFile.URL,
+		SHA256:       sha256,
+		Checksum:     prefixedChecksum(checksums),
+		Checksums:    checksums,
+		ReleaseDate:  releaseDate,
+		CPE:          cpe,
+		PURL:         d.purlGenerator.Generate(purl.NuGet(d.dotnetType.getPackageName(), version).WithSource(sha256, releaseFile.URL)),
+		Licenses:     licenses,
+		Artifacts:    d.getArtifacts(channel, version),
+		Verification: verification,
 	}
 	if channel.EOLDate != "" {
 		deprecationDate, err := time.Parse("2006-01-02", channel.EOLDate)
 		if err != nil {
-			return DepVersion{}, fmt.Errorf("could not parse EOL date: %w", err)
+			return DepVersion{}, fmt.Errorf("could not parse EOL date: %w", errors.ParseError{Err: err})
 		}
 		depVersion.DeprecationDate = &deprecationDate
 	}
@@ -160,7 +193,7 @@ func (d dotnet) GetReleaseDate(version string) (*time.Time, error) {
 }
 
 func (d dotnet) getAllChannelVersions() ([]string, error) {
-	body, err := d.webClient.Get(DotnetReleaseIndexURL)
+	body, err := cachedGet(d.cache, d.webClient, DotnetReleaseIndexURL)
 	if err != nil {
 		return nil, fmt.Errorf("could not get releases index body: %w", err)
 	}
@@ -172,7 +205,7 @@ func (d dotnet) getAllChannelVersions() ([]string, error) {
 	}
 	err = json.Unmarshal(body, &releasesIndex)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal releases index: %w", err)
+		return nil, fmt.Errorf("could not unmarshal releases index: %w", errors.ParseError{Err: err})
 	}
 
 	var channelVersions []string
@@ -200,7 +233,7 @@ func (d dotnet) getVersionsForChannel(version string) ([]DotnetVersion, error) {
 			}
 			parsedVersion, err := semver.NewVersion(version)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse version: %w", err)
+				return nil, fmt.Errorf("failed to parse version: %w", errors.ParseError{Err: err})
 			}
 			if parsedVersion.Prerelease() != "" {
 				continue
@@ -219,7 +252,7 @@ func (d dotnet) getVersionsForChannel(version string) ([]DotnetVersion, error) {
 func (d dotnet) getChannel(version string) (DotnetChannel, error) {
 	channelVersion := d.dotnetType.getChannelVersion(version)
 
-	body, err := d.webClient.Get(fmt.Sprintf(DotnetChannelURL, channelVersion))
+	body, err := cachedGet(d.cache, d.webClient, fmt.Sprintf(DotnetChannelURL, channelVersion))
 	if err != nil {
 		return DotnetChannel{}, fmt.Errorf("could not get channel body: %w", err)
 	}
@@ -227,7 +260,7 @@ func (d dotnet) getChannel(version string) (DotnetChannel, error) {
 	var channel DotnetChannel
 	err = json.Unmarshal(body, &channel)
 	if err != nil {
-		return DotnetChannel{}, fmt.Errorf("could not unmarshal channel: %w", err)
+		return DotnetChannel{}, fmt.Errorf("could not unmarshal channel: %w", errors.ParseError{Err: err})
 	}
 
 	return channel, nil
@@ -242,12 +275,12 @@ func (d dotnet) sortVersions(versions []DotnetVersion) ([]string, error) {
 
 		semver1, err := semver.NewVersion(versions[i].Version)
 		if err != nil {
-			sortErr = fmt.Errorf("could not parse '%s' as semver", versions[i].Version)
+			sortErr = errors.ParseError{Err: fmt.Errorf("could not parse '%s' as semver", versions[i].Version)}
 			return false
 		}
 		semver2, err := semver.NewVersion(versions[j].Version)
 		if err != nil {
-			sortErr = fmt.Errorf("could not parse '%s' as semver", versions[j].Version)
+			sortErr = errors.ParseError{Err: fmt.Errorf("could not parse '%s' as semver", versions[j].Version)}
 			return false
 		}
 		return semver1.LessThan(semver2)
@@ -284,34 +317,57 @@ func (d dotnet) getReleaseFile(channel DotnetChannel, version string) (DotnetCha
 	return DotnetChannelReleaseFile{}, errors.NoSourceCodeError{Version: version}
 }
 
-func (d dotnet) getReleaseFileSHA(file DotnetChannelReleaseFile) (string, error) {
-	if len(file.Hash) == 64 {
-		return strings.ToLower(file.Hash), nil
-	}
+func (d dotnet) getArtifacts(channel DotnetChannel, version string) []Artifact {
+	files := d.dotnetType.getReleaseFiles(channel, version)
 
-	tempDir, err := os.MkdirTemp("", "nginx")
-	if err != nil {
-		return "", fmt.Errorf("could not create temp directory: %w", err)
-	}
-	defer os.RemoveAll(tempDir)
+	var artifacts []Artifact
+	for _, platform := range dotnetPlatforms {
+		for _, file := range files {
+			if file.RID != platform.rid || file.Hash == "" || !strings.HasSuffix(file.Name, ".tar.gz") {
+				continue
+			}
 
-	dependencyOutputPath := filepath.Join(tempDir, file.Name)
-	err = d.webClient.Download(file.URL, dependencyOutputPath)
-	if err != nil {
-		return "", fmt.Errorf("could not download dependency: %w", err)
+			// The release metadata publishes SHA-512 hashes, apart from some
+			// older releases which list a SHA-256 instead
+			algorithm := "sha512"
+			if len(file.Hash) == 64 {
+				algorithm = "sha256"
+			}
+
+			artifacts = append(artifacts, Artifact{
+				OS:       platform.os,
+				Arch:     platform.arch,
+				Libc:     platform.libc,
+				URI:      file.URL,
+				Checksum: fmt.Sprintf("%s:%s", algorithm, strings.ToLower(file.Hash)),
+			})
+			break
+		}
 	}
 
-	if file.Hash != "" {
-		err = d.checksummer.VerifySHA512(dependencyOutputPath, strings.ToLower(file.Hash))
+	return artifacts
+}
+
+func (d dotnet) getReleaseFileChecksums(file DotnetChannelReleaseFile, channelURL, dependencyOutputPath string) (map[string]string, *Verification, error) {
+	verification := skippedVerification("the release file does not publish a hash")
+	if len(file.Hash) == 64 {
+		err := d.checksummer.VerifySHA256(dependencyOutputPath, strings.ToLower(file.Hash))
+		if err != nil {
+			return nil, nil, fmt.Errorf("dependency signature verification failed: %w", err)
+		}
+		verification = checksumVerification(VerificationSHA256, channelURL)
+	} else if file.Hash != "" {
+		err := d.checksummer.VerifySHA512(dependencyOutputPath, strings.ToLower(file.Hash))
 		if err != nil {
-			return "", fmt.Errorf("dependency signature verification failed: %w", err)
+			return nil, nil, fmt.Errorf("dependency signature verification failed: %w", err)
 		}
+		verification = checksumVerification(VerificationSHA512, channelURL)
 	}
 
-	sha256, err := d.checksummer.GetSHA256(dependencyOutputPath)
+	checksums, err := d.checksummer.GetChecksums(dependencyOutputPath, checksumAlgorithms...)
 	if err != nil {
-		return "", fmt.Errorf("could not get SHA256: %w", err)
+		return nil, nil, fmt.Errorf("could not get SHA256: %w", err)
 	}
 
-	return sha256, nil
+	return checksums, verification, nil
 }
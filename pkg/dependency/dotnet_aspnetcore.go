@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 )
 
 type DotnetASPNETCore struct {
@@ -11,6 +14,8 @@ type DotnetASPNETCore struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
 }
 
 type dotnetASPNETCoreType struct{}
@@ -20,6 +25,7 @@ func (d DotnetASPNETCore) GetAllVersionRefs() ([]string, error) {
 		dotnetType:  dotnetASPNETCoreType{},
 		checksummer: d.checksummer,
 		webClient:   d.webClient,
+		cache:       d.cache,
 	}.GetAllVersionRefs()
 }
 
@@ -30,6 +36,8 @@ func (d DotnetASPNETCore) GetDependencyVersion(version string) (DepVersion, erro
 		webClient:        d.webClient,
 		licenseRetriever: d.licenseRetriever,
 		purlGenerator:    d.purlGenerator,
+		cache:            d.cache,
+		artifactStore:    d.artifactStore,
 		name:             "dotnet-aspnetcore",
 	}.GetDependencyVersion(version)
 }
@@ -39,6 +47,7 @@ func (d DotnetASPNETCore) GetReleaseDate(version string) (*time.Time, error) {
 		dotnetType:  dotnetASPNETCoreType{},
 		checksummer: d.checksummer,
 		webClient:   d.webClient,
+		cache:       d.cache,
 	}.GetReleaseDate(version)
 }
 
@@ -51,7 +60,7 @@ func (d dotnetASPNETCoreType) getReleaseDate(channel DotnetChannel, version stri
 		if release.ASPNETCoreRuntime.Version == version {
 			releaseDate, err := time.Parse("2006-01-02", release.ReleaseDate)
 			if err != nil {
-				return nil, fmt.Errorf("could not parse release date: %w", err)
+				return nil, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 			}
 			return &releaseDate, nil
 		}
@@ -74,7 +83,11 @@ func (d dotnetASPNETCoreType) getReleaseVersions(release DotnetChannelRelease) [
 
 func (d dotnetASPNETCoreType) getCPE(version string) (string, error) {
 	majorMinorVersion := strings.Join(strings.Split(version, ".")[0:2], ".")
-	return fmt.Sprintf("cpe:2.3:a:microsoft:asp.net_core:%s:*:*:*:*:*:*:*", majorMinorVersion), nil
+	return cpe.Application("microsoft", "asp.net_core", majorMinorVersion).String(), nil
+}
+
+func (d dotnetASPNETCoreType) getPackageName() string {
+	return "Microsoft.AspNetCore.App.Runtime.linux-x64"
 }
 
 func (d dotnetASPNETCoreType) versionShouldBeIgnored(version string) bool {
@@ -27,6 +27,7 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeGithubClient     *dependencyfakes.FakeGithubClient
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		dotnetASPNETCore     dependency.Dependency
@@ -37,11 +38,13 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		dotnetASPNETCore, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("dotnet-aspnetcore")
+		dotnetASPNETCore, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("dotnet-aspnetcore")
 		require.NoError(err)
 	})
 
@@ -257,7 +260,7 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-			fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-aspnetcore@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
@@ -268,21 +271,36 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 			expectedDep := dependency.DepVersion{
-				Version:         "2.0.1",
-				URI:             "url-for-linux-x64-2.0.1",
-				SHA256:          "some-sha256",
+				Version:  "2.0.1",
+				URI:      "url-for-linux-x64-2.0.1",
+				SHA256:   "some-sha256",
+				Checksum: "sha256:some-sha256",
+				Checksums: map[string]string{
+					"sha256": "some-sha256",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: &expectedDeprecationDate,
 				CPE:             "cpe:2.3:a:microsoft:asp.net_core:2.0:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/dotnet-aspnetcore@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Artifacts: []dependency.Artifact{
+					{
+						OS:       "linux",
+						Arch:     "amd64",
+						Libc:     "glibc",
+						URI:      "url-for-linux-x64-2.0.1",
+						Checksum: "sha512:sha512-for-linux-x64-2.0.1",
+					},
+				},
+				Verification: &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 			}
 			assert.Equal(expectedDep, actualDep)
 
 			urlArg, _ := fakeWebClient.GetArgsForCall(0)
 			assert.Equal("https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json", urlArg)
 
-			urlArg, _, _ = fakeWebClient.DownloadArgsForCall(0)
+			urlArg, _ = fakeArtifactStore.FetchArgsForCall(0)
 			assert.Equal("url-for-linux-x64-2.0.1", urlArg)
 
 			_, sha512Arg := fakeChecksummer.VerifySHA512ArgsForCall(0)
@@ -324,7 +342,7 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-aspnetcore@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
 
@@ -335,21 +353,27 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.1",
-					URI:             "url-for-ubuntu-x64-2.0.1",
-					SHA256:          "some-sha256",
+					Version:  "2.0.1",
+					URI:      "url-for-ubuntu-x64-2.0.1",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:asp.net_core:2.0:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-aspnetcore@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
 				urlArg, _ := fakeWebClient.GetArgsForCall(0)
 				assert.Equal("https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json", urlArg)
 
-				urlArg, _, _ = fakeWebClient.DownloadArgsForCall(0)
+				urlArg, _ = fakeArtifactStore.FetchArgsForCall(0)
 				assert.Equal("url-for-ubuntu-x64-2.0.1", urlArg)
 
 				_, sha512Arg := fakeChecksummer.VerifySHA512ArgsForCall(0)
@@ -386,7 +410,7 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 				_, err := dotnetASPNETCore.GetDependencyVersion("2.0.1")
 				assert.Error(err)
@@ -418,7 +442,7 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-aspnetcore@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
 
@@ -429,14 +453,20 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.1",
-					URI:             "url-for-linux-x64-2.0.1",
-					SHA256:          "some-sha256",
+					Version:  "2.0.1",
+					URI:      "url-for-linux-x64-2.0.1",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:asp.net_core:2.0:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-aspnetcore@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationNone, SkipReason: "the release file does not publish a hash"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
@@ -445,7 +475,7 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
 		})
 
 		when("the file has is a sha256", func() {
-			it("returns the hash and does not download the file", func() {
+			it("verifies the hash and computes the checksums from the file", func() {
 				fakeWebClient.GetReturns([]byte(`
 {
   "eol-date": "2050-02-20",
@@ -471,6 +501,8 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-aspnetcore@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
 
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256", "sha512": "some-sha512"}, nil)
+
 				actualDep, err := dotnetASPNETCore.GetDependencyVersion("2.0.1")
 				require.NoError(err)
 
@@ -478,19 +510,36 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.1",
-					URI:             "url-for-linux-x64-2.0.1",
-					SHA256:          "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+					Version:  "2.0.1",
+					URI:      "url-for-linux-x64-2.0.1",
+					SHA256:   "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+					Checksum: "sha256:shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+					Checksums: map[string]string{
+						"sha256": "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:asp.net_core:2.0:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-aspnetcore@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Artifacts: []dependency.Artifact{
+						{
+							OS:       "linux",
+							Arch:     "amd64",
+							Libc:     "glibc",
+							URI:      "url-for-linux-x64-2.0.1",
+							Checksum: "sha256:shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+						},
+					},
+					Verification: &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
-				assert.Equal(0, fakeWebClient.DownloadCallCount())
+				assert.Equal(1, fakeChecksummer.GetChecksumsCallCount())
 				assert.Equal(0, fakeChecksummer.VerifySHA512CallCount())
+				_, sha256Arg := fakeChecksummer.VerifySHA256ArgsForCall(0)
+				assert.Equal("shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256", sha256Arg)
 			})
 		})
 
@@ -518,7 +567,7 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-aspnetcore@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
 
@@ -529,14 +578,29 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.2",
-					URI:             "url-for-linux-x64-2.0.2",
-					SHA256:          "some-sha256",
+					Version:  "2.0.2",
+					URI:      "url-for-linux-x64-2.0.2",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: nil,
 					CPE:             "cpe:2.3:a:microsoft:asp.net_core:2.0:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-aspnetcore@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Artifacts: []dependency.Artifact{
+						{
+							OS:       "linux",
+							Arch:     "amd64",
+							Libc:     "glibc",
+							URI:      "url-for-linux-x64-2.0.2",
+							Checksum: "sha512:shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa512",
+						},
+					},
+					Verification: &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 			})
@@ -606,7 +670,7 @@ func testDotnetASPNETCore(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-			fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 			releaseDate, err := dotnetASPNETCore.GetReleaseDate("2.0.1")
 			require.NoError(err)
@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/Masterminds/semver"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 )
 
 type DotnetRuntime struct {
@@ -13,6 +16,8 @@ type DotnetRuntime struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
 }
 
 type dotnetRuntimeType struct{}
@@ -22,6 +27,7 @@ func (d DotnetRuntime) GetAllVersionRefs() ([]string, error) {
 		dotnetType:  dotnetRuntimeType{},
 		checksummer: d.checksummer,
 		webClient:   d.webClient,
+		cache:       d.cache,
 	}.GetAllVersionRefs()
 }
 
@@ -32,6 +38,8 @@ func (d DotnetRuntime) GetDependencyVersion(version string) (DepVersion, error)
 		webClient:        d.webClient,
 		licenseRetriever: d.licenseRetriever,
 		purlGenerator:    d.purlGenerator,
+		cache:            d.cache,
+		artifactStore:    d.artifactStore,
 		name:             "dotnet-runtime",
 	}.GetDependencyVersion(version)
 }
@@ -41,6 +49,7 @@ func (d DotnetRuntime) GetReleaseDate(version string) (*time.Time, error) {
 		dotnetType:  dotnetRuntimeType{},
 		checksummer: d.checksummer,
 		webClient:   d.webClient,
+		cache:       d.cache,
 	}.GetReleaseDate(version)
 }
 
@@ -53,7 +62,7 @@ func (d dotnetRuntimeType) getReleaseDate(channel DotnetChannel, version string)
 		if release.Runtime.Version == version {
 			releaseDate, err := time.Parse("2006-01-02", release.ReleaseDate)
 			if err != nil {
-				return nil, fmt.Errorf("could not parse release date: %w", err)
+				return nil, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 			}
 			return &releaseDate, nil
 		}
@@ -77,14 +86,18 @@ func (d dotnetRuntimeType) getReleaseVersions(release DotnetChannelRelease) []st
 func (d dotnetRuntimeType) getCPE(version string) (string, error) {
 	parsedVersion, err := semver.NewVersion(version)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse semverL %w", err)
+		return "", fmt.Errorf("failed to parse semverL %w", depErrors.ParseError{Err: err})
 	}
 
 	productName := ".net"
 	if parsedVersion.LessThan(semver.MustParse("5.0.0-0")) { // use 5.0.0-0 to ensure 5.0.0 previews/RCs use the new `.net` product name
 		productName = ".net_core"
 	}
-	return fmt.Sprintf("cpe:2.3:a:microsoft:%s:%s:*:*:*:*:*:*:*", productName, version), nil
+	return cpe.Application("microsoft", productName, version).String(), nil
+}
+
+func (d dotnetRuntimeType) getPackageName() string {
+	return "Microsoft.NETCore.App.Runtime.linux-x64"
 }
 
 func (d dotnetRuntimeType) versionShouldBeIgnored(version string) bool {
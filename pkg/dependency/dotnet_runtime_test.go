@@ -13,6 +13,7 @@ import (
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
 	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 func TestDotnetRuntime(t *testing.T) {
@@ -27,6 +28,7 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeGithubClient     *dependencyfakes.FakeGithubClient
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		dotnetRuntime        dependency.Dependency
@@ -37,11 +39,13 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		dotnetRuntime, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("dotnet-runtime")
+		dotnetRuntime, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("dotnet-runtime")
 		require.NoError(err)
 	})
 
@@ -257,7 +261,7 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-			fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
 
@@ -266,22 +270,38 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
 
 			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
+			assert.Equal(purl.NuGet("Microsoft.NETCore.App.Runtime.linux-x64", "2.0.1").WithSource("some-sha256", "url-for-linux-x64-2.0.1"), fakePURLGenerator.GenerateArgsForCall(0))
 			expectedDep := dependency.DepVersion{
-				Version:         "2.0.1",
-				URI:             "url-for-linux-x64-2.0.1",
-				SHA256:          "some-sha256",
+				Version:  "2.0.1",
+				URI:      "url-for-linux-x64-2.0.1",
+				SHA256:   "some-sha256",
+				Checksum: "sha256:some-sha256",
+				Checksums: map[string]string{
+					"sha256": "some-sha256",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: &expectedDeprecationDate,
 				CPE:             "cpe:2.3:a:microsoft:.net_core:2.0.1:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Artifacts: []dependency.Artifact{
+					{
+						OS:       "linux",
+						Arch:     "amd64",
+						Libc:     "glibc",
+						URI:      "url-for-linux-x64-2.0.1",
+						Checksum: "sha512:sha512-for-linux-x64-2.0.1",
+					},
+				},
+				Verification: &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 			}
 			assert.Equal(expectedDep, actualDep)
 
 			urlArg, _ := fakeWebClient.GetArgsForCall(0)
 			assert.Equal("https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json", urlArg)
 
-			urlArg, _, _ = fakeWebClient.DownloadArgsForCall(0)
+			urlArg, _ = fakeArtifactStore.FetchArgsForCall(0)
 			assert.Equal("url-for-linux-x64-2.0.1", urlArg)
 
 			_, sha512Arg := fakeChecksummer.VerifySHA512ArgsForCall(0)
@@ -351,7 +371,7 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
 
@@ -361,21 +381,36 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "5.0.1",
-					URI:             "url-for-linux-x64-5.0.1",
-					SHA256:          "some-sha256",
+					Version:  "5.0.1",
+					URI:      "url-for-linux-x64-5.0.1",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:.net:5.0.1:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Artifacts: []dependency.Artifact{
+						{
+							OS:       "linux",
+							Arch:     "amd64",
+							Libc:     "glibc",
+							URI:      "url-for-linux-x64-5.0.1",
+							Checksum: "sha512:sha512-for-linux-x64-5.0.1",
+						},
+					},
+					Verification: &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/5.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
 				urlArg, _ := fakeWebClient.GetArgsForCall(0)
 				assert.Equal("https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/5.0/releases.json", urlArg)
 
-				urlArg, _, _ = fakeWebClient.DownloadArgsForCall(0)
+				urlArg, _ = fakeArtifactStore.FetchArgsForCall(0)
 				assert.Equal("url-for-linux-x64-5.0.1", urlArg)
 
 				_, sha512Arg := fakeChecksummer.VerifySHA512ArgsForCall(0)
@@ -418,7 +453,7 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
 
@@ -428,21 +463,27 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.1",
-					URI:             "url-for-ubuntu-x64-2.0.1",
-					SHA256:          "some-sha256",
+					Version:  "2.0.1",
+					URI:      "url-for-ubuntu-x64-2.0.1",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:.net_core:2.0.1:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
 				urlArg, _ := fakeWebClient.GetArgsForCall(0)
 				assert.Equal("https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json", urlArg)
 
-				urlArg, _, _ = fakeWebClient.DownloadArgsForCall(0)
+				urlArg, _ = fakeArtifactStore.FetchArgsForCall(0)
 				assert.Equal("url-for-ubuntu-x64-2.0.1", urlArg)
 
 				_, sha512Arg := fakeChecksummer.VerifySHA512ArgsForCall(0)
@@ -479,7 +520,7 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 				_, err := dotnetRuntime.GetDependencyVersion("2.0.1")
 				assert.Error(err)
@@ -511,7 +552,7 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
 
@@ -521,14 +562,20 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.1",
-					URI:             "url-for-linux-x64-2.0.1",
-					SHA256:          "some-sha256",
+					Version:  "2.0.1",
+					URI:      "url-for-linux-x64-2.0.1",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:.net_core:2.0.1:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationNone, SkipReason: "the release file does not publish a hash"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
@@ -537,7 +584,7 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
 		})
 
 		when("the file has is a sha256", func() {
-			it("returns the hash and does not download the file", func() {
+			it("verifies the hash and computes the checksums from the file", func() {
 				fakeWebClient.GetReturns([]byte(`
 {
   "eol-date": "2050-02-20",
@@ -563,25 +610,44 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
 
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256", "sha512": "some-sha512"}, nil)
+
 				actualDep, err := dotnetRuntime.GetDependencyVersion("2.0.1")
 				require.NoError(err)
 
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.1",
-					URI:             "url-for-linux-x64-2.0.1",
-					SHA256:          "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+					Version:  "2.0.1",
+					URI:      "url-for-linux-x64-2.0.1",
+					SHA256:   "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+					Checksum: "sha256:shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+					Checksums: map[string]string{
+						"sha256": "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:.net_core:2.0.1:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Artifacts: []dependency.Artifact{
+						{
+							OS:       "linux",
+							Arch:     "amd64",
+							Libc:     "glibc",
+							URI:      "url-for-linux-x64-2.0.1",
+							Checksum: "sha256:shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+						},
+					},
+					Verification: &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
-				assert.Equal(0, fakeWebClient.DownloadCallCount())
+				assert.Equal(1, fakeChecksummer.GetChecksumsCallCount())
 				assert.Equal(0, fakeChecksummer.VerifySHA512CallCount())
+				_, sha256Arg := fakeChecksummer.VerifySHA256ArgsForCall(0)
+				assert.Equal("shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256", sha256Arg)
 			})
 		})
 
@@ -609,7 +675,7 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1")
 
@@ -619,14 +685,29 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.2",
-					URI:             "url-for-linux-x64-2.0.2",
-					SHA256:          "some-sha256",
+					Version:  "2.0.2",
+					URI:      "url-for-linux-x64-2.0.2",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: nil,
 					CPE:             "cpe:2.3:a:microsoft:.net_core:2.0.2:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-runtime@2.0.1?checksum=some-sha256&download_url=url-for-linux-x64-2.0.1",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Artifacts: []dependency.Artifact{
+						{
+							OS:       "linux",
+							Arch:     "amd64",
+							Libc:     "glibc",
+							URI:      "url-for-linux-x64-2.0.2",
+							Checksum: "sha512:shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa512",
+						},
+					},
+					Verification: &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 			})
@@ -696,7 +777,7 @@ func testDotnetRuntime(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-			fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 			releaseDate, err := dotnetRuntime.GetReleaseDate("2.0.1")
 			require.NoError(err)
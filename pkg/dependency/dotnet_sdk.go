@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/Masterminds/semver"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 )
 
 type DotnetSDK struct {
@@ -13,6 +16,8 @@ type DotnetSDK struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
 }
 
 type dotnetSDKType struct{}
@@ -22,6 +27,7 @@ func (d DotnetSDK) GetAllVersionRefs() ([]string, error) {
 		dotnetType:  dotnetSDKType{},
 		checksummer: d.checksummer,
 		webClient:   d.webClient,
+		cache:       d.cache,
 	}.GetAllVersionRefs()
 }
 
@@ -32,6 +38,8 @@ func (d DotnetSDK) GetDependencyVersion(version string) (DepVersion, error) {
 		webClient:        d.webClient,
 		licenseRetriever: d.licenseRetriever,
 		purlGenerator:    d.purlGenerator,
+		cache:            d.cache,
+		artifactStore:    d.artifactStore,
 		name:             "dotnet-sdk",
 	}.GetDependencyVersion(version)
 }
@@ -41,6 +49,7 @@ func (d DotnetSDK) GetReleaseDate(version string) (*time.Time, error) {
 		dotnetType:  dotnetSDKType{},
 		checksummer: d.checksummer,
 		webClient:   d.webClient,
+		cache:       d.cache,
 	}.GetReleaseDate(version)
 }
 
@@ -64,7 +73,7 @@ func (d dotnetSDKType) getReleaseDate(channel DotnetChannel, version string) (*t
 		if release.SDK.Version == version {
 			releaseDate, err := time.Parse("2006-01-02", release.ReleaseDate)
 			if err != nil {
-				return nil, fmt.Errorf("could not parse release date: %w", err)
+				return nil, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 			}
 			return &releaseDate, nil
 		}
@@ -73,7 +82,7 @@ func (d dotnetSDKType) getReleaseDate(channel DotnetChannel, version string) (*t
 			if sdk.Version == version {
 				releaseDate, err := time.Parse("2006-01-02", release.ReleaseDate)
 				if err != nil {
-					return nil, fmt.Errorf("could not parse release date: %w", err)
+					return nil, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 				}
 				return &releaseDate, nil
 			}
@@ -116,14 +125,20 @@ func (d dotnetSDKType) getChannelVersion(version string) string {
 func (d dotnetSDKType) getCPE(version string) (string, error) {
 	parsedVersion, err := semver.NewVersion(version)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse semverL %w", err)
+		return "", fmt.Errorf("failed to parse semverL %w", depErrors.ParseError{Err: err})
 	}
 
 	productName := ".net"
 	if parsedVersion.LessThan(semver.MustParse("5.0.0-0")) { // use 5.0.0-0 to ensure 5.0.0 previews/RCs use the new `.net` product name
 		productName = ".net_core"
 	}
-	return fmt.Sprintf("cpe:2.3:a:microsoft:%s:%s:*:*:*:*:*:*:*", productName, version), nil
+	return cpe.Application("microsoft", productName, version).String(), nil
+}
+
+// The SDK is not a NuGet package, but Microsoft.NET.Sdk is the name projects
+// reference it by.
+func (d dotnetSDKType) getPackageName() string {
+	return "Microsoft.NET.Sdk"
 }
 
 func (d dotnetSDKType) versionShouldBeIgnored(version string) bool {
@@ -27,6 +27,7 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeGithubClient     *dependencyfakes.FakeGithubClient
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		dotnetSDK            dependency.Dependency
@@ -37,11 +38,13 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		dotnetSDK, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("dotnet-sdk")
+		dotnetSDK, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("dotnet-sdk")
 		require.NoError(err)
 	})
 
@@ -296,6 +299,24 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
               "url": "url-for-linux-x64-2.0.201",
               "hash": "SHA512-FOR-LINUX-X64-2.0.201"
             },
+            {
+              "name": "dotnet-sdk-linux-musl-arm64.tar.gz",
+              "rid": "linux-musl-arm64",
+              "url": "url-for-linux-musl-arm64-2.0.201",
+              "hash": "sha512-for-linux-musl-arm64-2.0.201"
+            },
+            {
+              "name": "dotnet-sdk-linux-arm64.zip",
+              "rid": "linux-arm64",
+              "url": "url-for-linux-arm64-zip-2.0.201",
+              "hash": "sha512-for-linux-arm64-zip-2.0.201"
+            },
+            {
+              "name": "dotnet-sdk-linux-arm64.tar.gz",
+              "rid": "linux-arm64",
+              "url": "url-for-linux-arm64-2.0.201",
+              "hash": "sha512-for-linux-arm64-2.0.201"
+            },
             {
               "name": "dotnet-sdk-osx-64.tar.gz",
               "rid": "osx-64",
@@ -323,7 +344,7 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-			fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201")
 
@@ -333,21 +354,50 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 			expectedDep := dependency.DepVersion{
-				Version:         "2.0.201",
-				URI:             "url-for-linux-x64-2.0.201",
-				SHA256:          "some-sha256",
+				Version:  "2.0.201",
+				URI:      "url-for-linux-x64-2.0.201",
+				SHA256:   "some-sha256",
+				Checksum: "sha256:some-sha256",
+				Checksums: map[string]string{
+					"sha256": "some-sha256",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: &expectedDeprecationDate,
 				CPE:             "cpe:2.3:a:microsoft:.net_core:2.0.201:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Artifacts: []dependency.Artifact{
+					{
+						OS:       "linux",
+						Arch:     "amd64",
+						Libc:     "glibc",
+						URI:      "url-for-linux-x64-2.0.201",
+						Checksum: "sha512:sha512-for-linux-x64-2.0.201",
+					},
+					{
+						OS:       "linux",
+						Arch:     "arm64",
+						Libc:     "glibc",
+						URI:      "url-for-linux-arm64-2.0.201",
+						Checksum: "sha512:sha512-for-linux-arm64-2.0.201",
+					},
+					{
+						OS:       "linux",
+						Arch:     "arm64",
+						Libc:     "musl",
+						URI:      "url-for-linux-musl-arm64-2.0.201",
+						Checksum: "sha512:sha512-for-linux-musl-arm64-2.0.201",
+					},
+				},
+				Verification: &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 			}
 			assert.Equal(expectedDep, actualDep)
 
 			urlArg, _ := fakeWebClient.GetArgsForCall(0)
 			assert.Equal("https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json", urlArg)
 
-			urlArg, _, _ = fakeWebClient.DownloadArgsForCall(0)
+			urlArg, _ = fakeArtifactStore.FetchArgsForCall(0)
 			assert.Equal("url-for-linux-x64-2.0.201", urlArg)
 
 			_, sha512Arg := fakeChecksummer.VerifySHA512ArgsForCall(0)
@@ -438,7 +488,7 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-sdk@5.0.201?checksum=some-sha256&download_url=url-for-linux-x64-5.0.201")
 
@@ -448,21 +498,36 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "5.0.201",
-					URI:             "url-for-linux-x64-5.0.201",
-					SHA256:          "some-sha256",
+					Version:  "5.0.201",
+					URI:      "url-for-linux-x64-5.0.201",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:.net:5.0.201:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-sdk@5.0.201?checksum=some-sha256&download_url=url-for-linux-x64-5.0.201",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Artifacts: []dependency.Artifact{
+						{
+							OS:       "linux",
+							Arch:     "amd64",
+							Libc:     "glibc",
+							URI:      "url-for-linux-x64-5.0.201",
+							Checksum: "sha512:sha512-for-linux-x64-5.0.201",
+						},
+					},
+					Verification: &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/5.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
 				urlArg, _ := fakeWebClient.GetArgsForCall(0)
 				assert.Equal("https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/5.0/releases.json", urlArg)
 
-				urlArg, _, _ = fakeWebClient.DownloadArgsForCall(0)
+				urlArg, _ = fakeArtifactStore.FetchArgsForCall(0)
 				assert.Equal("url-for-linux-x64-5.0.201", urlArg)
 
 				_, sha512Arg := fakeChecksummer.VerifySHA512ArgsForCall(0)
@@ -505,7 +570,7 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201")
 
@@ -515,21 +580,27 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.201",
-					URI:             "url-for-ubuntu-x64-2.0.201",
-					SHA256:          "some-sha256",
+					Version:  "2.0.201",
+					URI:      "url-for-ubuntu-x64-2.0.201",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:.net_core:2.0.201:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
 				urlArg, _ := fakeWebClient.GetArgsForCall(0)
 				assert.Equal("https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json", urlArg)
 
-				urlArg, _, _ = fakeWebClient.DownloadArgsForCall(0)
+				urlArg, _ = fakeArtifactStore.FetchArgsForCall(0)
 				assert.Equal("url-for-ubuntu-x64-2.0.201", urlArg)
 
 				_, sha512Arg := fakeChecksummer.VerifySHA512ArgsForCall(0)
@@ -566,7 +637,7 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 				_, err := dotnetSDK.GetDependencyVersion("2.0.201")
 				assert.Error(err)
@@ -598,7 +669,7 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201")
 
@@ -608,14 +679,20 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.201",
-					URI:             "url-for-linux-x64-2.0.201",
-					SHA256:          "some-sha256",
+					Version:  "2.0.201",
+					URI:      "url-for-linux-x64-2.0.201",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:.net_core:2.0.201:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationNone, SkipReason: "the release file does not publish a hash"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
@@ -624,7 +701,7 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
 		})
 
 		when("the file has is a sha256", func() {
-			it("returns the hash and does not download the file", func() {
+			it("verifies the hash and computes the checksums from the file", func() {
 				fakeWebClient.GetReturns([]byte(`
 {
   "eol-date": "2050-02-20",
@@ -650,25 +727,44 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201")
 
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256", "sha512": "some-sha512"}, nil)
+
 				actualDep, err := dotnetSDK.GetDependencyVersion("2.0.201")
 				require.NoError(err)
 
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.201",
-					URI:             "url-for-linux-x64-2.0.201",
-					SHA256:          "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+					Version:  "2.0.201",
+					URI:      "url-for-linux-x64-2.0.201",
+					SHA256:   "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+					Checksum: "sha256:shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+					Checksums: map[string]string{
+						"sha256": "shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:.net_core:2.0.201:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Artifacts: []dependency.Artifact{
+						{
+							OS:       "linux",
+							Arch:     "amd64",
+							Libc:     "glibc",
+							URI:      "url-for-linux-x64-2.0.201",
+							Checksum: "sha256:shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256",
+						},
+					},
+					Verification: &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
-				assert.Equal(0, fakeWebClient.DownloadCallCount())
+				assert.Equal(1, fakeChecksummer.GetChecksumsCallCount())
 				assert.Equal(0, fakeChecksummer.VerifySHA512CallCount())
+				_, sha256Arg := fakeChecksummer.VerifySHA256ArgsForCall(0)
+				assert.Equal("shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa256", sha256Arg)
 			})
 		})
 
@@ -695,7 +791,7 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201")
 
@@ -705,14 +801,29 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "2.1.201",
-					URI:             "url-for-linux-x64-2.1.201",
-					SHA256:          "some-sha256",
+					Version:  "2.1.201",
+					URI:      "url-for-linux-x64-2.1.201",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:microsoft:.net_core:2.1.201:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Artifacts: []dependency.Artifact{
+						{
+							OS:       "linux",
+							Arch:     "amd64",
+							Libc:     "glibc",
+							URI:      "url-for-linux-x64-2.1.201",
+							Checksum: "sha512:sha512-for-linux-x64-2.1.201",
+						},
+					},
+					Verification: &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
@@ -744,7 +855,7 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201")
 
@@ -754,14 +865,29 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "2.0.201",
-					URI:             "url-for-linux-x64-2.0.201",
-					SHA256:          "some-sha256",
+					Version:  "2.0.201",
+					URI:      "url-for-linux-x64-2.0.201",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: nil,
 					CPE:             "cpe:2.3:a:microsoft:.net_core:2.0.201:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/dotnet-sdk@2.0.201?checksum=some-sha256&download_url=url-for-linux-x64-2.0.201",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Artifacts: []dependency.Artifact{
+						{
+							OS:       "linux",
+							Arch:     "amd64",
+							Libc:     "glibc",
+							URI:      "url-for-linux-x64-2.0.201",
+							Checksum: "sha512:shaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa512",
+						},
+					},
+					Verification: &dependency.Verification{Method: dependency.VerificationSHA512, SourceURL: "https://dotnetcli.blob.core.windows.net/dotnet/release-metadata/2.0/releases.json"},
 				}
 				assert.Equal(expectedDep, actualDep)
 			})
@@ -852,7 +978,7 @@ func testDotnetSDK(t *testing.T, when spec.G, it spec.S) {
   ]
 }
 `), nil)
-			fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 			releaseDate, err := dotnetSDK.GetReleaseDate("2.0.201")
 			require.NoError(err)
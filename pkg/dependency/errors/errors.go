@@ -1,6 +1,10 @@
 package errors
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 type NoSourceCodeError struct {
 	Version string
@@ -9,3 +13,178 @@ type NoSourceCodeError struct {
 func (n NoSourceCodeError) Error() string {
 	return fmt.Sprintf("could not find source code for dependency version %s", n.Version)
 }
+
+// URLNotAllowedError is returned when a dependency would request a URL
+// outside of what its URL policy allows.
+type URLNotAllowedError struct {
+	URL    string
+	Reason string
+}
+
+func (u URLNotAllowedError) Error() string {
+	return fmt.Sprintf("url %s is not allowed: %s", u.URL, u.Reason)
+}
+
+// HTTPError is returned when an upstream answers with an unsuccessful status
+// code. Body holds a redacted and truncated excerpt of the response.
+type HTTPError struct {
+	StatusCode int
+	URL        string
+	Body       string
+}
+
+func (h HTTPError) Error() string {
+	return fmt.Sprintf("got unsuccessful response: status code: %d, body: %s", h.StatusCode, h.Body)
+}
+
+// VersionNotFoundError is returned when the requested version of a
+// dependency does not exist upstream.
+type VersionNotFoundError struct {
+	Dependency string
+	Version    string
+}
+
+func (v VersionNotFoundError) Error() string {
+	if v.Dependency == "" {
+		return fmt.Sprintf("could not find version %s", v.Version)
+	}
+	return fmt.Sprintf("could not find %s version %s", v.Dependency, v.Version)
+}
+
+// UpstreamUnavailableError marks Err as caused by an upstream that could not
+// be reached or answered with a server error.
+type UpstreamUnavailableError struct {
+	URL string
+	Err error
+}
+
+func (u UpstreamUnavailableError) Error() string {
+	return u.Err.Error()
+}
+
+func (u UpstreamUnavailableError) Unwrap() error {
+	return u.Err
+}
+
+// RateLimitedError marks Err as caused by an upstream rate limit. RetryAfter
+// is zero when the upstream did not say when to retry.
+type RateLimitedError struct {
+	URL        string
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (r RateLimitedError) Error() string {
+	return r.Err.Error()
+}
+
+func (r RateLimitedError) Unwrap() error {
+	return r.Err
+}
+
+// ChecksumMismatchError is returned when an artifact does not have the
+// checksum published for it.
+type ChecksumMismatchError struct {
+	Algorithm string
+	Expected  string
+	Actual    string
+}
+
+func (c ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("expected %s '%s' but got '%s'", c.Algorithm, c.Expected, c.Actual)
+}
+
+// SignatureInvalidError is returned when an artifact's signature could not
+// be verified with any of the trusted keys.
+type SignatureInvalidError struct {
+	Reason string
+}
+
+func (s SignatureInvalidError) Error() string {
+	return s.Reason
+}
+
+// UnverifiedError is returned when policy requires a verified artifact but
+// the checksums of the version could not be checked against anything
+// published by its upstream.
+type UnverifiedError struct {
+	Version string
+	Reason  string
+}
+
+func (u UnverifiedError) Error() string {
+	if u.Reason == "" {
+		return fmt.Sprintf("dependency version %s is not verified", u.Version)
+	}
+	return fmt.Sprintf("dependency version %s is not verified: %s", u.Version, u.Reason)
+}
+
+// ParseError marks Err as caused by upstream data in an unexpected format,
+// which usually means the upstream has changed how it publishes releases.
+type ParseError struct {
+	Err error
+}
+
+func (p ParseError) Error() string {
+	return p.Err.Error()
+}
+
+func (p ParseError) Unwrap() error {
+	return p.Err
+}
+
+// Exit codes returned by the actions for each kind of error, so that
+// automation can tell them apart.
+const (
+	ExitCodeFailure             = 1
+	ExitCodeVersionNotFound     = 3
+	ExitCodeUpstreamUnavailable = 4
+	ExitCodeRateLimited         = 5
+	ExitCodeChecksumMismatch    = 6
+	ExitCodeSignatureInvalid    = 7
+	ExitCodeParseFailure        = 8
+	ExitCodeURLNotAllowed       = 9
+	ExitCodeNoSourceCode        = 10
+	ExitCodeUnverified          = 11
+)
+
+// ExitCode returns the exit code for err, or 0 if err is nil. Errors that do
+// not match any of the types in this package return ExitCodeFailure.
+func ExitCode(err error) int {
+	var (
+		versionNotFound     VersionNotFoundError
+		rateLimited         RateLimitedError
+		upstreamUnavailable UpstreamUnavailableError
+		checksumMismatch    ChecksumMismatchError
+		signatureInvalid    SignatureInvalidError
+		parseError          ParseError
+		urlNotAllowed       URLNotAllowedError
+		noSourceCode        NoSourceCodeError
+		unverified          UnverifiedError
+	)
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &versionNotFound):
+		return ExitCodeVersionNotFound
+	case errors.As(err, &rateLimited):
+		return ExitCodeRateLimited
+	case errors.As(err, &upstreamUnavailable):
+		return ExitCodeUpstreamUnavailable
+	case errors.As(err, &checksumMismatch):
+		return ExitCodeChecksumMismatch
+	case errors.As(err, &signatureInvalid):
+		return ExitCodeSignatureInvalid
+	case errors.As(err, &parseError):
+		return ExitCodeParseFailure
+	case errors.As(err, &urlNotAllowed):
+		return ExitCodeURLNotAllowed
+	case errors.As(err, &noSourceCode):
+		return ExitCodeNoSourceCode
+	case errors.As(err, &unverified):
+		return ExitCodeUnverified
+	default:
+		return ExitCodeFailure
+	}
+}
@@ -0,0 +1,51 @@
+package errors_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	spec.Run(t, "errors", testErrors, spec.Report(report.Terminal{}))
+}
+
+func testErrors(t *testing.T, when spec.G, it spec.S) {
+	var assert = assert.New(t)
+
+	when("ExitCode", func() {
+		it("returns a distinct exit code for each kind of error", func() {
+			httpErr := depErrors.HTTPError{StatusCode: 503, URL: "some-url", Body: "some-body"}
+
+			assert.Equal(0, depErrors.ExitCode(nil))
+			assert.Equal(depErrors.ExitCodeFailure, depErrors.ExitCode(errors.New("some-error")))
+			assert.Equal(depErrors.ExitCodeFailure, depErrors.ExitCode(httpErr))
+			assert.Equal(depErrors.ExitCodeVersionNotFound, depErrors.ExitCode(depErrors.VersionNotFoundError{Version: "1.0.0"}))
+			assert.Equal(depErrors.ExitCodeUpstreamUnavailable, depErrors.ExitCode(depErrors.UpstreamUnavailableError{Err: httpErr}))
+			assert.Equal(depErrors.ExitCodeRateLimited, depErrors.ExitCode(depErrors.RateLimitedError{Err: httpErr}))
+			assert.Equal(depErrors.ExitCodeChecksumMismatch, depErrors.ExitCode(depErrors.ChecksumMismatchError{}))
+			assert.Equal(depErrors.ExitCodeSignatureInvalid, depErrors.ExitCode(depErrors.SignatureInvalidError{}))
+			assert.Equal(depErrors.ExitCodeParseFailure, depErrors.ExitCode(depErrors.ParseError{Err: errors.New("some-error")}))
+			assert.Equal(depErrors.ExitCodeURLNotAllowed, depErrors.ExitCode(depErrors.URLNotAllowedError{}))
+			assert.Equal(depErrors.ExitCodeNoSourceCode, depErrors.ExitCode(depErrors.NoSourceCodeError{}))
+			assert.Equal(depErrors.ExitCodeUnverified, depErrors.ExitCode(depErrors.UnverifiedError{}))
+		})
+
+		it("looks through wrapped errors", func() {
+			err := fmt.Errorf("failed to get version '1.0.0': %w", depErrors.VersionNotFoundError{Version: "1.0.0"})
+			assert.Equal(depErrors.ExitCodeVersionNotFound, depErrors.ExitCode(err))
+		})
+	})
+
+	when("the error marks another error", func() {
+		it("keeps its message", func() {
+			err := depErrors.ParseError{Err: errors.New("could not parse release date")}
+			assert.EqualError(err, "could not parse release date")
+		})
+	})
+}
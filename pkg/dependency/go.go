@@ -3,22 +3,26 @@ package dependency
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
+const goReleasesURL = "https://golang.org/dl/?mode=json&include=all"
+
 type Go struct {
 	checksummer      Checksummer
 	fileSystem       FileSystem
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
 }
 
 type GoReleaseWithFiles struct {
@@ -27,8 +31,11 @@ type GoReleaseWithFiles struct {
 }
 
 type GoFile struct {
-	SHA256 string `json:"sha256"`
-	Kind   string `json:"kind"`
+	Filename string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	SHA256   string `json:"sha256"`
+	Kind     string `json:"kind"`
 }
 
 type GoRelease struct {
@@ -65,14 +72,31 @@ func (g Go) GetDependencyVersion(version string) (DepVersion, error) {
 		return DepVersion{}, fmt.Errorf("could not find tag for go version %s: %w", version, err)
 	}
 
-	sha, err := g.getDependencySHA(version, goReleasesWithFiles)
+	sourceSHA, err := g.getSourceSHA(version, goReleasesWithFiles)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get dependency SHA256: %w", err)
 	}
 
 	depURL := g.dependencyURL(version)
+	artifactPath, err := g.artifactStore.Fetch(depURL, verifySHA256(sourceSHA)...)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer g.artifactStore.Release(artifactPath)
+
+	// Some older releases do not publish the SHA256 of their source
+	verification := checksumVerification(VerificationSHA256, goReleasesURL)
+	if sourceSHA == "" {
+		verification = skippedVerification("go does not publish a SHA256 for the source of this release")
+	}
+
+	checksums, err := g.checksummer.GetChecksums(artifactPath, checksumAlgorithms...)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not get dependency SHA256: %w", err)
+	}
+	sha := checksums["sha256"]
 
-	licenses, err := g.licenseRetriever.LookupLicenses("go", depURL)
+	licenses, err := g.licenseRetriever.LookupLicenses("go", artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
@@ -81,16 +105,20 @@ func (g Go) GetDependencyVersion(version string) (DepVersion, error) {
 		Version:         version,
 		URI:             depURL,
 		SHA256:          sha,
+		Checksum:        prefixedChecksum(checksums),
+		Checksums:       checksums,
 		ReleaseDate:     releaseDate,
 		DeprecationDate: nil,
-		CPE:             fmt.Sprintf("cpe:2.3:a:golang:go:%s:*:*:*:*:*:*:*", strings.TrimPrefix(version, "go")),
-		PURL:            g.purlGenerator.Generate("go", version, sha, depURL),
+		CPE:             cpe.Application("golang", "go", strings.TrimPrefix(version, "go")).String(),
+		PURL:            g.purlGenerator.Generate(purl.Golang("stdlib", strings.TrimPrefix(version, "go")).WithSource(sha, depURL)),
 		Licenses:        licenses,
+		Artifacts:       g.getArtifacts(version, goReleasesWithFiles),
+		Verification:    verification,
 	}, nil
 }
 
 func (g Go) GetReleaseDate(version string) (*time.Time, error) {
-	body, err := g.webClient.Get("https://golang.org/doc/devel/release.html")
+	body, err := cachedGet(g.cache, g.webClient, "https://golang.org/doc/devel/release.html")
 	if err != nil {
 		return nil, fmt.Errorf("could not hit golang.org: %w", err)
 	}
@@ -99,18 +127,20 @@ func (g Go) GetReleaseDate(version string) (*time.Time, error) {
 	match := re.FindStringSubmatch(string(body))
 
 	if len(match) < 2 {
-		return nil, fmt.Errorf("could not find release date")
+		return nil, errors.ParseError{Err: fmt.Errorf("could not find release date")}
 	}
 
 	releaseDate, err := time.Parse("2006-01-02", match[1])
 	if err != nil {
-		return nil, fmt.Errorf("error parsing release date: %w", err)
+		return nil, fmt.Errorf("error parsing release date: %w", errors.ParseError{Err: err})
 	}
 
 	return &releaseDate, nil
 }
 
-func (g Go) getDependencySHA(version string, releases []GoReleaseWithFiles) (string, error) {
+// getSourceSHA returns the published SHA256 of the source of a release, which
+// is empty when the release does not publish one.
+func (g Go) getSourceSHA(version string, releases []GoReleaseWithFiles) (string, error) {
 	sha := ""
 	foundSHA := false
 	for _, release := range releases {
@@ -128,38 +158,47 @@ func (g Go) getDependencySHA(version string, releases []GoReleaseWithFiles) (str
 		return "", fmt.Errorf("could not find SHA256 for %s: %w", version, errors.NoSourceCodeError{Version: version})
 	}
 
-	if sha == "" {
-		return g.calculateDependencySHA(version)
-	}
-
 	return sha, nil
 }
 
-func (g Go) calculateDependencySHA(version string) (string, error) {
-	tempDir, err := os.MkdirTemp("", "httpd")
-	if err != nil {
-		return "", fmt.Errorf("could not make temp dir: %w", err)
-	}
-	defer os.RemoveAll(tempDir)
+// goArchitectures maps the architecture names of the Go downloads that are
+// not the names of Artifact.Arch. The 32-bit ARM downloads are built for
+// ARMv6.
+var goArchitectures = map[string]string{
+	"armv6l": "arm/v6",
+}
 
-	url := g.dependencyURL(version)
-	dependencyPath := filepath.Join(tempDir, filepath.Base(url))
+func (g Go) getArtifacts(version string, releases []GoReleaseWithFiles) []Artifact {
+	var artifacts []Artifact
+	for _, release := range releases {
+		if release.Version != version {
+			continue
+		}
 
-	err = g.webClient.Download(url, dependencyPath)
-	if err != nil {
-		return "", fmt.Errorf("could not download dependency: %w", err)
-	}
+		for _, file := range release.Files {
+			if file.Kind != "archive" || file.OS != "linux" || file.SHA256 == "" {
+				continue
+			}
 
-	sha256, err := g.checksummer.GetSHA256(dependencyPath)
-	if err != nil {
-		return "", fmt.Errorf("could not get sha256: %w", err)
+			arch := file.Arch
+			if name, ok := goArchitectures[arch]; ok {
+				arch = name
+			}
+
+			artifacts = append(artifacts, Artifact{
+				OS:       file.OS,
+				Arch:     arch,
+				URI:      fmt.Sprintf("https://dl.google.com/go/%s", file.Filename),
+				Checksum: fmt.Sprintf("sha256:%s", file.SHA256),
+			})
+		}
 	}
 
-	return sha256, nil
+	return artifacts
 }
 
 func (g Go) getGoReleases() ([]GoRelease, error) {
-	body, err := g.webClient.Get("https://golang.org/doc/devel/release.html")
+	body, err := cachedGet(g.cache, g.webClient, "https://golang.org/doc/devel/release.html")
 	if err != nil {
 		return nil, fmt.Errorf("could not hit golang.org: %w", err)
 	}
@@ -200,7 +239,7 @@ func (g Go) removeReleasesWithoutFiles(allReleases []GoRelease) ([]GoRelease, er
 }
 
 func (g Go) getGoReleasesWithFiles() ([]GoReleaseWithFiles, error) {
-	body, err := g.webClient.Get("https://golang.org/dl/?mode=json&include=all")
+	body, err := cachedGet(g.cache, g.webClient, goReleasesURL)
 	if err != nil {
 		return nil, fmt.Errorf("could not hit golang.org: %w", err)
 	}
@@ -208,7 +247,7 @@ func (g Go) getGoReleasesWithFiles() ([]GoReleaseWithFiles, error) {
 	var goReleasesWithFiles []GoReleaseWithFiles
 	err = json.Unmarshal(body, &goReleasesWithFiles)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal response: %w\n%s", err, body)
+		return nil, fmt.Errorf("could not unmarshal response: %w\n%s", errors.ParseError{Err: err}, body)
 	}
 
 	return goReleasesWithFiles, nil
@@ -13,6 +13,7 @@ import (
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
 	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 func TestGo(t *testing.T) {
@@ -26,6 +27,7 @@ func testGo(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer      *dependencyfakes.FakeChecksummer
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		golang               dependency.Dependency
@@ -35,11 +37,13 @@ func testGo(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		golang, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("go")
+		golang, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("go")
 		require.NoError(err)
 	})
 
@@ -123,7 +127,14 @@ func testGo(t *testing.T, when spec.G, it spec.S) {
 			fakeWebClient.GetReturnsOnCall(0, []byte(`
 [
  {"version": "go1.14.1", "files": [{"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "kind": "source"}]},
- {"version": "go1.13.9", "files": [{"sha256": "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "kind": "source"}]},
+ {"version": "go1.13.9", "files": [
+   {"filename": "go1.13.9.src.tar.gz", "os": "", "arch": "", "sha256": "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "kind": "source"},
+   {"filename": "go1.13.9.darwin-amd64.tar.gz", "os": "darwin", "arch": "amd64", "sha256": "dddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddd", "kind": "archive"},
+   {"filename": "go1.13.9.linux-amd64.tar.gz", "os": "linux", "arch": "amd64", "sha256": "eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee", "kind": "archive"},
+   {"filename": "go1.13.9.linux-arm64.tar.gz", "os": "linux", "arch": "arm64", "sha256": "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "kind": "archive"},
+   {"filename": "go1.13.9.linux-armv6l.tar.gz", "os": "linux", "arch": "armv6l", "sha256": "1111111111111111111111111111111111111111111111111111111111111111", "kind": "archive"},
+   {"filename": "go1.13.9.windows-amd64.msi", "os": "windows", "arch": "amd64", "sha256": "0000000000000000000000000000000000000000000000000000000000000000", "kind": "installer"}
+ ]},
  {"version": "go1.14", "files": [{"sha256": "cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc", "kind": "source"}]}
 ]`), nil)
 
@@ -153,20 +164,49 @@ func testGo(t *testing.T, when spec.G, it spec.S) {
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/go@go1.13.9?checksum=bbbbbb&download_url=https://dl.google.com/go")
 
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "sha512": "some-sha512"}, nil)
+
 			actualDep, err := golang.GetDependencyVersion("go1.13.9")
 			require.NoError(err)
 
 			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
+			assert.Equal(purl.Golang("stdlib", "1.13.9").WithSource("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "https://dl.google.com/go/go1.13.9.src.tar.gz"), fakePURLGenerator.GenerateArgsForCall(0))
 			expectedDep := dependency.DepVersion{
-				Version:         "go1.13.9",
-				URI:             "https://dl.google.com/go/go1.13.9.src.tar.gz",
-				SHA256:          "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+				Version:  "go1.13.9",
+				URI:      "https://dl.google.com/go/go1.13.9.src.tar.gz",
+				SHA256:   "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+				Checksum: "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+				Checksums: map[string]string{
+					"sha256": "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: nil,
 				CPE:             "cpe:2.3:a:golang:go:1.13.9:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/go@go1.13.9?checksum=bbbbbb&download_url=https://dl.google.com/go",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Artifacts: []dependency.Artifact{
+					{
+						OS:       "linux",
+						Arch:     "amd64",
+						URI:      "https://dl.google.com/go/go1.13.9.linux-amd64.tar.gz",
+						Checksum: "sha256:eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee",
+					},
+					{
+						OS:       "linux",
+						Arch:     "arm64",
+						URI:      "https://dl.google.com/go/go1.13.9.linux-arm64.tar.gz",
+						Checksum: "sha256:ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+					},
+					{
+						OS:       "linux",
+						Arch:     "arm/v6",
+						URI:      "https://dl.google.com/go/go1.13.9.linux-armv6l.tar.gz",
+						Checksum: "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+					},
+				},
+				Verification: &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://golang.org/dl/?mode=json&include=all"},
 			}
 			assert.Equal(expectedDep, actualDep)
 
@@ -175,6 +215,10 @@ func testGo(t *testing.T, when spec.G, it spec.S) {
 
 			urlArg, _ = fakeWebClient.GetArgsForCall(1)
 			assert.Equal("https://golang.org/doc/devel/release.html", urlArg)
+
+			urlArg, optionsArg := fakeArtifactStore.FetchArgsForCall(0)
+			assert.Equal("https://dl.google.com/go/go1.13.9.src.tar.gz", urlArg)
+			assert.Len(optionsArg, 1)
 		})
 
 		when("the SHA256 is empty", func() {
@@ -194,7 +238,7 @@ func testGo(t *testing.T, when spec.G, it spec.S) {
 		</p>
 `), nil)
 
-				fakeChecksummer.GetSHA256Returns("some-source-sha", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/go@go1.13.9?checksum=some-source-sha&download_url=https://dl.google.com/go")
 
@@ -204,24 +248,33 @@ func testGo(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDep := dependency.DepVersion{
-					Version:         "go1.13.9",
-					URI:             "https://dl.google.com/go/go1.13.9.src.tar.gz",
-					SHA256:          "some-source-sha",
+					Version:  "go1.13.9",
+					URI:      "https://dl.google.com/go/go1.13.9.src.tar.gz",
+					SHA256:   "some-source-sha",
+					Checksum: "sha256:some-source-sha",
+					Checksums: map[string]string{
+						"sha256": "some-source-sha",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: nil,
 					CPE:             "cpe:2.3:a:golang:go:1.13.9:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/go@go1.13.9?checksum=some-source-sha&download_url=https://dl.google.com/go",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationNone, SkipReason: "go does not publish a SHA256 for the source of this release"},
 				}
 				assert.Equal(expectedDep, actualDep)
 
 				urlArg, _ := fakeWebClient.GetArgsForCall(0)
 				assert.Equal("https://golang.org/dl/?mode=json&include=all", urlArg)
 
-				urlArg, dependencyPathDownloadArg, _ := fakeWebClient.DownloadArgsForCall(0)
+				urlArg, optionsArg := fakeArtifactStore.FetchArgsForCall(0)
 				assert.Equal("https://dl.google.com/go/go1.13.9.src.tar.gz", urlArg)
+				assert.Empty(optionsArg)
 
-				assert.Equal(dependencyPathDownloadArg, fakeChecksummer.GetSHA256ArgsForCall(0))
+				pathArg, algorithmsArg := fakeChecksummer.GetChecksumsArgsForCall(0)
+				assert.Equal("some-artifact-path", pathArg)
+				assert.Equal([]string{"sha256", "sha512"}, algorithmsArg)
 			})
 		})
 
@@ -1,24 +1,29 @@
 package dependency
 
 import (
-	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Httpd struct {
-	checksummer      Checksummer
-	fileSystem       FileSystem
-	webClient        WebClient
-	licenseRetriever LicenseRetriever
-	purlGenerator    PURLGenerator
+	checksummer        Checksummer
+	fileSystem         FileSystem
+	webClient          WebClient
+	licenseRetriever   LicenseRetriever
+	purlGenerator      PURLGenerator
+	cache              Cache
+	artifactStore      ArtifactStore
+	checksumExceptions ChecksumExceptions
+	keyring            Keyring
 }
 
 type HttpdRelease struct {
@@ -26,12 +31,11 @@ type HttpdRelease struct {
 	releaseDate   time.Time
 	dependencyURL string
 	sha256URL     string
-	sha1URL       string
-	md5URL        string
+	signatureURL  string
 }
 
 func (h Httpd) GetAllVersionRefs() ([]string, error) {
-	releases, err := h.getReleases("")
+	releases, err := h.getReleases()
 	if err != nil {
 		return nil, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -55,25 +59,35 @@ func (h Httpd) GetDependencyVersion(version string) (DepVersion, error) {
 		return DepVersion{}, fmt.Errorf("could not get release: %w", err)
 	}
 
-	sha, err := h.getDependencySHA256(release)
+	depURL := release.dependencyURL
+	artifactPath, err := h.artifactStore.Fetch(depURL)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer h.artifactStore.Release(artifactPath)
+
+	checksums, verification, err := h.getDependencyChecksums(release, artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get sha256 for dependency: %w", err)
 	}
+	sha := checksums["sha256"]
 
-	depURL := release.dependencyURL
-	licenses, err := h.licenseRetriever.LookupLicenses("httpd", depURL)
+	licenses, err := h.licenseRetriever.LookupLicenses("httpd", artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
 
 	return DepVersion{
-		Version:     version,
-		URI:         depURL,
-		SHA256:      sha,
-		ReleaseDate: &release.releaseDate,
-		CPE:         fmt.Sprintf("cpe:2.3:a:apache:http_server:%s:*:*:*:*:*:*:*", version),
-		PURL:        h.purlGenerator.Generate("httpd", version, sha, depURL),
-		Licenses:    licenses,
+		Version:      version,
+		URI:          depURL,
+		SHA256:       sha,
+		Checksum:     prefixedChecksum(checksums),
+		Checksums:    checksums,
+		ReleaseDate:  &release.releaseDate,
+		CPE:          cpe.Application("apache", "http_server", version).String(),
+		PURL:         h.purlGenerator.Generate(purl.Generic("httpd", version).WithSource(sha, depURL)),
+		Licenses:     licenses,
+		Verification: verification,
 	}, nil
 }
 
@@ -86,24 +100,29 @@ func (h Httpd) GetReleaseDate(version string) (*time.Time, error) {
 }
 
 func (h Httpd) getRelease(version string) (HttpdRelease, error) {
-	releases, err := h.getReleases(version)
+	releases, err := h.getReleases()
 	if err != nil {
 		return HttpdRelease{}, fmt.Errorf("could not get releases: %w", err)
 	}
-	if len(releases) != 1 {
-		return HttpdRelease{}, fmt.Errorf("expected to find 1 release but found %d (%v)", len(releases), releases)
+
+	var matchingReleases []HttpdRelease
+	for _, release := range releases {
+		if release.version == version {
+			matchingReleases = append(matchingReleases, release)
+		}
+	}
+	if len(matchingReleases) == 0 {
+		return HttpdRelease{}, depErrors.VersionNotFoundError{Dependency: "httpd", Version: version}
+	}
+	if len(matchingReleases) > 1 {
+		return HttpdRelease{}, depErrors.ParseError{Err: fmt.Errorf("expected to find 1 release but found %d (%v)", len(matchingReleases), matchingReleases)}
 	}
 
-	return releases[0], nil
+	return matchingReleases[0], nil
 }
 
-func (h Httpd) getReleases(versionFilter string) ([]HttpdRelease, error) {
-	filePattern := "httpd-*.tar.bz2*"
-	if versionFilter != "" {
-		filePattern = fmt.Sprintf("httpd-%s.tar.bz2*", versionFilter)
-	}
-
-	body, err := h.webClient.Get("http://archive.apache.org/dist/httpd/?F=2&C=M&O=D&P=" + filePattern)
+func (h Httpd) getReleases() ([]HttpdRelease, error) {
+	body, err := cachedGet(h.cache, h.webClient, "https://archive.apache.org/dist/httpd/?F=2&C=M&O=D&P=httpd-*.tar.bz2*")
 	if err != nil {
 		return nil, fmt.Errorf("could not get file list from archive.apache.org: %w", err)
 	}
@@ -121,7 +140,7 @@ func (h Httpd) getReleases(versionFilter string) ([]HttpdRelease, error) {
 
 		date, err := time.Parse("2006-01-02 15:04", matches[2])
 		if err != nil {
-			return nil, fmt.Errorf("could not parse '%s' as date for version '%s'", matches[2], version)
+			return nil, depErrors.ParseError{Err: fmt.Errorf("could not parse '%s' as date for version '%s'", matches[2], version)}
 		}
 
 		releases = append(releases, HttpdRelease{
@@ -129,8 +148,7 @@ func (h Httpd) getReleases(versionFilter string) ([]HttpdRelease, error) {
 			releaseDate:   date,
 			dependencyURL: h.dependencyURL(version),
 			sha256URL:     h.sha256URL(string(body), version),
-			sha1URL:       h.sha1URL(string(body), version),
-			md5URL:        h.md5URL(string(body), version),
+			signatureURL:  h.signatureURL(string(body), version),
 		})
 	}
 
@@ -138,25 +156,21 @@ func (h Httpd) getReleases(versionFilter string) ([]HttpdRelease, error) {
 }
 
 func (h Httpd) dependencyURL(version string) string {
-	return fmt.Sprintf("http://archive.apache.org/dist/httpd/httpd-%s.tar.bz2", version)
+	return fmt.Sprintf("https://archive.apache.org/dist/httpd/httpd-%s.tar.bz2", version)
 }
 
 func (h Httpd) sha256URL(index string, version string) string {
 	return h.checksumURL(index, version, "sha256")
 }
 
-func (h Httpd) sha1URL(index string, version string) string {
-	return h.checksumURL(index, version, "sha1")
-}
-
-func (h Httpd) md5URL(index string, version string) string {
-	return h.checksumURL(index, version, "md5")
+func (h Httpd) signatureURL(index string, version string) string {
+	return h.checksumURL(index, version, "asc")
 }
 
 func (h Httpd) checksumURL(index string, version string, checksum string) string {
 	checksumFilename := fmt.Sprintf("httpd-%s.tar.bz2.%s", version, checksum)
 	if strings.Contains(index, checksumFilename) {
-		return fmt.Sprintf("http://archive.apache.org/dist/httpd/%s", checksumFilename)
+		return fmt.Sprintf("https://archive.apache.org/dist/httpd/%s", checksumFilename)
 	}
 	return ""
 }
@@ -187,99 +201,92 @@ func (h Httpd) sortReleases(releases []HttpdRelease) error {
 	return sortErr
 }
 
-func (h Httpd) getDependencySHA256(release HttpdRelease) (string, error) {
-	if release.sha256URL == "" && release.sha1URL == "" && release.md5URL == "" && !h.dependencyVersionIsMissingChecksum(release.version) {
-		return "", errors.New("could not find checksum file")
+// getDependencyChecksums verifies the release against its signature, or its
+// sha256 when it has no signature or no keyring with pinned fingerprints is
+// configured for httpd. Releases with neither are rejected, as the sha1 and
+// md5 files of older releases are not trusted on their own.
+func (h Httpd) getDependencyChecksums(release HttpdRelease, dependencyPath string) (map[string]string, *Verification, error) {
+	if exception, ok := h.checksumExceptions.Lookup("httpd", release.version); ok {
+		return exceptionChecksums(h.checksummer, exception, dependencyPath)
 	}
 
-	if release.sha256URL != "" {
-		checksumContents, err := h.webClient.Get(release.sha256URL)
+	var verification *Verification
+	switch {
+	case release.signatureURL != "" && h.keyring.Pinned():
+		var err error
+		verification, err = h.verifySignature(release, dependencyPath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+	case release.sha256URL != "":
+		err := h.verifySHA256(release, dependencyPath)
 		if err != nil {
-			return "", fmt.Errorf("could not download sha256 file: %w", err)
+			return nil, nil, err
 		}
 
-		return strings.Fields(string(checksumContents))[0], nil
+		verification = checksumVerification(VerificationSHA256, release.sha256URL)
+		if release.signatureURL != "" {
+			verification = fallbackVerification(VerificationSHA256, release.sha256URL, httpdUnpinnedReason)
+		}
+
+	case release.signatureURL != "":
+		verification = skippedVerification(httpdUnpinnedReason)
+
+	default:
+		return nil, nil, depErrors.UnverifiedError{Version: release.version, Reason: "httpd publishes neither a signature nor a sha256 for this release"}
 	}
 
-	tempDir, err := os.MkdirTemp("", "httpd")
+	checksums, err := h.checksummer.GetChecksums(dependencyPath, checksumAlgorithms...)
 	if err != nil {
-		return "", fmt.Errorf("could not make temp dir: %w", err)
+		return nil, nil, fmt.Errorf("could not get sha256: %w", err)
 	}
-	defer os.RemoveAll(tempDir)
 
-	dependencyPath := filepath.Join(tempDir, filepath.Base(release.dependencyURL))
+	return checksums, verification, nil
+}
+
+// httpdUnpinnedReason is why the signature of a release is not checked while
+// no keyring with pinned fingerprints is configured for httpd.
+const httpdUnpinnedReason = "no keyring with pinned fingerprints is configured for httpd"
 
-	err = h.webClient.Download(release.dependencyURL, dependencyPath)
+// verifySHA256 verifies the release against the sha256 file published next
+// to it.
+func (h Httpd) verifySHA256(release HttpdRelease, dependencyPath string) error {
+	checksumContents, err := h.webClient.Get(release.sha256URL)
 	if err != nil {
-		return "", fmt.Errorf("could not download dependency: %w", err)
+		return fmt.Errorf("could not download sha256 file: %w", err)
 	}
 
-	err = h.verifyChecksum(release, dependencyPath)
-	if err != nil {
-		return "", fmt.Errorf("could not verify checksum: %w", err)
+	fields := strings.Fields(string(checksumContents))
+	if len(fields) == 0 {
+		return fmt.Errorf("could not read sha256 file: %w", depErrors.ParseError{Err: fmt.Errorf("%s is empty", release.sha256URL)})
 	}
 
-	sha256, err := h.checksummer.GetSHA256(dependencyPath)
+	err = h.checksummer.VerifySHA256(dependencyPath, fields[0])
 	if err != nil {
-		return "", fmt.Errorf("could not get sha256: %w", err)
+		return fmt.Errorf("could not verify sha256: %w", err)
 	}
 
-	return sha256, nil
+	return nil
 }
 
-func (h Httpd) verifyChecksum(release HttpdRelease, dependencyPath string) error {
-	if h.dependencyVersionIsMissingChecksum(release.version) {
-		return nil
+// verifySignature verifies the release against its signature, with the keys
+// of the httpd KEYS file whose fingerprints are pinned.
+func (h Httpd) verifySignature(release HttpdRelease, dependencyPath string) (*Verification, error) {
+	keyring, err := fetchKeys(h.cache, h.webClient, h.keyring)
+	if err != nil {
+		return nil, fmt.Errorf("could not get httpd KEYS: %w", err)
 	}
 
-	if release.sha1URL != "" {
-		checksumContents, err := h.webClient.Get(release.sha1URL)
-		if err != nil {
-			return fmt.Errorf("could not download sha1 file: %w", err)
-		}
-
-		fields := strings.Fields(string(checksumContents))
-
-		var checksum string
-		if strings.HasPrefix(fields[0], "SHA1") {
-			checksum = fields[len(fields)-1]
-		} else {
-			checksum = fields[0]
-		}
-
-		err = h.checksummer.VerifySHA1(dependencyPath, checksum)
-		if err != nil {
-			return fmt.Errorf("could not verify sha1: %w", err)
-		}
-	} else if release.md5URL != "" {
-		checksumContents, err := h.webClient.Get(release.md5URL)
-		if err != nil {
-			return fmt.Errorf("could not download md5 file: %w", err)
-		}
-
-		fields := strings.Fields(string(checksumContents))
-
-		var checksum string
-		if strings.HasPrefix(fields[0], "MD5") {
-			checksum = fields[len(fields)-1]
-		} else {
-			checksum = fields[0]
-		}
-
-		err = h.checksummer.VerifyMD5(dependencyPath, checksum)
-		if err != nil {
-			return fmt.Errorf("could not verify md5: %w", err)
-		}
+	dependencySignature, err := h.webClient.Get(release.signatureURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not get dependency signature: %w", err)
 	}
 
-	return nil
-}
-
-func (h Httpd) dependencyVersionIsMissingChecksum(version string) bool {
-	versionsWithMissingChecksum := map[string]bool{
-		"2.2.3": true,
+	fingerprint, err := h.checksummer.VerifyASC(string(dependencySignature), dependencyPath, keyring)
+	if err != nil {
+		return nil, fmt.Errorf("dependency signature verification failed: %w", err)
 	}
 
-	_, shouldBeIgnored := versionsWithMissingChecksum[version]
-	return shouldBeIgnored
+	return signatureVerification(release.signatureURL, fingerprint), nil
 }
@@ -1,6 +1,7 @@
 package dependency_test
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"testing"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 )
 
 func TestHttpd(t *testing.T) {
@@ -26,6 +28,7 @@ func testHttpd(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer      *dependencyfakes.FakeChecksummer
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		httpd                dependency.Dependency
@@ -40,11 +43,21 @@ func testHttpd(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		httpd, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("httpd")
+		httpd, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator,
+			dependency.WithArtifactStore(fakeArtifactStore),
+			dependency.WithKeyring("httpd", dependency.Keyring{
+				KeyURLs: []dependency.KeySource{{
+					URL:          "https://archive.apache.org/dist/httpd/KEYS",
+					Fingerprints: []string{"some-fingerprint"},
+				}},
+			}),
+		).NewDependency("httpd")
 		require.NoError(err)
 	})
 
@@ -67,17 +80,20 @@ func testHttpd(t *testing.T, when spec.G, it spec.S) {
 			}, versions)
 
 			urlArg, _ := fakeWebClient.GetArgsForCall(0)
-			assert.Equal("http://archive.apache.org/dist/httpd/?F=2&C=M&O=D&P=httpd-*.tar.bz2*", urlArg)
+			assert.Equal("https://archive.apache.org/dist/httpd/?F=2&C=M&O=D&P=httpd-*.tar.bz2*", urlArg)
 		})
 	})
 
 	when("GetDependencyVersion", func() {
 		var expectedReleaseDate = time.Date(2020, 03, 30, 14, 21, 0, 0, time.UTC)
 
-		it("returns the correct httpd version", func() {
+		it("returns the correct httpd version verified against its signature", func() {
 			fakeWebClient.GetReturnsOnCall(0, []byte(httpdIndex2443), nil)
+			fakeWebClient.GetReturnsOnCall(1, []byte("some-keys"), nil)
+			fakeWebClient.GetReturnsOnCall(2, []byte("some-signature"), nil)
 
-			fakeWebClient.GetReturnsOnCall(1, []byte("some-sha256 *httpd-2.4.43.tar.bz2"), nil)
+			fakeChecksummer.VerifyASCReturns("some-fingerprint", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/httpd@2.4.43?checksum=some-sha256&download_url=http://archive.apache.org/dist")
@@ -88,159 +104,203 @@ func testHttpd(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 			expectedDepVersion := dependency.DepVersion{
-				Version:         "2.4.43",
-				URI:             "http://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2",
-				SHA256:          "some-sha256",
+				Version:  "2.4.43",
+				URI:      "https://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2",
+				SHA256:   "some-sha256",
+				Checksum: "sha256:some-sha256",
+				Checksums: map[string]string{
+					"sha256": "some-sha256",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: nil,
 				CPE:             "cpe:2.3:a:apache:http_server:2.4.43:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/httpd@2.4.43?checksum=some-sha256&download_url=http://archive.apache.org/dist",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Verification: &dependency.Verification{
+					Method:      dependency.VerificationPGP,
+					SourceURL:   "https://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2.asc",
+					Fingerprint: "some-fingerprint",
+				},
 			}
 
 			assert.Equal(expectedDepVersion, actualDepVersion)
 
 			urlArg, _ := fakeWebClient.GetArgsForCall(0)
-			assert.Equal("http://archive.apache.org/dist/httpd/?F=2&C=M&O=D&P=httpd-2.4.43.tar.bz2*", urlArg)
+			assert.Equal("https://archive.apache.org/dist/httpd/?F=2&C=M&O=D&P=httpd-*.tar.bz2*", urlArg)
 
 			urlArg, _ = fakeWebClient.GetArgsForCall(1)
-			assert.Equal("http://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2.sha256", urlArg)
-		})
+			assert.Equal("https://archive.apache.org/dist/httpd/KEYS", urlArg)
 
-		when("there is no SHA256 file", func() {
-			it("returns the correct httpd version using the SHA1 file", func() {
-				index := removeLinesContaining(httpdIndex2443, "httpd-2.4.43.tar.bz2.sha256")
-				fakeWebClient.GetReturnsOnCall(0, []byte(index), nil)
+			urlArg, _ = fakeWebClient.GetArgsForCall(2)
+			assert.Equal("https://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2.asc", urlArg)
 
-				fakeWebClient.GetReturnsOnCall(1, []byte("some-sha1 *httpd-2.4.43.tar.bz2"), nil)
+			signatureArg, dependencyPathArg, keyringArg := fakeChecksummer.VerifyASCArgsForCall(0)
+			assert.Equal("some-signature", signatureArg)
+			assert.Equal("some-artifact-path", dependencyPathArg)
+			assert.Equal([]string{"some-keys"}, keyringArg.Keys)
 
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+			assert.Equal(0, fakeChecksummer.VerifySHA256CallCount())
+		})
 
-				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
-				fakePURLGenerator.GenerateReturns("pkg:generic/httpd@2.4.43?checksum=some-sha256&download_url=http://archive.apache.org/dist")
+		when("the signature does not verify", func() {
+			it("returns an error", func() {
+				fakeWebClient.GetReturnsOnCall(0, []byte(httpdIndex2443), nil)
+				fakeChecksummer.VerifyASCReturns("", depErrors.SignatureInvalidError{Reason: "some-reason"})
 
-				actualDepVersion, err := httpd.GetDependencyVersion("2.4.43")
-				require.NoError(err)
+				_, err := httpd.GetDependencyVersion("2.4.43")
+				assert.True(errors.Is(err, depErrors.SignatureInvalidError{Reason: "some-reason"}))
+			})
+		})
 
-				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
-				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
-				expectedDepVersion := dependency.DepVersion{
-					Version:         "2.4.43",
-					URI:             "http://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2",
-					SHA256:          "some-sha256",
-					ReleaseDate:     &expectedReleaseDate,
-					DeprecationDate: nil,
-					CPE:             "cpe:2.3:a:apache:http_server:2.4.43:*:*:*:*:*:*:*",
-					PURL:            "pkg:generic/httpd@2.4.43?checksum=some-sha256&download_url=http://archive.apache.org/dist",
-					Licenses:        []string{"MIT", "MIT-2"},
-				}
+		when("no keyring with pinned fingerprints is configured for httpd", func() {
+			it.Before(func() {
+				var err error
+				httpd, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("httpd")
+				require.NoError(err)
 
-				assert.Equal(expectedDepVersion, actualDepVersion)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256"}, nil)
+			})
 
-				urlArg, _ := fakeWebClient.GetArgsForCall(0)
-				assert.Equal("http://archive.apache.org/dist/httpd/?F=2&C=M&O=D&P=httpd-2.4.43.tar.bz2*", urlArg)
+			it("verifies the version against the SHA256 file and records why the signature was skipped", func() {
+				fakeWebClient.GetReturnsOnCall(0, []byte(httpdIndex2443), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte("some-sha256 *httpd-2.4.43.tar.bz2"), nil)
 
-				urlArg, _ = fakeWebClient.GetArgsForCall(1)
-				assert.Equal("http://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2.sha1", urlArg)
+				actualDepVersion, err := httpd.GetDependencyVersion("2.4.43")
+				require.NoError(err)
 
-				urlArg, dependencyPathDownloadArg, _ := fakeWebClient.DownloadArgsForCall(0)
-				assert.Equal("http://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2", urlArg)
+				assert.Equal(&dependency.Verification{
+					Method:     dependency.VerificationSHA256,
+					SourceURL:  "https://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2.sha256",
+					SkipReason: "no keyring with pinned fingerprints is configured for httpd",
+				}, actualDepVersion.Verification)
+				assert.False(actualDepVersion.Verification.Verified())
 
-				dependencyPathVerifyArg, checksumArg := fakeChecksummer.VerifySHA1ArgsForCall(0)
-				assert.Equal("some-sha1", checksumArg)
-				assert.Equal(dependencyPathDownloadArg, dependencyPathVerifyArg)
+				dependencyPathArg, sha256Arg := fakeChecksummer.VerifySHA256ArgsForCall(0)
+				assert.Equal("some-artifact-path", dependencyPathArg)
+				assert.Equal("some-sha256", sha256Arg)
+				assert.Equal(0, fakeChecksummer.VerifyASCCallCount())
 			})
 
-			when("the SHA1 file is in a different format", func() {
-				it("returns the correct httpd version using the SHA1 file", func() {
+			when("there is no SHA256 file", func() {
+				it("records the version as unverified without checking its signature", func() {
 					index := removeLinesContaining(httpdIndex2443, "httpd-2.4.43.tar.bz2.sha256")
 					fakeWebClient.GetReturnsOnCall(0, []byte(index), nil)
 
-					fakeWebClient.GetReturnsOnCall(1, []byte("SHA1(httpd-2.2.14.tar.gz)= some-sha1"), nil)
-
-					fakeChecksummer.GetSHA256Returns("some-sha256", nil)
-
-					depVersion, err := httpd.GetDependencyVersion("2.4.43")
+					actualDepVersion, err := httpd.GetDependencyVersion("2.4.43")
 					require.NoError(err)
 
-					assert.Equal("some-sha256", depVersion.SHA256)
-
-					_, checksumArg := fakeChecksummer.VerifySHA1ArgsForCall(0)
-					assert.Equal("some-sha1", checksumArg)
+					assert.Equal(&dependency.Verification{
+						Method:     dependency.VerificationNone,
+						SkipReason: "no keyring with pinned fingerprints is configured for httpd",
+					}, actualDepVersion.Verification)
+					assert.Equal(1, fakeWebClient.GetCallCount())
+					assert.Equal(0, fakeChecksummer.VerifyASCCallCount())
 				})
 			})
 		})
 
-		when("there are no SHA256 or SHA1 files", func() {
-			it("returns the correct httpd version using the MD5 file", func() {
-				index := removeLinesContaining(httpdIndex2443, "httpd-2.4.43.tar.bz2.sha256")
-				index = removeLinesContaining(index, "httpd-2.4.43.tar.bz2.sha1")
+		when("there is no signature", func() {
+			it("returns the correct httpd version verified against the SHA256 file", func() {
+				index := removeLinesContaining(httpdIndex2443, "httpd-2.4.43.tar.bz2.asc")
 				fakeWebClient.GetReturnsOnCall(0, []byte(index), nil)
 
-				fakeWebClient.GetReturnsOnCall(1, []byte("some-md5 httpd-2.4.43.tar.bz2"), nil)
-
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte("some-sha256 *httpd-2.4.43.tar.bz2"), nil)
 
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/httpd@2.4.43?checksum=some-sha256&download_url=http://archive.apache.org/dist")
 
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
+
 				actualDepVersion, err := httpd.GetDependencyVersion("2.4.43")
 				require.NoError(err)
 
-				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
-				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedDepVersion := dependency.DepVersion{
-					Version:         "2.4.43",
-					URI:             "http://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2",
-					SHA256:          "some-sha256",
+					Version:  "2.4.43",
+					URI:      "https://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: nil,
 					CPE:             "cpe:2.3:a:apache:http_server:2.4.43:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/httpd@2.4.43?checksum=some-sha256&download_url=http://archive.apache.org/dist",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2.sha256"},
 				}
 
 				assert.Equal(expectedDepVersion, actualDepVersion)
 
-				urlArg, _ := fakeWebClient.GetArgsForCall(0)
-				assert.Equal("http://archive.apache.org/dist/httpd/?F=2&C=M&O=D&P=httpd-2.4.43.tar.bz2*", urlArg)
-
-				urlArg, _ = fakeWebClient.GetArgsForCall(1)
-				assert.Equal("http://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2.md5", urlArg)
+				urlArg, _ := fakeWebClient.GetArgsForCall(1)
+				assert.Equal("https://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2.sha256", urlArg)
 
-				urlArg, dependencyPathDownloadArg, _ := fakeWebClient.DownloadArgsForCall(0)
-				assert.Equal("http://archive.apache.org/dist/httpd/httpd-2.4.43.tar.bz2", urlArg)
+				dependencyPathArg, sha256Arg := fakeChecksummer.VerifySHA256ArgsForCall(0)
+				assert.Equal("some-artifact-path", dependencyPathArg)
+				assert.Equal("some-sha256", sha256Arg)
 
-				dependencyPathVerifyArg, checksumArg := fakeChecksummer.VerifyMD5ArgsForCall(0)
-				assert.Equal("some-md5", checksumArg)
-				assert.Equal(dependencyPathDownloadArg, dependencyPathVerifyArg)
+				assert.Equal(0, fakeChecksummer.VerifyASCCallCount())
 			})
+		})
 
-			when("the MD5 file in a different format", func() {
-				it("returns the correct httpd version using the MD5 file", func() {
-					index := removeLinesContaining(httpdIndex2443, "httpd-2.4.43.tar.bz2.sha256")
-					index = removeLinesContaining(index, "httpd-2.4.43.tar.bz2.sha1")
-					fakeWebClient.GetReturnsOnCall(0, []byte(index), nil)
+		when("the SHA256 file is empty", func() {
+			it("returns a ParseError", func() {
+				index := removeLinesContaining(httpdIndex2443, "httpd-2.4.43.tar.bz2.asc")
+				fakeWebClient.GetReturnsOnCall(0, []byte(index), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte(" \n"), nil)
 
-					fakeWebClient.GetReturnsOnCall(1, []byte("MD5 (httpd-2.4.43.tar.gz) = some-md5"), nil)
+				_, err := httpd.GetDependencyVersion("2.4.43")
+				assert.True(errors.As(err, &depErrors.ParseError{}), "expected a ParseError, got %v", err)
+				assert.Equal(0, fakeChecksummer.VerifySHA256CallCount())
+			})
+		})
 
-					fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+		when("there is neither a signature nor a SHA256 file", func() {
+			it("returns an unverified error without trusting the SHA1 or MD5 files", func() {
+				index := removeLinesContaining(httpdIndex2443, "httpd-2.4.43.tar.bz2.asc")
+				index = removeLinesContaining(index, "httpd-2.4.43.tar.bz2.sha256")
+				fakeWebClient.GetReturnsOnCall(0, []byte(index), nil)
 
-					depVersion, err := httpd.GetDependencyVersion("2.4.43")
-					require.NoError(err)
+				_, err := httpd.GetDependencyVersion("2.4.43")
+				assert.True(errors.Is(err, depErrors.UnverifiedError{Version: "2.4.43", Reason: "httpd publishes neither a signature nor a sha256 for this release"}))
+
+				assert.Equal(1, fakeWebClient.GetCallCount())
+				assert.Equal(0, fakeChecksummer.VerifySHA1CallCount())
+				assert.Equal(0, fakeChecksummer.VerifyMD5CallCount())
+			})
+		})
 
-					assert.Equal("some-sha256", depVersion.SHA256)
+		when("the version does not exist", func() {
+			it("returns a VersionNotFoundError", func() {
+				fakeWebClient.GetReturnsOnCall(0, []byte(httpdIndex2443), nil)
 
-					_, checksumArg := fakeChecksummer.VerifyMD5ArgsForCall(0)
-					assert.Equal("some-md5", checksumArg)
-				})
+				_, err := httpd.GetDependencyVersion("2.4.44")
+				assert.True(errors.Is(err, depErrors.VersionNotFoundError{Dependency: "httpd", Version: "2.4.44"}), "expected a VersionNotFoundError, got %v", err)
 			})
 		})
 
-		when("the version is known to be missing a checksum", func() {
+		when("the version has a checksum exception", func() {
+			it.Before(func() {
+				var err error
+				httpd, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator,
+					dependency.WithArtifactStore(fakeArtifactStore),
+					dependency.WithChecksumExceptions(dependency.ChecksumExceptions{
+						Version: 1,
+						Exceptions: []dependency.ChecksumException{{
+							Dependency: "httpd",
+							Version:    "2.2.3",
+							Reason:     "archive.apache.org does not publish a checksum file for this release",
+						}},
+					}),
+				).NewDependency("httpd")
+				require.NoError(err)
+			})
+
 			it("returns the correct httpd version without verifying the checksum", func() {
 				fakeWebClient.GetReturnsOnCall(0, []byte(httpdIndex2_2_3), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/httpd@2.2.3?checksum=some-sha256&download_url=http://archive.apache.org/dist")
 
@@ -252,14 +312,20 @@ func testHttpd(t *testing.T, when spec.G, it spec.S) {
 
 				expectedReleaseDate223 := time.Date(2006, 07, 27, 17, 39, 0, 0, time.UTC)
 				expectedDepVersion := dependency.DepVersion{
-					Version:         "2.2.3",
-					URI:             "http://archive.apache.org/dist/httpd/httpd-2.2.3.tar.bz2",
-					SHA256:          "some-sha256",
+					Version:  "2.2.3",
+					URI:      "https://archive.apache.org/dist/httpd/httpd-2.2.3.tar.bz2",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate223,
 					DeprecationDate: nil,
 					CPE:             "cpe:2.3:a:apache:http_server:2.2.3:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/httpd@2.2.3?checksum=some-sha256&download_url=http://archive.apache.org/dist",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationNone, SkipReason: "archive.apache.org does not publish a checksum file for this release"},
 				}
 
 				assert.Equal(expectedDepVersion, actualDepVersion)
@@ -272,24 +338,10 @@ func testHttpd(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakeWebClient.GetCallCount())
 
 				urlArg, _ := fakeWebClient.GetArgsForCall(0)
-				assert.Equal("http://archive.apache.org/dist/httpd/?F=2&C=M&O=D&P=httpd-2.2.3.tar.bz2*", urlArg)
-
-				urlArg, _, _ = fakeWebClient.DownloadArgsForCall(0)
-				assert.Equal("http://archive.apache.org/dist/httpd/httpd-2.2.3.tar.bz2", urlArg)
-			})
-		})
+				assert.Equal("https://archive.apache.org/dist/httpd/?F=2&C=M&O=D&P=httpd-*.tar.bz2*", urlArg)
 
-		when("there are no checksum files", func() {
-			it("returns an error", func() {
-				index := removeLinesContaining(httpdIndex2443, "httpd-2.4.43.tar.bz2.sha256")
-				index = removeLinesContaining(index, "httpd-2.4.43.tar.bz2.sha1")
-				index = removeLinesContaining(index, "httpd-2.4.43.tar.bz2.md5")
-				index = removeLinesContaining(index, "httpd-2.4.43.tar.bz2.asc")
-				fakeWebClient.GetReturnsOnCall(0, []byte(index), nil)
-
-				_, err := httpd.GetDependencyVersion("2.4.43")
-				assert.Error(err)
-				assert.Contains(err.Error(), "could not find checksum file")
+				urlArg, _ = fakeArtifactStore.FetchArgsForCall(0)
+				assert.Equal("https://archive.apache.org/dist/httpd/httpd-2.2.3.tar.bz2", urlArg)
 			})
 		})
 	})
@@ -4,15 +4,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
 	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal/internal_errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type ICU struct {
@@ -22,6 +23,10 @@ type ICU struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
+	tempDirs         internal.TempDirs
+	keyring          Keyring
 }
 
 func (i ICU) GetAllVersionRefs() ([]string, error) {
@@ -64,7 +69,7 @@ func (i ICU) GetDependencyVersion(version string) (DepVersion, error) {
 			return depVersion, nil
 		}
 	}
-	return DepVersion{}, fmt.Errorf("could not find ICU version %s", version)
+	return DepVersion{}, depErrors.VersionNotFoundError{Dependency: "ICU", Version: version}
 }
 
 func (i ICU) GetReleaseDate(version string) (*time.Time, error) {
@@ -79,22 +84,17 @@ func (i ICU) GetReleaseDate(version string) (*time.Time, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("could not find ICU version %s", version)
+	return nil, depErrors.VersionNotFoundError{Dependency: "ICU", Version: version}
 }
 
 func (i ICU) createDependencyVersion(version string, release internal.GithubRelease) (DepVersion, error) {
-	pgpKeysBlock, err := i.webClient.Get("https://raw.githubusercontent.com/unicode-org/icu/master/KEYS")
-	if err != nil {
-		return DepVersion{}, fmt.Errorf("could not get ICU GPG key: %w", err)
-	}
-	pgpKeys := i.checksummer.SplitPGPKeys(string(pgpKeysBlock))
-
 	icuVersion := versionToICUVersion(version)
 	assetName := fmt.Sprintf("icu4c-%s-src.tgz", icuVersion)
-	assetDir, err := os.MkdirTemp("", "icu")
+	assetDir, err := i.tempDirs.MkdirTemp("icu")
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("failed to create temp directory: %w", err)
 	}
+	defer i.tempDirs.Remove(assetDir)
 	releaseAssetPath := filepath.Join(assetDir, assetName)
 
 	tag := versionToTag(version)
@@ -114,45 +114,76 @@ func (i ICU) createDependencyVersion(version string, release internal.GithubRele
 	asset := Asset{}
 	err = json.Unmarshal(assetContent, &asset)
 	if err != nil {
-		return DepVersion{}, fmt.Errorf("could not unmarshal asset url content: %w", err)
-	}
-
-	assetName = fmt.Sprintf("icu4c-%s-src.tgz.asc", icuVersion)
-	releaseAssetSignature, err := i.githubClient.GetReleaseAsset("unicode-org", "icu", tag, assetName)
-	if err != nil {
-		if errors.Is(err, internal_errors.AssetNotFound{AssetName: assetName}) {
-			return DepVersion{}, depErrors.NoSourceCodeError{Version: version}
-		}
-		return DepVersion{}, fmt.Errorf("could not get release artifact signature: %w", err)
+		return DepVersion{}, fmt.Errorf("could not unmarshal asset url content: %w", depErrors.ParseError{Err: err})
 	}
 
-	err = i.checksummer.VerifyASC(string(releaseAssetSignature), releaseAssetPath, pgpKeys...)
+	verification, err := i.verifySignature(version, icuVersion, releaseAssetPath)
 	if err != nil {
-		return DepVersion{}, fmt.Errorf("release artifact signature verification failed: %w", err)
+		return DepVersion{}, err
 	}
 
-	dependencySHA, err := i.checksummer.GetSHA256(releaseAssetPath)
+	checksums, err := i.checksummer.GetChecksums(releaseAssetPath, checksumAlgorithms...)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get SHA256: %w", err)
 	}
+	dependencySHA := checksums["sha256"]
 
-	licenses, err := i.licenseRetriever.LookupLicenses("icu", asset.BrowserDownloadUrl)
+	licenses, licenseExpression, licenseSources, err := resolveLicenses(i.licenseRetriever, "icu", releaseAssetPath,
+		githubLicenses(i.githubClient, "unicode-org", "icu", tag),
+	)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
 
 	return DepVersion{
-		Version:         version,
-		URI:             asset.BrowserDownloadUrl,
-		SHA256:          dependencySHA,
-		ReleaseDate:     &release.CreatedDate,
-		DeprecationDate: nil,
-		CPE:             fmt.Sprintf(`cpe:2.3:a:icu-project:international_components_for_unicode:%s:*:*:*:*:c\/c\+\+:*:*`, version),
-		PURL:            i.purlGenerator.Generate("icu", version, dependencySHA, asset.BrowserDownloadUrl),
-		Licenses:        licenses,
+		Version:           version,
+		URI:               asset.BrowserDownloadUrl,
+		SHA256:            dependencySHA,
+		Checksum:          prefixedChecksum(checksums),
+		Checksums:         checksums,
+		ReleaseDate:       &release.CreatedDate,
+		DeprecationDate:   nil,
+		CPE:               cpe.Application("icu-project", "international_components_for_unicode", version).WithTargetSoftware("c/c++").String(),
+		PURL:              i.purlGenerator.Generate(purl.Generic("icu", version).WithSource(dependencySHA, asset.BrowserDownloadUrl)),
+		Licenses:          licenses,
+		LicenseExpression: licenseExpression,
+		LicenseSources:    licenseSources,
+		Verification:      verification,
 	}, nil
 }
 
+// verifySignature verifies the release asset against its signature. The
+// default ICU keyring pins no fingerprints yet, so unless its keys are pinned,
+// the asset is recorded as unverified rather than checked against whichever
+// keys the ICU KEYS file lists.
+func (i ICU) verifySignature(version, icuVersion, releaseAssetPath string) (*Verification, error) {
+	if !i.keyring.Pinned() {
+		return skippedVerification("no keyring with pinned fingerprints is configured for ICU"), nil
+	}
+
+	keyring, err := fetchKeys(i.cache, i.webClient, i.keyring)
+	if err != nil {
+		return nil, fmt.Errorf("could not get ICU GPG key: %w", err)
+	}
+
+	tag := versionToTag(version)
+	assetName := fmt.Sprintf("icu4c-%s-src.tgz.asc", icuVersion)
+	releaseAssetSignature, err := i.githubClient.GetReleaseAsset("unicode-org", "icu", tag, assetName)
+	if err != nil {
+		if errors.Is(err, internal_errors.AssetNotFound{AssetName: assetName}) {
+			return nil, depErrors.NoSourceCodeError{Version: version}
+		}
+		return nil, fmt.Errorf("could not get release artifact signature: %w", err)
+	}
+
+	fingerprint, err := i.checksummer.VerifyASC(string(releaseAssetSignature), releaseAssetPath, keyring)
+	if err != nil {
+		return nil, fmt.Errorf("release artifact signature verification failed: %w", err)
+	}
+
+	return signatureVerification(releaseAssetURL("unicode-org", "icu", tag, assetName), fingerprint), nil
+}
+
 func tagToVersion(tagName string) string {
 	version := strings.TrimPrefix(tagName, "release-")
 	version = strings.ReplaceAll(version, "-", ".")
@@ -170,7 +201,7 @@ func versionToTag(version string) string {
 }
 
 func (i ICU) getAllVersions() ([]internal.GithubRelease, error) {
-	releases, err := i.githubClient.GetReleaseTags("unicode-org", "icu")
+	releases, err := cachedReleaseTags(i.cache, i.githubClient, "unicode-org", "icu")
 	if err != nil {
 		return nil, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -36,6 +36,7 @@ func testICU(t *testing.T, when spec.G, it spec.S) {
 
 	it.Before(func() {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
+		fakeChecksummer.VerifyASCReturns("some-fingerprint", nil)
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
@@ -43,7 +44,14 @@ func testICU(t *testing.T, when spec.G, it spec.S) {
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		icu, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("icu")
+		icu, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator,
+			dependency.WithKeyring("icu", dependency.Keyring{
+				KeyURLs: []dependency.KeySource{{
+					URL:          "https://raw.githubusercontent.com/unicode-org/icu/master/KEYS",
+					Fingerprints: []string{"some-fingerprint"},
+				}},
+			}),
+		).NewDependency("icu")
 		require.NoError(err)
 	})
 
@@ -94,12 +102,11 @@ func testICU(t *testing.T, when spec.G, it spec.S) {
 				{TagName: "release-65-1", CreatedDate: time.Date(2020, 10, 02, 21, 30, 54, 0, time.UTC)},
 			}, nil)
 			assetUrlContent := `{"browser_download_url":"some-source-url", "key":"some_value"}`
-			fakeWebClient.GetReturnsOnCall(0, []byte("some-gpg-key"), nil)
-			fakeWebClient.GetReturnsOnCall(1, []byte(assetUrlContent), nil)
+			fakeWebClient.GetReturnsOnCall(0, []byte(assetUrlContent), nil)
+			fakeWebClient.GetReturnsOnCall(1, []byte("some-gpg-key"), nil)
 			fakeGithubClient.GetReleaseAssetReturns([]byte("some-signature"), nil)
 			fakeGithubClient.DownloadReleaseAssetReturns("some-asset-url", nil)
-			fakeChecksummer.GetSHA256Returns("some-source-sha", nil)
-			fakeChecksummer.SplitPGPKeysReturns([]string{"some-gpg-key"})
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha", "sha512": "some-sha512"}, nil)
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/icu@66.1?checksum=some-source-sha&download_url=some-source-url")
 
@@ -110,19 +117,26 @@ func testICU(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 			expectedReleaseDate := time.Date(2020, 03, 11, 17, 21, 07, 0, time.UTC)
 			expectedDep := dependency.DepVersion{
-				Version:         "66.1",
-				URI:             "some-source-url",
-				SHA256:          "some-source-sha",
+				Version:  "66.1",
+				URI:      "some-source-url",
+				SHA256:   "some-source-sha",
+				Checksum: "sha256:some-source-sha",
+				Checksums: map[string]string{
+					"sha256": "some-source-sha",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: nil,
 				CPE:             `cpe:2.3:a:icu-project:international_components_for_unicode:66.1:*:*:*:*:c\/c\+\+:*:*`,
 				PURL:            "pkg:generic/icu@66.1?checksum=some-source-sha&download_url=some-source-url",
 				Licenses:        []string{"MIT", "MIT-2"},
+				LicenseSources:  map[string]dependency.LicenseSource{"MIT": dependency.LicenseSourceFileScan, "MIT-2": dependency.LicenseSourceFileScan},
+				Verification:    &dependency.Verification{Method: dependency.VerificationPGP, SourceURL: "https://github.com/unicode-org/icu/releases/download/release-66-1/icu4c-66_1-src.tgz.asc", Fingerprint: "some-fingerprint"},
 			}
 
 			assert.Equal(expectedDep, actualDep)
 
-			url, _ := fakeWebClient.GetArgsForCall(0)
+			url, _ := fakeWebClient.GetArgsForCall(1)
 			assert.Equal("https://raw.githubusercontent.com/unicode-org/icu/master/KEYS", url)
 
 			orgArg, repoArg, versionArg, filenameArg, _ := fakeGithubClient.DownloadReleaseAssetArgsForCall(0)
@@ -137,11 +151,9 @@ func testICU(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal("release-66-1", versionArg)
 			assert.Equal("icu4c-66_1-src.tgz.asc", filenameArg)
 
-			assert.Equal("some-gpg-key", fakeChecksummer.SplitPGPKeysArgsForCall(0))
-
 			releaseAssetSignatureArg, _, gpgKeysArg := fakeChecksummer.VerifyASCArgsForCall(0)
 			assert.Equal("some-signature", releaseAssetSignatureArg)
-			assert.Equal([]string{"some-gpg-key"}, gpgKeysArg)
+			assert.Equal([]string{"some-gpg-key"}, gpgKeysArg.Keys)
 		})
 
 		when("getting a version prior to 49", func() {
@@ -152,11 +164,11 @@ func testICU(t *testing.T, when spec.G, it spec.S) {
 					{TagName: "release-4-8-1", CreatedDate: time.Date(2019, 04, 10, 18, 17, 52, 0, time.UTC)},
 				}, nil)
 				assetUrlContent := `{"browser_download_url":"some-source-url", "key":"some_value"}`
-				fakeWebClient.GetReturnsOnCall(0, []byte("some-gpg-key"), nil)
-				fakeWebClient.GetReturnsOnCall(1, []byte(assetUrlContent), nil)
+				fakeWebClient.GetReturnsOnCall(0, []byte(assetUrlContent), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte("some-gpg-key"), nil)
 				fakeGithubClient.GetReleaseAssetReturns([]byte("some-signature"), nil)
 				fakeGithubClient.DownloadReleaseAssetReturns("some-asset-url", nil)
-				fakeChecksummer.GetSHA256Returns("some-source-sha", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/icu@4.8.2?checksum=some-source-sha&download_url=some-source-url")
 
@@ -167,19 +179,26 @@ func testICU(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedReleaseDate := time.Date(2019, 04, 11, 18, 17, 52, 0, time.UTC)
 				expectedDep := dependency.DepVersion{
-					Version:         "4.8.2",
-					URI:             "some-source-url",
-					SHA256:          "some-source-sha",
+					Version:  "4.8.2",
+					URI:      "some-source-url",
+					SHA256:   "some-source-sha",
+					Checksum: "sha256:some-source-sha",
+					Checksums: map[string]string{
+						"sha256": "some-source-sha",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: nil,
 					CPE:             `cpe:2.3:a:icu-project:international_components_for_unicode:4.8.2:*:*:*:*:c\/c\+\+:*:*`,
 					PURL:            "pkg:generic/icu@4.8.2?checksum=some-source-sha&download_url=some-source-url",
 					Licenses:        []string{"MIT", "MIT-2"},
+					LicenseSources:  map[string]dependency.LicenseSource{"MIT": dependency.LicenseSourceFileScan, "MIT-2": dependency.LicenseSourceFileScan},
+					Verification:    &dependency.Verification{Method: dependency.VerificationPGP, SourceURL: "https://github.com/unicode-org/icu/releases/download/release-4-8-2/icu4c-4_8_2-src.tgz.asc", Fingerprint: "some-fingerprint"},
 				}
 
 				assert.Equal(expectedDep, actualDep)
 
-				url, _ := fakeWebClient.GetArgsForCall(0)
+				url, _ := fakeWebClient.GetArgsForCall(1)
 				assert.Equal("https://raw.githubusercontent.com/unicode-org/icu/master/KEYS", url)
 
 				orgArg, repoArg, versionArg, filenameArg, _ := fakeGithubClient.DownloadReleaseAssetArgsForCall(0)
@@ -196,14 +215,39 @@ func testICU(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("no keyring with pinned fingerprints is configured for ICU", func() {
+			it("records the version as unverified without checking its signature", func() {
+				icu, err := dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("icu")
+				require.NoError(err)
+
+				fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{
+					{TagName: "release-66-1", CreatedDate: time.Date(2020, 03, 11, 17, 21, 07, 0, time.UTC)},
+				}, nil)
+				fakeWebClient.GetReturns([]byte(`{"browser_download_url":"some-source-url"}`), nil)
+				fakeGithubClient.DownloadReleaseAssetReturns("some-asset-url", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha"}, nil)
+
+				actualDep, err := icu.GetDependencyVersion("66.1")
+				require.NoError(err)
+
+				assert.Equal(&dependency.Verification{
+					Method:     dependency.VerificationNone,
+					SkipReason: "no keyring with pinned fingerprints is configured for ICU",
+				}, actualDep.Verification)
+				assert.Equal(1, fakeWebClient.GetCallCount())
+				assert.Equal(0, fakeGithubClient.GetReleaseAssetCallCount())
+				assert.Equal(0, fakeChecksummer.VerifyASCCallCount())
+			})
+		})
+
 		when("the asset cannot be found", func() {
 			it("returns a NoSourceCode error", func() {
 				fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{
 					{TagName: "release-66-1", CreatedDate: time.Date(2020, 03, 11, 17, 21, 07, 0, time.UTC)},
 				}, nil)
 				assetUrlContent := `{"browser_download_url":"some-source-url", "key":"some_value"}`
-				fakeWebClient.GetReturnsOnCall(0, []byte("some-gpg-key"), nil)
-				fakeWebClient.GetReturnsOnCall(1, []byte(assetUrlContent), nil)
+				fakeWebClient.GetReturnsOnCall(0, []byte(assetUrlContent), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte("some-gpg-key"), nil)
 				fakeGithubClient.DownloadReleaseAssetReturns("", internal_errors.AssetNotFound{AssetName: "icu4c-66_1-src.tgz"})
 
 				_, err := icu.GetDependencyVersion("66.1")
@@ -0,0 +1,257 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+)
+
+type Downloader interface {
+	Download(url, filename string, options ...RequestOption) error
+}
+
+// ArtifactStore downloads artifacts into a temporary directory, keyed by the
+// SHA256 of their contents, so that hashing, signature verification and
+// license detection can all work from a single local copy.
+//
+// An artifact is kept until every path returned by Fetch for it has been
+// given back to Release. While it is kept, fetching the same url again, or
+// another url with the same contents, returns the stored copy. A stored copy
+// is only returned if it has the SHA256 and fits the maximum size given to
+// Fetch, and urls fetched with a URL policy of their own are stored apart
+// from the same urls fetched with another. Copies of an ArtifactStore share
+// their contents.
+type ArtifactStore struct {
+	downloader Downloader
+	tempDirs   TempDirs
+	state      *artifactStoreState
+}
+
+type artifactStoreState struct {
+	mutex     sync.Mutex
+	dir       string
+	byKey     map[string]*storedArtifact
+	byDigest  map[string]*storedArtifact
+	byPath    map[string]*storedArtifact
+	downloads map[string]chan struct{}
+}
+
+type storedArtifact struct {
+	digest string
+	size   int64
+	path   string
+	keys   []string
+	refs   int
+}
+
+func NewArtifactStore(downloader Downloader, tempDirs TempDirs) ArtifactStore {
+	return ArtifactStore{
+		downloader: downloader,
+		tempDirs:   tempDirs,
+		state: &artifactStoreState{
+			byKey:     map[string]*storedArtifact{},
+			byDigest:  map[string]*storedArtifact{},
+			byPath:    map[string]*storedArtifact{},
+			downloads: map[string]chan struct{}{},
+		},
+	}
+}
+
+// Fetch returns the local path of the artifact at url, downloading it if it
+// is not already stored. The path must be given back to Release once the
+// caller is done with it.
+func (s ArtifactStore) Fetch(url string, options ...RequestOption) (string, error) {
+	request, err := newRequest(http.MethodGet, url, nil, options...)
+	if err != nil {
+		return "", err
+	}
+	key := storeKey(url, request)
+
+	state := s.state
+
+	state.mutex.Lock()
+	for {
+		if artifact, ok := state.byKey[key]; ok {
+			err := checkStored(url, request, artifact)
+			if err != nil {
+				state.mutex.Unlock()
+				return "", err
+			}
+
+			artifact.refs++
+			state.mutex.Unlock()
+			return artifact.path, nil
+		}
+
+		download, ok := state.downloads[key]
+		if !ok {
+			break
+		}
+
+		state.mutex.Unlock()
+		<-download
+		state.mutex.Lock()
+	}
+
+	download := make(chan struct{})
+	state.downloads[key] = download
+
+	if state.dir == "" {
+		dir, err := s.tempDirs.MkdirTemp("artifacts")
+		if err != nil {
+			delete(state.downloads, key)
+			close(download)
+			state.mutex.Unlock()
+			return "", fmt.Errorf("could not create artifact directory: %w", err)
+		}
+		state.dir = dir
+	}
+	dir := state.dir
+	state.mutex.Unlock()
+
+	tempDir, digest, size, err := s.download(dir, url, options...)
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	delete(state.downloads, key)
+	close(download)
+
+	if err != nil {
+		return "", err
+	}
+
+	artifact, ok := state.byDigest[digest]
+	if ok {
+		_ = os.RemoveAll(tempDir)
+	} else {
+		artifactDir := filepath.Join(dir, digest)
+		err = os.Rename(tempDir, artifactDir)
+		if err != nil {
+			_ = os.RemoveAll(tempDir)
+			return "", fmt.Errorf("could not store artifact: %w", err)
+		}
+
+		artifact = &storedArtifact{
+			digest: digest,
+			size:   size,
+			path:   filepath.Join(artifactDir, artifactName(url)),
+		}
+		state.byDigest[digest] = artifact
+		state.byPath[artifact.path] = artifact
+	}
+
+	artifact.keys = append(artifact.keys, key)
+	artifact.refs++
+	state.byKey[key] = artifact
+
+	return artifact.path, nil
+}
+
+// Release gives back a path returned by Fetch. Once every path for an
+// artifact has been released it is removed from disk.
+func (s ArtifactStore) Release(path string) {
+	state := s.state
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	artifact, ok := state.byPath[path]
+	if !ok {
+		return
+	}
+
+	artifact.refs--
+	if artifact.refs > 0 {
+		return
+	}
+
+	for _, key := range artifact.keys {
+		delete(state.byKey, key)
+	}
+	delete(state.byPath, artifact.path)
+	delete(state.byDigest, artifact.digest)
+	_ = os.RemoveAll(filepath.Dir(artifact.path))
+
+	if len(state.byDigest) == 0 && len(state.downloads) == 0 {
+		_ = s.tempDirs.Remove(state.dir)
+		state.dir = ""
+	}
+}
+
+func (s ArtifactStore) download(dir, url string, options ...RequestOption) (string, string, int64, error) {
+	tempDir, err := os.MkdirTemp(dir, "download")
+	if err != nil {
+		return "", "", 0, fmt.Errorf("could not create download directory: %w", err)
+	}
+
+	artifactPath := filepath.Join(tempDir, artifactName(url))
+	err = s.downloader.Download(url, artifactPath, options...)
+	if err != nil {
+		_ = os.RemoveAll(tempDir)
+		return "", "", 0, fmt.Errorf("could not download artifact: %w", err)
+	}
+
+	file, err := os.Open(artifactPath)
+	if err != nil {
+		_ = os.RemoveAll(tempDir)
+		return "", "", 0, fmt.Errorf("could not open artifact: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		_ = os.RemoveAll(tempDir)
+		return "", "", 0, fmt.Errorf("could not hash artifact: %w", err)
+	}
+
+	return tempDir, fmt.Sprintf("%x", hash.Sum(nil)), size, nil
+}
+
+// storeKey is the key the artifact at url is stored under: the url itself,
+// along with the URL policy given for the request, if any, so that an
+// artifact whose redirects were checked against one policy is not returned
+// to a caller with another.
+func storeKey(url string, request *http.Request) string {
+	policy, ok := request.Context().Value(urlPolicyKey{}).(URLPolicy)
+	if !ok {
+		return url
+	}
+	return fmt.Sprintf("%s %#v", url, policy)
+}
+
+// checkStored applies the WithExpectedSHA256 and WithMaxSize of request to
+// a stored artifact, which may have been downloaded without them. It fails
+// with the errors the download itself would have failed with.
+func checkStored(url string, request *http.Request, artifact *storedArtifact) error {
+	if expected, ok := request.Context().Value(expectedSHA256Key{}).(string); ok && expected != artifact.digest {
+		return fmt.Errorf("could not download artifact: downloaded file does not match expected SHA256: %w", depErrors.ChecksumMismatchError{Algorithm: "SHA256", Expected: expected, Actual: artifact.digest})
+	}
+
+	if limit := maxSize(request); limit > 0 && artifact.size > limit {
+		return fmt.Errorf("could not download artifact: %w", SizeLimitError{URL: url, Limit: limit})
+	}
+
+	return nil
+}
+
+// artifactName is the file name an artifact is stored under, taken from the
+// last element of the url path.
+func artifactName(rawURL string) string {
+	name := "artifact"
+	if parsedURL, err := url.Parse(rawURL); err == nil {
+		if base := path.Base(parsedURL.Path); base != "." && base != "/" {
+			name = base
+		}
+	}
+	return name
+}
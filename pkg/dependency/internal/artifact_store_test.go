@@ -0,0 +1,208 @@
+package internal_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubDownloader struct {
+	mutex    sync.Mutex
+	contents map[string]string
+	calls    []string
+}
+
+func (d *stubDownloader) Download(url, filename string, options ...internal.RequestOption) error {
+	d.mutex.Lock()
+	d.calls = append(d.calls, url)
+	contents, ok := d.contents[url]
+	d.mutex.Unlock()
+
+	if !ok {
+		return errors.New("some-download-error")
+	}
+
+	return os.WriteFile(filename, []byte(contents), 0644)
+}
+
+func TestArtifactStore(t *testing.T) {
+	spec.Run(t, "artifactStore", testArtifactStore, spec.Report(report.Terminal{}))
+}
+
+func testArtifactStore(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert     = assert.New(t)
+		require    = require.New(t)
+		downloader *stubDownloader
+		store      internal.ArtifactStore
+	)
+
+	it.Before(func() {
+		downloader = &stubDownloader{
+			contents: map[string]string{
+				"https://example.com/some-artifact.tgz":         "some-contents",
+				"https://mirror.example.com/some-artifact.tgz":  "some-contents",
+				"https://example.com/other-artifact.tgz?a=b":    "other-contents",
+				"https://example.com/downloads/third-artifact/": "third-contents",
+			},
+		}
+		store = internal.NewArtifactStore(downloader, internal.NewTempDirs())
+	})
+
+	when("Fetch", func() {
+		it("downloads the artifact into a directory named after its SHA256", func() {
+			path, err := store.Fetch("https://example.com/some-artifact.tgz")
+			require.NoError(err)
+			defer store.Release(path)
+
+			assert.Equal("some-artifact.tgz", filepath.Base(path))
+			assert.Equal("6e32ea34db1b3755d7dec972eb72c705338f0dd8e0be881d966963438fb2e800", filepath.Base(filepath.Dir(path)))
+
+			contents, err := os.ReadFile(path)
+			require.NoError(err)
+			assert.Equal("some-contents", string(contents))
+		})
+
+		it("only downloads a url once while it is held", func() {
+			path, err := store.Fetch("https://example.com/some-artifact.tgz")
+			require.NoError(err)
+
+			samePath, err := store.Fetch("https://example.com/some-artifact.tgz")
+			require.NoError(err)
+
+			assert.Equal(path, samePath)
+			assert.Equal([]string{"https://example.com/some-artifact.tgz"}, downloader.calls)
+
+			store.Release(path)
+			assert.FileExists(path)
+
+			store.Release(samePath)
+			assert.NoFileExists(path)
+		})
+
+		it("shares a stored copy between urls with the same contents", func() {
+			path, err := store.Fetch("https://example.com/some-artifact.tgz")
+			require.NoError(err)
+
+			mirrorPath, err := store.Fetch("https://mirror.example.com/some-artifact.tgz")
+			require.NoError(err)
+
+			assert.Equal(path, mirrorPath)
+
+			store.Release(path)
+			store.Release(mirrorPath)
+			assert.NoFileExists(path)
+		})
+
+		it("names artifacts after the last element of the url path", func() {
+			path, err := store.Fetch("https://example.com/other-artifact.tgz?a=b")
+			require.NoError(err)
+			defer store.Release(path)
+			assert.Equal("other-artifact.tgz", filepath.Base(path))
+
+			thirdPath, err := store.Fetch("https://example.com/downloads/third-artifact/")
+			require.NoError(err)
+			defer store.Release(thirdPath)
+			assert.Equal("third-artifact", filepath.Base(thirdPath))
+		})
+
+		it("downloads a url again once it has been released", func() {
+			path, err := store.Fetch("https://example.com/some-artifact.tgz")
+			require.NoError(err)
+			store.Release(path)
+
+			path, err = store.Fetch("https://example.com/some-artifact.tgz")
+			require.NoError(err)
+			store.Release(path)
+
+			assert.Len(downloader.calls, 2)
+		})
+
+		it("waits for an in-flight download of the same url", func() {
+			var (
+				wg    sync.WaitGroup
+				mutex sync.Mutex
+				paths []string
+			)
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					path, err := store.Fetch("https://example.com/some-artifact.tgz")
+					assert.NoError(err)
+
+					mutex.Lock()
+					paths = append(paths, path)
+					mutex.Unlock()
+				}()
+			}
+			wg.Wait()
+
+			assert.Len(downloader.calls, 1)
+			for _, path := range paths {
+				assert.Equal(paths[0], path)
+				store.Release(path)
+			}
+			assert.NoFileExists(paths[0])
+		})
+
+		it("checks a stored copy against the expected SHA256 of the caller", func() {
+			path, err := store.Fetch("https://example.com/some-artifact.tgz")
+			require.NoError(err)
+			defer store.Release(path)
+
+			samePath, err := store.Fetch("https://example.com/some-artifact.tgz", internal.WithExpectedSHA256("6E32EA34DB1B3755D7DEC972EB72C705338F0DD8E0BE881D966963438FB2E800"))
+			require.NoError(err)
+			defer store.Release(samePath)
+			assert.Equal(path, samePath)
+
+			_, err = store.Fetch("https://example.com/some-artifact.tgz", internal.WithExpectedSHA256("some-other-sha"))
+			assert.ErrorAs(err, &depErrors.ChecksumMismatchError{})
+			assert.Len(downloader.calls, 1)
+		})
+
+		it("checks a stored copy against the maximum size of the caller", func() {
+			path, err := store.Fetch("https://example.com/some-artifact.tgz")
+			require.NoError(err)
+			defer store.Release(path)
+
+			_, err = store.Fetch("https://example.com/some-artifact.tgz", internal.WithMaxSize(4))
+			assert.ErrorAs(err, &internal.SizeLimitError{})
+			assert.EqualError(err, "could not download artifact: download of https://example.com/some-artifact.tgz exceeds the maximum size of 4 bytes")
+		})
+
+		it("downloads a url again for a caller with another URL policy", func() {
+			path, err := store.Fetch("https://example.com/some-artifact.tgz")
+			require.NoError(err)
+			defer store.Release(path)
+
+			policyPath, err := store.Fetch("https://example.com/some-artifact.tgz", internal.WithRequestURLPolicy(internal.URLPolicy{Hosts: []string{"example.com"}}))
+			require.NoError(err)
+			defer store.Release(policyPath)
+
+			samePolicyPath, err := store.Fetch("https://example.com/some-artifact.tgz", internal.WithRequestURLPolicy(internal.URLPolicy{Hosts: []string{"example.com"}}))
+			require.NoError(err)
+			defer store.Release(samePolicyPath)
+
+			assert.Equal(path, policyPath)
+			assert.Equal(path, samePolicyPath)
+			assert.Len(downloader.calls, 2)
+		})
+
+		when("the download fails", func() {
+			it("returns an error", func() {
+				_, err := store.Fetch("https://example.com/missing-artifact.tgz")
+				assert.EqualError(err, "could not download artifact: some-download-error")
+			})
+		})
+	})
+}
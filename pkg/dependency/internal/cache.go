@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache memoizes the results of upstream lookups for a bounded amount of
+// time. Copies of a Cache share the same entries, so it can be held by the
+// value-typed dependency implementations and shared between them.
+type Cache struct {
+	ttl     time.Duration
+	mutex   *sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	ready   chan struct{}
+	value   interface{}
+	err     error
+	expires time.Time
+}
+
+// NewCache returns a Cache whose entries expire after ttl. A ttl of zero or
+// less disables memoization and every lookup is passed straight through.
+func NewCache(ttl time.Duration) Cache {
+	return Cache{
+		ttl:     ttl,
+		mutex:   &sync.Mutex{},
+		entries: map[string]*cacheEntry{},
+	}
+}
+
+// Memoize returns the value stored under key, calling fetch to populate it
+// when it is missing or expired. Concurrent callers asking for the same key
+// wait for a single in-flight fetch. Errors are returned to every waiting
+// caller but are never stored, and expired entries are evicted whenever a
+// new entry is added.
+func (c Cache) Memoize(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	if c.ttl <= 0 {
+		return fetch()
+	}
+
+	c.mutex.Lock()
+	entry, ok := c.entries[key]
+	if ok {
+		select {
+		case <-entry.ready:
+			if time.Now().Before(entry.expires) {
+				c.mutex.Unlock()
+				return entry.value, nil
+			}
+			ok = false
+		default:
+		}
+	}
+
+	if ok {
+		c.mutex.Unlock()
+		<-entry.ready
+		return entry.value, entry.err
+	}
+
+	c.evictExpired(time.Now())
+	entry = &cacheEntry{ready: make(chan struct{})}
+	c.entries[key] = entry
+	c.mutex.Unlock()
+
+	entry.value, entry.err = fetch()
+	entry.expires = time.Now().Add(c.ttl)
+
+	if entry.err != nil {
+		c.mutex.Lock()
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.mutex.Unlock()
+	}
+	close(entry.ready)
+
+	return entry.value, entry.err
+}
+
+// Len returns the number of entries held by the cache, including in-flight
+// fetches and expired entries that have not been evicted yet.
+func (c Cache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.entries)
+}
+
+// evictExpired removes the completed entries that expired before now, so
+// that keys which are never asked for again do not stay in memory. It must
+// be called with the mutex held.
+func (c Cache) evictExpired(now time.Time) {
+	for key, entry := range c.entries {
+		select {
+		case <-entry.ready:
+			if !now.Before(entry.expires) {
+				delete(c.entries, key)
+			}
+		default:
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package internal_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	spec.Run(t, "cache", testCache, spec.Report(report.Terminal{}))
+}
+
+func testCache(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	when("Memoize", func() {
+		it("only fetches a key once within the ttl", func() {
+			cache := internal.NewCache(time.Minute)
+
+			calls := 0
+			fetch := func() (interface{}, error) {
+				calls++
+				return "some-value", nil
+			}
+
+			value, err := cache.Memoize("some-key", fetch)
+			require.NoError(err)
+			assert.Equal("some-value", value)
+
+			value, err = cache.Memoize("some-key", fetch)
+			require.NoError(err)
+			assert.Equal("some-value", value)
+
+			assert.Equal(1, calls)
+		})
+
+		it("shares entries between copies of the cache", func() {
+			cache := internal.NewCache(time.Minute)
+			cacheCopy := cache
+
+			calls := 0
+			fetch := func() (interface{}, error) {
+				calls++
+				return "some-value", nil
+			}
+
+			_, err := cache.Memoize("some-key", fetch)
+			require.NoError(err)
+			_, err = cacheCopy.Memoize("some-key", fetch)
+			require.NoError(err)
+
+			assert.Equal(1, calls)
+		})
+
+		it("fetches the key again once the entry has expired", func() {
+			cache := internal.NewCache(10 * time.Millisecond)
+
+			calls := 0
+			fetch := func() (interface{}, error) {
+				calls++
+				return calls, nil
+			}
+
+			value, err := cache.Memoize("some-key", fetch)
+			require.NoError(err)
+			assert.Equal(1, value)
+
+			time.Sleep(20 * time.Millisecond)
+
+			value, err = cache.Memoize("some-key", fetch)
+			require.NoError(err)
+			assert.Equal(2, value)
+		})
+
+		it("evicts expired entries when another key is added", func() {
+			cache := internal.NewCache(10 * time.Millisecond)
+
+			fetch := func() (interface{}, error) {
+				return "some-value", nil
+			}
+
+			_, err := cache.Memoize("some-key", fetch)
+			require.NoError(err)
+			_, err = cache.Memoize("other-key", fetch)
+			require.NoError(err)
+			assert.Equal(2, cache.Len())
+
+			time.Sleep(20 * time.Millisecond)
+
+			_, err = cache.Memoize("another-key", fetch)
+			require.NoError(err)
+			assert.Equal(1, cache.Len())
+		})
+
+		it("does not store errors", func() {
+			cache := internal.NewCache(time.Minute)
+
+			_, err := cache.Memoize("some-key", func() (interface{}, error) {
+				return nil, errors.New("some-error")
+			})
+			assert.EqualError(err, "some-error")
+
+			value, err := cache.Memoize("some-key", func() (interface{}, error) {
+				return "some-value", nil
+			})
+			require.NoError(err)
+			assert.Equal("some-value", value)
+		})
+
+		it("waits for an in-flight fetch of the same key", func() {
+			cache := internal.NewCache(time.Minute)
+
+			var (
+				mutex sync.Mutex
+				calls int
+				wg    sync.WaitGroup
+			)
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					value, err := cache.Memoize("some-key", func() (interface{}, error) {
+						mutex.Lock()
+						calls++
+						mutex.Unlock()
+						time.Sleep(10 * time.Millisecond)
+						return "some-value", nil
+					})
+					assert.NoError(err)
+					assert.Equal("some-value", value)
+				}()
+			}
+			wg.Wait()
+
+			assert.Equal(1, calls)
+		})
+
+		when("the ttl is zero", func() {
+			it("always calls fetch", func() {
+				cache := internal.NewCache(0)
+
+				calls := 0
+				fetch := func() (interface{}, error) {
+					calls++
+					return "some-value", nil
+				}
+
+				_, err := cache.Memoize("some-key", fetch)
+				require.NoError(err)
+				_, err = cache.Memoize("some-key", fetch)
+				require.NoError(err)
+
+				assert.Equal(2, calls)
+			})
+		})
+	})
+}
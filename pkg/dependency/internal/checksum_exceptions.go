@@ -0,0 +1,118 @@
+package internal
+
+import (
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ChecksumExceptionsVersion is the version of the checksum exceptions file
+// format understood by ParseChecksumExceptions.
+const ChecksumExceptionsVersion = 1
+
+//go:embed checksum_exceptions.yml
+var defaultChecksumExceptions []byte
+
+// ChecksumException exempts a dependency version from being verified
+// against its upstream checksum. When SHA256 is set the artifact is verified
+// against it instead.
+type ChecksumException struct {
+	Dependency string `yaml:"dependency"`
+	Version    string `yaml:"version"`
+	Reason     string `yaml:"reason"`
+	SHA256     string `yaml:"sha256,omitempty"`
+}
+
+// ChecksumExceptions is the registry of checksum exceptions.
+type ChecksumExceptions struct {
+	Version    int                 `yaml:"version"`
+	Exceptions []ChecksumException `yaml:"exceptions"`
+}
+
+// DefaultChecksumExceptions returns the exceptions embedded from
+// checksum_exceptions.yml. It panics if that file is invalid, as it is part
+// of the binary.
+func DefaultChecksumExceptions() ChecksumExceptions {
+	exceptions, err := ParseChecksumExceptions(defaultChecksumExceptions)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded checksum exceptions: %s", err))
+	}
+	return exceptions
+}
+
+// LoadChecksumExceptions reads and validates a checksum exceptions file.
+func LoadChecksumExceptions(path string) (ChecksumExceptions, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ChecksumExceptions{}, fmt.Errorf("could not read checksum exceptions: %w", err)
+	}
+
+	exceptions, err := ParseChecksumExceptions(content)
+	if err != nil {
+		return ChecksumExceptions{}, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	return exceptions, nil
+}
+
+// ParseChecksumExceptions parses and validates the content of a checksum
+// exceptions file.
+func ParseChecksumExceptions(content []byte) (ChecksumExceptions, error) {
+	var exceptions ChecksumExceptions
+	err := yaml.UnmarshalStrict(content, &exceptions)
+	if err != nil {
+		return ChecksumExceptions{}, fmt.Errorf("could not unmarshal checksum exceptions: %w", err)
+	}
+
+	if exceptions.Version != ChecksumExceptionsVersion {
+		return ChecksumExceptions{}, fmt.Errorf("unsupported checksum exceptions version %d", exceptions.Version)
+	}
+
+	seen := map[string]bool{}
+	for _, exception := range exceptions.Exceptions {
+		err := exception.validate()
+		if err != nil {
+			return ChecksumExceptions{}, err
+		}
+
+		key := exception.Dependency + "@" + exception.Version
+		if seen[key] {
+			return ChecksumExceptions{}, fmt.Errorf("duplicate checksum exception for %s", key)
+		}
+		seen[key] = true
+	}
+
+	return exceptions, nil
+}
+
+// Lookup returns the exception for version of the named dependency.
+func (c ChecksumExceptions) Lookup(dependency, version string) (ChecksumException, bool) {
+	for _, exception := range c.Exceptions {
+		if exception.Dependency == dependency && exception.Version == version {
+			return exception, true
+		}
+	}
+	return ChecksumException{}, false
+}
+
+func (c ChecksumException) validate() error {
+	if c.Dependency == "" || c.Version == "" {
+		return errors.New("checksum exceptions must have a dependency and a version")
+	}
+
+	if c.Reason == "" {
+		return fmt.Errorf("checksum exception for %s@%s must have a reason", c.Dependency, c.Version)
+	}
+
+	if c.SHA256 != "" {
+		sha, err := hex.DecodeString(c.SHA256)
+		if err != nil || len(sha) != 32 {
+			return fmt.Errorf("checksum exception for %s@%s has an invalid sha256 '%s'", c.Dependency, c.Version, c.SHA256)
+		}
+	}
+
+	return nil
+}
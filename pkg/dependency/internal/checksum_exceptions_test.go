@@ -0,0 +1,97 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksumExceptions(t *testing.T) {
+	spec.Run(t, "checksumExceptions", testChecksumExceptions, spec.Report(report.Terminal{}))
+}
+
+func testChecksumExceptions(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	when("DefaultChecksumExceptions", func() {
+		it("has the exceptions of every dependency that needs them", func() {
+			exceptions := internal.DefaultChecksumExceptions()
+
+			for _, dependencyVersion := range [][2]string{{"php", "5.1.6"}, {"php", "5.3.25"}, {"python", "3.1.0"}} {
+				exception, ok := exceptions.Lookup(dependencyVersion[0], dependencyVersion[1])
+				assert.True(ok, dependencyVersion)
+				assert.NotEmpty(exception.Reason, dependencyVersion)
+			}
+
+			_, ok := exceptions.Lookup("php", "7.4.4")
+			assert.False(ok)
+		})
+	})
+
+	when("ParseChecksumExceptions", func() {
+		it("parses exceptions with an optional sha256", func() {
+			exceptions, err := internal.ParseChecksumExceptions([]byte(`
+version: 1
+exceptions:
+- dependency: some-dep
+  version: 1.0.0
+  reason: some-reason
+- dependency: some-dep
+  version: "2.0"
+  reason: some-other-reason
+  sha256: 0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+`))
+			require.NoError(err)
+
+			exception, ok := exceptions.Lookup("some-dep", "2.0")
+			require.True(ok)
+			assert.Equal(internal.ChecksumException{
+				Dependency: "some-dep",
+				Version:    "2.0",
+				Reason:     "some-other-reason",
+				SHA256:     "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			}, exception)
+		})
+
+		it("rejects invalid exceptions", func() {
+			for name, content := range map[string]string{
+				"unsupported version": "version: 2\nexceptions: []",
+				"unknown field":       "version: 1\nexceptions:\n- dependency: a\n  version: 1.0.0\n  reason: r\n  md5: abc",
+				"missing reason":      "version: 1\nexceptions:\n- dependency: a\n  version: 1.0.0",
+				"missing version":     "version: 1\nexceptions:\n- dependency: a\n  reason: r",
+				"invalid sha256":      "version: 1\nexceptions:\n- dependency: a\n  version: 1.0.0\n  reason: r\n  sha256: abc",
+				"duplicate":           "version: 1\nexceptions:\n- dependency: a\n  version: 1.0.0\n  reason: r\n- dependency: a\n  version: 1.0.0\n  reason: r",
+			} {
+				_, err := internal.ParseChecksumExceptions([]byte(content))
+				assert.Error(err, name)
+			}
+		})
+	})
+
+	when("LoadChecksumExceptions", func() {
+		it("reads the exceptions from a file", func() {
+			path := filepath.Join(t.TempDir(), "exceptions.yml")
+			require.NoError(os.WriteFile(path, []byte("version: 1\nexceptions:\n- dependency: a\n  version: 1.0.0\n  reason: r\n"), 0600))
+
+			exceptions, err := internal.LoadChecksumExceptions(path)
+			require.NoError(err)
+
+			_, ok := exceptions.Lookup("a", "1.0.0")
+			assert.True(ok)
+		})
+
+		it("returns an error when the file does not exist", func() {
+			_, err := internal.LoadChecksumExceptions(filepath.Join(t.TempDir(), "missing.yml"))
+			assert.Error(err)
+		})
+	})
+}
@@ -7,11 +7,15 @@ import (
 	"crypto/sha512"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 	"golang.org/x/crypto/openpgp"
 )
 
@@ -21,34 +25,88 @@ func NewChecksummer() Checksummer {
 	return Checksummer{}
 }
 
-func (c Checksummer) VerifyASC(asc, path string, pgpKeys ...string) error {
-	if len(pgpKeys) == 0 {
-		return errors.New("no pgp keys provided")
+// VerifyASC checks the detached signature asc of the file at path against
+// the keys of keyring, ignoring keys whose fingerprint is not pinned. It
+// returns the fingerprint of the primary key that made the signature.
+func (c Checksummer) VerifyASC(asc, path string, keyring Keyring) (string, error) {
+	if len(keyring.Keys) == 0 {
+		return "", errors.New("no pgp keys provided")
 	}
 
-	file, err := os.Open(path)
+	err := keyring.Validate()
 	if err != nil {
-		return fmt.Errorf("could not open file: %w", err)
+		return "", err
+	}
+
+	entities, unreadable := readKeyring(keyring)
+	for _, err := range unreadable {
+		log.Print(err.Error())
 	}
-	defer file.Close()
 
-	for _, pgpKey := range pgpKeys {
-		keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(pgpKey))
-		if err != nil {			
-			log.Printf("could not read armored key ring: %s", err.Error())
+	// A key may be in the keyring more than once, such as a committed copy
+	// and a fetched one, and only the newer copy may carry its revocation,
+	// so a key is refused if any copy of it is revoked.
+	statuses := make([]KeyStatus, len(entities))
+	revoked := map[string]bool{}
+	for i, entity := range entities {
+		statuses[i] = keyStatus(entity, time.Now(), DefaultKeyExpiryWarning)
+		if statuses[i].State == KeyRevoked {
+			revoked[statuses[i].Fingerprint] = true
+		}
+	}
+
+	var (
+		trusted openpgp.EntityList
+		refused int
+	)
+	for i, entity := range entities {
+		status := statuses[i]
+		if !keyring.trusts(status.Fingerprint) {
+			log.Printf("ignoring pgp key %s: fingerprint is not pinned", status.Fingerprint)
 			continue
 		}
 
-		_, err = openpgp.CheckArmoredDetachedSignature(keyring, file, strings.NewReader(asc))
-		if err != nil {
-			log.Printf("failed to check signature: %s", err.Error())
+		if revoked[status.Fingerprint] {
+			log.Printf("refusing pgp key %s: it is revoked", status.Fingerprint)
+			refused++
 			continue
 		}
-		log.Printf("found valid pgp key")
-		return nil
+
+		switch status.State {
+		case KeyExpiring:
+			log.Printf("WARNING: pgp key %s expires on %s", status.Fingerprint, status.ExpiresAt.Format("2006-01-02"))
+		case KeyExpired:
+			// Signatures made before the key expired are still checked, so
+			// that older releases can be verified.
+			log.Printf("WARNING: pgp key %s expired on %s", status.Fingerprint, status.ExpiresAt.Format("2006-01-02"))
+		}
+
+		trusted = append(trusted, entity)
+	}
+
+	if len(trusted) == 0 {
+		if refused > 0 {
+			return "", depErrors.SignatureInvalidError{Reason: "all of the pinned pgp keys are revoked"}
+		}
+		return "", depErrors.SignatureInvalidError{Reason: "none of the pgp keys match the pinned fingerprints"}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("could not open file: %w", err)
+	}
+	defer file.Close()
+
+	signer, err := openpgp.CheckArmoredDetachedSignature(trusted, file, strings.NewReader(asc))
+	if err != nil {
+		log.Printf("failed to check signature: %s", err.Error())
+		return "", depErrors.SignatureInvalidError{Reason: "no valid pgp keys provided"}
 	}
 
-	return errors.New("no valid pgp keys provided")
+	fingerprint := fmt.Sprintf("%X", signer.PrimaryKey.Fingerprint)
+	log.Printf("verified signature of %s with pgp key %s", filepath.Base(path), fingerprint)
+
+	return fingerprint, nil
 }
 
 func (c Checksummer) VerifyMD5(path, expectedMD5 string) error {
@@ -58,7 +116,7 @@ func (c Checksummer) VerifyMD5(path, expectedMD5 string) error {
 	}
 
 	if actualMD5 != expectedMD5 {
-		return fmt.Errorf("expected MD5 '%s' but got '%s'", expectedMD5, actualMD5)
+		return depErrors.ChecksumMismatchError{Algorithm: "MD5", Expected: expectedMD5, Actual: actualMD5}
 	}
 
 	return nil
@@ -71,7 +129,7 @@ func (c Checksummer) VerifySHA1(path, expectedSHA string) error {
 	}
 
 	if actualSHA != expectedSHA {
-		return fmt.Errorf("expected SHA256 '%s' but got '%s'", expectedSHA, actualSHA)
+		return depErrors.ChecksumMismatchError{Algorithm: "SHA1", Expected: expectedSHA, Actual: actualSHA}
 	}
 
 	return nil
@@ -84,7 +142,7 @@ func (c Checksummer) VerifySHA256(path, expectedSHA string) error {
 	}
 
 	if actualSHA != expectedSHA {
-		return fmt.Errorf("expected SHA256 '%s' but got '%s'", expectedSHA, actualSHA)
+		return depErrors.ChecksumMismatchError{Algorithm: "SHA256", Expected: expectedSHA, Actual: actualSHA}
 	}
 
 	return nil
@@ -97,7 +155,7 @@ func (c Checksummer) VerifySHA512(path, expectedSHA string) error {
 	}
 
 	if actualSHA != expectedSHA {
-		return fmt.Errorf("expected SHA256 '%s' but got '%s'", expectedSHA, actualSHA)
+		return depErrors.ChecksumMismatchError{Algorithm: "SHA512", Expected: expectedSHA, Actual: actualSHA}
 	}
 
 	return nil
@@ -135,6 +193,54 @@ func (c Checksummer) GetSHA512(path string) (string, error) {
 	return fmt.Sprintf("%x", hash.Sum(nil)), nil
 }
 
+// GetChecksums returns the hex digests of a file for each of the given
+// algorithms ("md5", "sha1", "sha256" or "sha512"), keyed by algorithm. The
+// file is only read once, however many algorithms are requested.
+func (c Checksummer) GetChecksums(path string, algorithms ...string) (map[string]string, error) {
+	hashes := map[string]hash.Hash{}
+	var writers []io.Writer
+	for _, algorithm := range algorithms {
+		if _, ok := hashes[algorithm]; ok {
+			continue
+		}
+
+		var h hash.Hash
+		switch algorithm {
+		case "md5":
+			h = md5.New()
+		case "sha1":
+			h = sha1.New()
+		case "sha256":
+			h = sha256.New()
+		case "sha512":
+			h = sha512.New()
+		default:
+			return nil, fmt.Errorf("unsupported checksum algorithm '%s'", algorithm)
+		}
+
+		hashes[algorithm] = h
+		writers = append(writers, h)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	_, err = io.Copy(io.MultiWriter(writers...), file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate checksums: %w", err)
+	}
+
+	checksums := map[string]string{}
+	for algorithm, h := range hashes {
+		checksums[algorithm] = fmt.Sprintf("%x", h.Sum(nil))
+	}
+
+	return checksums, nil
+}
+
 func (c Checksummer) SplitPGPKeys(block string) []string {
 	var keys []string
 	var currentKey string
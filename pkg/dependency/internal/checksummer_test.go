@@ -1,15 +1,22 @@
 package internal_test
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
 )
 
 func TestChecksummer(t *testing.T) {
@@ -121,28 +128,129 @@ KbtkeGcVHGGJtT8krUQB6f3VPT2vQg==
 	})
 
 	when("VerifyASC", func() {
+		const pgpKeyFingerprint = "767DBC09AD643B63750B6E246D59A55EEFCA38CD"
+
 		it("verifies a file is signed with the given pgp key and signature", func() {
-			err := checksummer.VerifyASC(fileASC, filePath, pgpKey)
+			fingerprint, err := checksummer.VerifyASC(fileASC, filePath, internal.Keyring{
+				Fingerprints: []string{pgpKeyFingerprint},
+				Keys:         []string{pgpKey},
+			})
 			require.NoError(err)
+			assert.Equal(pgpKeyFingerprint, fingerprint)
 		})
 
 		when("there are multiple pgp keys", func() {
 			it("succeeds if any match", func() {
-				err := checksummer.VerifyASC(fileASC, filePath, wrongPGPKey, pgpKey, wrongPGPKey)
+				fingerprint, err := checksummer.VerifyASC(fileASC, filePath, internal.Keyring{
+					Fingerprints: []string{pgpKeyFingerprint},
+					Keys:         []string{wrongPGPKey, pgpKey, wrongPGPKey},
+				})
+				require.NoError(err)
+				assert.Equal(pgpKeyFingerprint, fingerprint)
+			})
+
+			it("succeeds if they are in a single block", func() {
+				fingerprint, err := checksummer.VerifyASC(fileASC, filePath, internal.Keyring{
+					Fingerprints: []string{pgpKeyFingerprint},
+					Keys:         []string{wrongPGPKey + "\n" + pgpKey},
+				})
+				require.NoError(err)
+				assert.Equal(pgpKeyFingerprint, fingerprint)
+			})
+		})
+
+		when("the fingerprint of the signing key is not pinned", func() {
+			it("returns a SignatureInvalidError", func() {
+				_, err := checksummer.VerifyASC(fileASC, filePath, internal.Keyring{
+					Fingerprints: []string{"some-other-fingerprint"},
+					Keys:         []string{pgpKey},
+				})
+
+				var signatureErr depErrors.SignatureInvalidError
+				assert.True(errors.As(err, &signatureErr), "expected a SignatureInvalidError, got %v", err)
+			})
+		})
+
+		when("a pinned key is revoked", func() {
+			var (
+				entity                 *openpgp.Entity
+				signature, fingerprint string
+				staleKey, revokedKey   string
+			)
+
+			it.Before(func() {
+				var err error
+				entity, err = openpgp.NewEntity("some-signer", "", "some-signer@example.com", &packet.Config{RSABits: 1024})
+				require.NoError(err)
+				fingerprint = fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint)
+
+				file, err := os.Open(filePath)
+				require.NoError(err)
+				defer file.Close()
+
+				var buffer bytes.Buffer
+				require.NoError(openpgp.ArmoredDetachSign(&buffer, entity, file, nil))
+				signature = buffer.String()
+
+				staleKey = armorKey(t, entity, nil)
+				revokedKey = revokeKey(t, entity, time.Now())
+			})
+
+			it("verifies with a copy of the key without the revocation on its own", func() {
+				signer, err := checksummer.VerifyASC(signature, filePath, internal.Keyring{
+					Fingerprints: []string{fingerprint},
+					Keys:         []string{staleKey},
+				})
 				require.NoError(err)
+				assert.Equal(fingerprint, signer)
+			})
+
+			it("refuses every copy of the key, whichever comes first", func() {
+				for _, keys := range [][]string{{staleKey, revokedKey}, {revokedKey, staleKey}} {
+					_, err := checksummer.VerifyASC(signature, filePath, internal.Keyring{
+						Fingerprints: []string{fingerprint},
+						Keys:         keys,
+					})
+					assert.Equal(depErrors.SignatureInvalidError{Reason: "all of the pinned pgp keys are revoked"}, err)
+				}
+			})
+		})
+
+		when("the keyring pins no fingerprints", func() {
+			it("returns an error rather than trusting all of its keys", func() {
+				_, err := checksummer.VerifyASC(fileASC, filePath, internal.Keyring{Keys: []string{pgpKey}})
+				assert.EqualError(err, "keyring pins no fingerprints")
+			})
+		})
+
+		when("a key URL of the keyring pins no fingerprints", func() {
+			it("returns an error", func() {
+				_, err := checksummer.VerifyASC(fileASC, filePath, internal.Keyring{
+					Fingerprints: []string{pgpKeyFingerprint},
+					Keys:         []string{pgpKey},
+					KeyURLs:      []internal.KeySource{{URL: "https://example.org/key.asc"}},
+				})
+				assert.EqualError(err, "key URL https://example.org/key.asc pins no fingerprints")
 			})
 		})
 
 		when("the pgp key does not match", func() {
 			it("returns an error", func() {
-				err := checksummer.VerifyASC(fileASC, filePath, "some-bad-pgp-key")
+				_, err := checksummer.VerifyASC(fileASC, filePath, internal.Keyring{Keys: []string{"some-bad-pgp-key"}})
 				assert.Error(err)
 			})
 		})
 
+		when("there are no keys", func() {
+			it("returns an error", func() {
+				_, err := checksummer.VerifyASC(fileASC, filePath, internal.Keyring{})
+				assert.EqualError(err, "no pgp keys provided")
+			})
+		})
+
 		when("the signature does not match", func() {
 			it("returns an error", func() {
-				err := checksummer.VerifyASC("some-bad-asc", filePath, pgpKey)
+				_, err := checksummer.VerifyASC("some-bad-asc", filePath, internal.Keyring{Keys: []string{pgpKey}})
 				assert.Error(err)
 			})
 		})
@@ -173,7 +281,7 @@ KbtkeGcVHGGJtT8krUQB6f3VPT2vQg==
 			it("returns an error", func() {
 				err := checksummer.VerifySHA1(filePath, "some-bad-sha")
 				assert.Error(err)
-				assert.Equal("expected SHA256 'some-bad-sha' but got '21202296bf50267250155e46d3b9eb3e4c1acb7e'", err.Error())
+				assert.Equal("expected SHA1 'some-bad-sha' but got '21202296bf50267250155e46d3b9eb3e4c1acb7e'", err.Error())
 			})
 		})
 	})
@@ -203,7 +311,7 @@ KbtkeGcVHGGJtT8krUQB6f3VPT2vQg==
 			it("returns an error", func() {
 				err := checksummer.VerifySHA512(filePath, "some-bad-sha")
 				assert.Error(err)
-				assert.Equal("expected SHA256 'some-bad-sha' but got 'b7b2b9e0a4d7f84985a720d1273166bb00132a60ac45388a7d3090a7d4c9692f38d019f807a02750f810f52c623362f977040231c2bbf5947170fe83686cfd9d'", err.Error())
+				assert.Equal("expected SHA512 'some-bad-sha' but got 'b7b2b9e0a4d7f84985a720d1273166bb00132a60ac45388a7d3090a7d4c9692f38d019f807a02750f810f52c623362f977040231c2bbf5947170fe83686cfd9d'", err.Error())
 			})
 		})
 	})
@@ -216,6 +324,26 @@ KbtkeGcVHGGJtT8krUQB6f3VPT2vQg==
 		})
 	})
 
+	when("GetChecksums", func() {
+		it("returns a file's checksum for each algorithm", func() {
+			checksums, err := checksummer.GetChecksums(filePath, "md5", "sha1", "sha256", "sha512")
+			require.NoError(err)
+			assert.Equal(map[string]string{
+				"md5":    fileMD5,
+				"sha1":   fileSHA1,
+				"sha256": fileSHA256,
+				"sha512": fileSHA512,
+			}, checksums)
+		})
+
+		when("an algorithm is not supported", func() {
+			it("returns an error", func() {
+				_, err := checksummer.GetChecksums(filePath, "sha256", "crc32")
+				assert.EqualError(err, "unsupported checksum algorithm 'crc32'")
+			})
+		})
+	})
+
 	when("SplitPGPKeys", func() {
 		it("splits a block of multiple keys into a slice of individual keys", func() {
 			block := `
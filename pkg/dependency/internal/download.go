@@ -0,0 +1,260 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+)
+
+type expectedSHA256Key struct{}
+
+type maxSizeKey struct{}
+
+// SizeLimitError is returned when a download is larger than the maximum size
+// allowed for it.
+type SizeLimitError struct {
+	URL   string
+	Limit int64
+}
+
+func (e SizeLimitError) Error() string {
+	return fmt.Sprintf("download of %s exceeds the maximum size of %d bytes", e.URL, e.Limit)
+}
+
+// WithMaxSize overrides the maximum size of a single Download. A size of
+// zero or less lifts the limit.
+func WithMaxSize(size int64) RequestOption {
+	return func(r *http.Request) {
+		*r = *r.WithContext(context.WithValue(r.Context(), maxSizeKey{}, size))
+	}
+}
+
+// WithExpectedSHA256 makes Download fail unless the downloaded file has the
+// given SHA256. It has no effect on other requests.
+func WithExpectedSHA256(sha string) RequestOption {
+	return func(r *http.Request) {
+		*r = *r.WithContext(context.WithValue(r.Context(), expectedSHA256Key{}, strings.ToLower(sha)))
+	}
+}
+
+// resumableDownload downloads url into filename. When the connection drops
+// part way through, the rest of the file is requested with a Range request,
+// guarded by If-Range so that a changed upstream file is downloaded again
+// from the start. Servers that do not support ranges answer with the whole
+// file, which replaces what was already written. The number of resumes is
+// bounded by the retry policy for the host.
+func (w WebClient) resumableDownload(url, filename string, options ...RequestOption) error {
+	var (
+		file      *os.File
+		written   int64
+		validator string
+	)
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	for attempt := 1; ; attempt++ {
+		request, err := newRequest(http.MethodGet, url, nil, options...)
+		if err != nil {
+			return err
+		}
+
+		if written > 0 {
+			request.Header.Set("Range", fmt.Sprintf("bytes=%d-", written))
+			request.Header.Set("If-Range", validator)
+		}
+
+		response, err := w.do(request)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case written > 0 && response.StatusCode == http.StatusPartialContent && contentRangeStart(response) == written:
+		case response.StatusCode == http.StatusOK:
+			err = checkContentLength(request, response)
+			if err != nil {
+				response.Body.Close()
+				return err
+			}
+
+			if file == nil {
+				file, err = os.OpenFile(filename, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0644)
+				if err != nil {
+					response.Body.Close()
+					return fmt.Errorf("failed to open file: %w", err)
+				}
+			}
+
+			if written > 0 {
+				err = restartFile(file)
+				if err != nil {
+					response.Body.Close()
+					return err
+				}
+				written = 0
+			}
+
+			validator = rangeValidator(response)
+		default:
+			_, err = w.responseBody(response)
+			return err
+		}
+
+		body := &readErrorRecorder{reader: response.Body}
+		n, err := io.Copy(file, limitSize(request, body, written))
+		response.Body.Close()
+		written += n
+
+		if err == nil {
+			return nil
+		}
+
+		if body.err == nil || validator == "" || attempt >= w.retryPolicyFor(request).MaxAttempts {
+			return fmt.Errorf("failed to write to file: %w", err)
+		}
+	}
+}
+
+// checkContentLength fails early when a response announces a body larger
+// than the maximum size of the request.
+func checkContentLength(request *http.Request, response *http.Response) error {
+	limit := maxSize(request)
+	if limit > 0 && response.ContentLength > limit {
+		return SizeLimitError{URL: request.URL.String(), Limit: limit}
+	}
+	return nil
+}
+
+// limitSize returns a reader that fails with a SizeLimitError once more than
+// the maximum size of the request, less what has already been written, is
+// read from body.
+func limitSize(request *http.Request, body io.Reader, written int64) io.Reader {
+	limit := maxSize(request)
+	if limit <= 0 {
+		return body
+	}
+
+	return &sizeLimitedReader{
+		reader:    body,
+		remaining: limit - written,
+		err:       SizeLimitError{URL: request.URL.String(), Limit: limit},
+	}
+}
+
+func maxSize(request *http.Request) int64 {
+	limit, _ := request.Context().Value(maxSizeKey{}).(int64)
+	return limit
+}
+
+type sizeLimitedReader struct {
+	reader    io.Reader
+	remaining int64
+	err       error
+}
+
+func (r *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, r.err
+	}
+	return n, err
+}
+
+// verifyDownload checks the downloaded file against the SHA256 given with
+// WithExpectedSHA256, if any.
+func verifyDownload(filename string, options ...RequestOption) error {
+	request, err := newRequest(http.MethodGet, "", nil, options...)
+	if err != nil {
+		return err
+	}
+
+	expected, ok := request.Context().Value(expectedSHA256Key{}).(string)
+	if !ok {
+		return nil
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return fmt.Errorf("failed to hash file: %w", err)
+	}
+
+	actual := fmt.Sprintf("%x", hash.Sum(nil))
+	if actual != expected {
+		return fmt.Errorf("downloaded file does not match expected SHA256: %w", depErrors.ChecksumMismatchError{Algorithm: "SHA256", Expected: expected, Actual: actual})
+	}
+
+	return nil
+}
+
+// rangeValidator returns the value to send as If-Range when resuming the
+// response. Weak ETags cannot be used for ranges, in which case the
+// Last-Modified date is used instead, if there is one.
+func rangeValidator(response *http.Response) string {
+	if response.Header.Get("Accept-Ranges") == "none" {
+		return ""
+	}
+
+	if etag := response.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+
+	return response.Header.Get("Last-Modified")
+}
+
+func contentRangeStart(response *http.Response) int64 {
+	var start, end, size int64
+	_, err := fmt.Sscanf(response.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &size)
+	if err != nil {
+		_, err = fmt.Sscanf(response.Header.Get("Content-Range"), "bytes %d-%d/*", &start, &end)
+		if err != nil {
+			return -1
+		}
+	}
+	return start
+}
+
+func restartFile(file *os.File) error {
+	err := file.Truncate(0)
+	if err != nil {
+		return fmt.Errorf("failed to truncate file: %w", err)
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("failed to truncate file: %w", err)
+	}
+
+	return nil
+}
+
+// readErrorRecorder remembers errors reading the response, so that they can
+// be told apart from errors writing the file.
+type readErrorRecorder struct {
+	reader io.Reader
+	err    error
+}
+
+func (r *readErrorRecorder) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
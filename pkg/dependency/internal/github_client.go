@@ -3,8 +3,10 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal/internal_errors"
 )
 
@@ -88,12 +90,14 @@ type GithubWebClient interface {
 type GithubClient struct {
 	webClient   GithubWebClient
 	accessToken string
+	redactor    Redactor
 }
 
 func NewGithubClient(webClient GithubWebClient, accessToken string) GithubClient {
 	return GithubClient{
 		webClient:   webClient,
 		accessToken: accessToken,
+		redactor:    NewRedactor(accessToken),
 	}
 }
 
@@ -101,10 +105,7 @@ func (g GithubClient) GetReleaseTags(org, repo string) ([]GithubRelease, error)
 	page := 1
 	var allReleases []GithubRelease
 	for {
-		body, err := g.webClient.Get(
-			fmt.Sprintf("https://api.github.com/repos/%s/%s/releases?per_page=100&page=%d", org, repo, page),
-			WithHeader("Authorization", "token "+g.accessToken),
-		)
+		body, err := g.get(fmt.Sprintf("https://api.github.com/repos/%s/%s/releases?per_page=100&page=%d", org, repo, page))
 		if err != nil {
 			return nil, fmt.Errorf("could not get releases: %w", err)
 		}
@@ -112,7 +113,7 @@ func (g GithubClient) GetReleaseTags(org, repo string) ([]GithubRelease, error)
 		var releases []GithubReleaseResponse
 		err = json.Unmarshal(body, &releases)
 		if err != nil {
-			return nil, fmt.Errorf("could not unmarshal releases: %w\n%s", err, body)
+			return nil, fmt.Errorf("could not unmarshal releases: %w\n%s", depErrors.ParseError{Err: err}, g.redactor.Excerpt(body))
 		}
 
 		if len(releases) == 0 {
@@ -163,10 +164,9 @@ func (g GithubClient) GetTags(org, repo string) ([]string, error) {
 		return nil, fmt.Errorf("could not marshal graphql request: %w", err)
 	}
 
-	body, err := g.webClient.Post(
+	body, err := g.post(
 		"https://api.github.com/graphql",
 		requestBody,
-		WithHeader("Authorization", "token "+g.accessToken),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("could not make query graphql: %w", err)
@@ -175,7 +175,7 @@ func (g GithubClient) GetTags(org, repo string) ([]string, error) {
 	var tagsResponse GithubGraphQLTagsResponse
 	err = json.Unmarshal(body, &tagsResponse)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal response: %w\n%s", err, body)
+		return nil, fmt.Errorf("could not unmarshal response: %w\n%s", depErrors.ParseError{Err: err}, g.redactor.Excerpt(body))
 	}
 
 	var tags []string
@@ -192,9 +192,8 @@ func (g GithubClient) GetReleaseAsset(org, repo, tag, assetName string) ([]byte,
 		return nil, err
 	}
 
-	assetContents, err := g.webClient.Get(
+	assetContents, err := g.get(
 		assetURL,
-		WithHeader("Authorization", "token "+g.accessToken),
 		WithHeader("Accept", "application/octet-stream"),
 	)
 	if err != nil {
@@ -210,10 +209,9 @@ func (g GithubClient) DownloadReleaseAsset(org, repo, tag, assetName, outputPath
 		return "", err
 	}
 
-	err = g.webClient.Download(
+	err = g.download(
 		assetURL,
 		outputPath,
-		WithHeader("Authorization", "token "+g.accessToken),
 		WithHeader("Accept", "application/octet-stream"),
 	)
 	if err != nil {
@@ -226,10 +224,9 @@ func (g GithubClient) DownloadReleaseAsset(org, repo, tag, assetName, outputPath
 func (g GithubClient) DownloadSourceTarball(org, repo, ref, outputPath string) (string, error) {
 	assetURL := fmt.Sprintf("https://github.com/%s/%s/tarball/%s", org, repo, ref)
 
-	err := g.webClient.Download(
+	err := g.download(
 		assetURL,
 		outputPath,
-		WithHeader("Authorization", "token "+g.accessToken),
 		WithHeader("Accept", "application/octet-stream"),
 	)
 	if err != nil {
@@ -240,10 +237,7 @@ func (g GithubClient) DownloadSourceTarball(org, repo, ref, outputPath string) (
 }
 
 func (g GithubClient) GetTagCommit(org, repo, tag string) (GithubTagCommit, error) {
-	body, err := g.webClient.Get(
-		fmt.Sprintf("https://api.github.com/repos/%s/%s/git/refs/tags/%s", org, repo, tag),
-		WithHeader("Authorization", "token "+g.accessToken),
-	)
+	body, err := g.get(fmt.Sprintf("https://api.github.com/repos/%s/%s/git/refs/tags/%s", org, repo, tag))
 	if err != nil {
 		return GithubTagCommit{}, fmt.Errorf("could not get tag %s: %w", tag, err)
 	}
@@ -251,13 +245,10 @@ func (g GithubClient) GetTagCommit(org, repo, tag string) (GithubTagCommit, erro
 	var tagResponse GithubTagResponse
 	err = json.Unmarshal(body, &tagResponse)
 	if err != nil {
-		return GithubTagCommit{}, fmt.Errorf("could not unmarshal tag: %w\n%s", err, body)
+		return GithubTagCommit{}, fmt.Errorf("could not unmarshal tag: %w\n%s", depErrors.ParseError{Err: err}, g.redactor.Excerpt(body))
 	}
 
-	body, err = g.webClient.Get(
-		tagResponse.Object.URL,
-		WithHeader("Authorization", "token "+g.accessToken),
-	)
+	body, err = g.get(tagResponse.Object.URL)
 	if err != nil {
 		return GithubTagCommit{}, fmt.Errorf("could not get commit: %w", err)
 	}
@@ -269,7 +260,7 @@ func (g GithubClient) GetTagCommit(org, repo, tag string) (GithubTagCommit, erro
 		var githubCommitResponse GithubCommitResponse
 		err = json.Unmarshal(body, &githubCommitResponse)
 		if err != nil {
-			return GithubTagCommit{}, fmt.Errorf("could not unmarshal releases: %w\n%s", err, body)
+			return GithubTagCommit{}, fmt.Errorf("could not unmarshal releases: %w\n%s", depErrors.ParseError{Err: err}, g.redactor.Excerpt(body))
 		}
 		commitSha = githubCommitResponse.SHA
 		releaseDate = githubCommitResponse.Committer.Date
@@ -277,7 +268,7 @@ func (g GithubClient) GetTagCommit(org, repo, tag string) (GithubTagCommit, erro
 		var githubAnnotatedTagResponse GithubAnnotatedTagResponse
 		err = json.Unmarshal(body, &githubAnnotatedTagResponse)
 		if err != nil {
-			return GithubTagCommit{}, fmt.Errorf("could not unmarshal releases: %w\n%s", err, body)
+			return GithubTagCommit{}, fmt.Errorf("could not unmarshal releases: %w\n%s", depErrors.ParseError{Err: err}, g.redactor.Excerpt(body))
 		}
 		commitSha = githubAnnotatedTagResponse.Object.SHA
 		releaseDate = githubAnnotatedTagResponse.Tagger.Date
@@ -291,10 +282,7 @@ func (g GithubClient) GetTagCommit(org, repo, tag string) (GithubTagCommit, erro
 }
 
 func (g GithubClient) GetReleaseDate(org, repo, tag string) (*time.Time, error) {
-	body, err := g.webClient.Get(
-		fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", org, repo, tag),
-		WithHeader("Authorization", "token "+g.accessToken),
-	)
+	body, err := g.get(fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", org, repo, tag))
 	if err != nil {
 		return nil, fmt.Errorf("could not get release for tag %s: %w", tag, err)
 	}
@@ -302,17 +290,40 @@ func (g GithubClient) GetReleaseDate(org, repo, tag string) (*time.Time, error)
 	var releaseResponse GithubReleaseResponse
 	err = json.Unmarshal(body, &releaseResponse)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal release: %w\n%s", err, body)
+		return nil, fmt.Errorf("could not unmarshal release: %w\n%s", depErrors.ParseError{Err: err}, g.redactor.Excerpt(body))
 	}
 
 	return &releaseResponse.PublishedAt, nil
 }
 
+// GetLicense returns the SPDX ID of the license GitHub detects for a
+// repository at ref, or an empty string when GitHub detects none.
+func (g GithubClient) GetLicense(org, repo, ref string) (string, error) {
+	body, err := g.get(fmt.Sprintf("https://api.github.com/repos/%s/%s/license?ref=%s", org, repo, url.QueryEscape(ref)))
+	if err != nil {
+		return "", fmt.Errorf("could not get license for ref %s: %w", ref, err)
+	}
+
+	var licenseResponse struct {
+		License struct {
+			SPDXID string `json:"spdx_id"`
+		} `json:"license"`
+	}
+	err = json.Unmarshal(body, &licenseResponse)
+	if err != nil {
+		return "", fmt.Errorf("could not unmarshal license: %w\n%s", depErrors.ParseError{Err: err}, g.redactor.Excerpt(body))
+	}
+
+	// GitHub names licenses it cannot identify NOASSERTION
+	if licenseResponse.License.SPDXID == "NOASSERTION" {
+		return "", nil
+	}
+
+	return licenseResponse.License.SPDXID, nil
+}
+
 func (g GithubClient) getReleaseAssetURL(org, repo, tag, assetName string) (string, error) {
-	body, err := g.webClient.Get(
-		fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", org, repo, tag),
-		WithHeader("Authorization", "token "+g.accessToken),
-	)
+	body, err := g.get(fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/tags/%s", org, repo, tag))
 	if err != nil {
 		return "", fmt.Errorf("could not get release: %w", err)
 	}
@@ -320,7 +331,7 @@ func (g GithubClient) getReleaseAssetURL(org, repo, tag, assetName string) (stri
 	var release GithubReleaseResponse
 	err = json.Unmarshal(body, &release)
 	if err != nil {
-		return "", fmt.Errorf("could not unmarshal release: %w\n%s", err, body)
+		return "", fmt.Errorf("could not unmarshal release: %w\n%s", depErrors.ParseError{Err: err}, g.redactor.Excerpt(body))
 	}
 
 	var assetURL string
@@ -337,3 +348,24 @@ func (g GithubClient) getReleaseAssetURL(org, repo, tag, assetName string) (stri
 
 	return assetURL, nil
 }
+
+// get, post and download make authenticated requests to GitHub. Errors are
+// redacted so that the access token never ends up in build logs.
+func (g GithubClient) get(url string, options ...RequestOption) ([]byte, error) {
+	body, err := g.webClient.Get(url, append([]RequestOption{g.authorization()}, options...)...)
+	return body, g.redactor.Error(err)
+}
+
+func (g GithubClient) post(url string, requestBody []byte, options ...RequestOption) ([]byte, error) {
+	body, err := g.webClient.Post(url, requestBody, append([]RequestOption{g.authorization()}, options...)...)
+	return body, g.redactor.Error(err)
+}
+
+func (g GithubClient) download(url, filename string, options ...RequestOption) error {
+	err := g.webClient.Download(url, filename, append([]RequestOption{g.authorization()}, options...)...)
+	return g.redactor.Error(err)
+}
+
+func (g GithubClient) authorization() RequestOption {
+	return WithHeader("Authorization", "token "+g.accessToken)
+}
@@ -306,6 +306,36 @@ func testGithubClient(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("GetLicense", func() {
+		it("returns the SPDX ID of the license at the ref", func() {
+			fakeWebClient.GetReturnsOnCall(0, []byte(`{"name": "LICENSE", "license": {"key": "mit", "spdx_id": "MIT"}}`), nil)
+
+			license, err := githubClient.GetLicense("some-org", "some-repo", "v1.0.0")
+			require.NoError(err)
+			assert.Equal("MIT", license)
+
+			urlArg, _ := fakeWebClient.GetArgsForCall(0)
+			assert.Equal("https://api.github.com/repos/some-org/some-repo/license?ref=v1.0.0", urlArg)
+		})
+
+		it("returns no license when GitHub cannot identify it", func() {
+			fakeWebClient.GetReturnsOnCall(0, []byte(`{"name": "LICENSE", "license": {"key": "other", "spdx_id": "NOASSERTION"}}`), nil)
+
+			license, err := githubClient.GetLicense("some-org", "some-repo", "v1.0.0")
+			require.NoError(err)
+			assert.Equal("", license)
+		})
+
+		when("the request fails", func() {
+			it("does not leak the access token", func() {
+				fakeWebClient.GetReturnsOnCall(0, nil, errors.New("request with token some-access-token failed"))
+
+				_, err := githubClient.GetLicense("some-org", "some-repo", "v1.0.0")
+				assert.EqualError(err, "could not get license for ref v1.0.0: request with token [REDACTED] failed")
+			})
+		})
+	})
+
 	when("GetReleaseDate", func() {
 		it("returns the release date of the given release tag", func() {
 			releaseResponse := `{"published_at": "2021-02-11T14:34:19Z"}`
@@ -318,5 +348,25 @@ func testGithubClient(t *testing.T, when spec.G, it spec.S) {
 
 			assert.Equal(expectedReleaseDate, actualReleaseDate.Format(time.RFC3339))
 		})
+
+		when("the request fails", func() {
+			it("does not leak the access token", func() {
+				fakeWebClient.GetReturnsOnCall(0, nil, errors.New("request with token some-access-token failed"))
+
+				_, err := githubClient.GetReleaseDate("some-org", "some-repo", "1.0.0")
+				assert.EqualError(err, "could not get release for tag 1.0.0: request with token [REDACTED] failed")
+			})
+		})
+
+		when("the response cannot be unmarshalled", func() {
+			it("includes a redacted excerpt of the body", func() {
+				fakeWebClient.GetReturnsOnCall(0, []byte("not json: some-access-token"), nil)
+
+				_, err := githubClient.GetReleaseDate("some-org", "some-repo", "1.0.0")
+				require.Error(err)
+				assert.Contains(err.Error(), "not json: [REDACTED]")
+				assert.NotContains(err.Error(), "some-access-token")
+			})
+		})
 	})
 }
@@ -0,0 +1,306 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultImmutableURLs match versioned artifact URLs whose contents never
+// change once published. Cached responses for them are reused without
+// asking the upstream whether they are still current.
+var DefaultImmutableURLs = []*regexp.Regexp{
+	regexp.MustCompile(`^https://nodejs\.org/dist/v[^/]+/[^/]+$`),
+	regexp.MustCompile(`^https://dl\.google\.com/go/go[^/]+\.src\.tar\.gz$`),
+	regexp.MustCompile(`^https://static\.rust-lang\.org/dist/rustc-[^/]+-src\.tar\.gz(\.asc)?$`),
+	regexp.MustCompile(`^https://curl\.se/download/(archeology/)?curl-[^/]+\.tar\.gz(\.asc)?$`),
+	regexp.MustCompile(`^https?://nginx\.org/download/nginx-[^/]+\.tar\.gz(\.asc)?$`),
+	regexp.MustCompile(`^https?://archive\.apache\.org/dist/httpd/httpd-[^/]+\.tar\.bz2$`),
+	regexp.MustCompile(`^https://pecl\.php\.net/get/[^/]+-[^/]+$`),
+	regexp.MustCompile(`^https://rubygems\.org/downloads/[^/]+\.gem$`),
+	regexp.MustCompile(`^https://cache\.ruby-lang\.org/pub/ruby/[^/]+/ruby-[^/]+\.tar\.gz$`),
+	regexp.MustCompile(`^https://www\.python\.org/ftp/python/[^/]+/Python-[^/]+\.tgz$`),
+	regexp.MustCompile(`^https://files\.pythonhosted\.org/packages/`),
+	regexp.MustCompile(`^https://getcomposer\.org/download/[^/]+/composer\.phar$`),
+}
+
+// HTTPCache keeps GET responses on disk, keyed by URL, so that they can be
+// reused between runs. Responses are revalidated with the upstream using
+// their ETag or Last-Modified headers, except for URLs matching one of the
+// immutable patterns, which are served straight from disk. Once the cache
+// grows beyond maxSize bytes the least recently used responses are removed.
+//
+// Copies of an HTTPCache share the same entries.
+type HTTPCache struct {
+	dir       string
+	maxSize   int64
+	immutable []*regexp.Regexp
+	state     *httpCacheState
+}
+
+type httpCacheState struct {
+	mutex   sync.Mutex
+	loaded  bool
+	entries map[string]*httpCacheEntry
+	size    int64
+}
+
+type httpCacheEntry struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Size         int64     `json:"size"`
+	LastUsed     time.Time `json:"last_used"`
+}
+
+// NewHTTPCache returns an HTTPCache storing responses in dir. A maxSize of
+// zero or less leaves the cache unbounded.
+func NewHTTPCache(dir string, maxSize int64, immutable ...*regexp.Regexp) HTTPCache {
+	return HTTPCache{
+		dir:       dir,
+		maxSize:   maxSize,
+		immutable: immutable,
+		state: &httpCacheState{
+			entries: map[string]*httpCacheEntry{},
+		},
+	}
+}
+
+// key identifies a request in the cache. The Accept header is part of the
+// key since some APIs, such as GitHub's release assets, serve different
+// representations from the same URL.
+func (c HTTPCache) key(request *http.Request) string {
+	hash := sha256.Sum256([]byte(request.URL.String() + "\n" + request.Header.Get("Accept")))
+	return fmt.Sprintf("%x", hash)
+}
+
+func (c HTTPCache) isImmutable(url string) bool {
+	for _, pattern := range c.immutable {
+		if pattern.MatchString(url) {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the entry stored under key together with an open handle on
+// its body, so the body stays readable even if the entry is evicted before
+// the caller is done with it.
+func (c HTTPCache) get(key string) (httpCacheEntry, *os.File, bool) {
+	state := c.state
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	c.load()
+
+	entry, ok := state.entries[key]
+	if !ok {
+		return httpCacheEntry{}, nil, false
+	}
+
+	file, err := os.Open(c.bodyPath(key))
+	if err != nil {
+		c.remove(key)
+		return httpCacheEntry{}, nil, false
+	}
+
+	return *entry, file, true
+}
+
+// touch marks the entry stored under key as used.
+func (c HTTPCache) touch(key string) {
+	state := c.state
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	entry, ok := state.entries[key]
+	if !ok {
+		return
+	}
+
+	entry.LastUsed = time.Now()
+	_ = c.writeEntry(key, entry)
+}
+
+// store saves the response body under key and returns a reader over the
+// saved copy. A body larger than the whole cache is not kept, and is
+// removed once the returned reader is closed.
+func (c HTTPCache) store(key string, entry httpCacheEntry, body io.Reader) (io.ReadCloser, error) {
+	err := os.MkdirAll(c.dir, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("could not create http cache directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(c.dir, "response")
+	if err != nil {
+		return nil, fmt.Errorf("could not create http cache file: %w", err)
+	}
+
+	size, err := io.Copy(file, body)
+	if err != nil {
+		file.Close()
+		_ = os.Remove(file.Name())
+		return nil, fmt.Errorf("could not read response: %w", err)
+	}
+
+	_, err = file.Seek(0, io.SeekStart)
+	if err != nil {
+		file.Close()
+		_ = os.Remove(file.Name())
+		return nil, fmt.Errorf("could not rewind http cache file: %w", err)
+	}
+
+	if c.maxSize > 0 && size > c.maxSize {
+		return &removeOnClose{File: file}, nil
+	}
+
+	state := c.state
+
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+
+	c.load()
+
+	err = os.Rename(file.Name(), c.bodyPath(key))
+	if err != nil {
+		file.Close()
+		_ = os.Remove(file.Name())
+		return nil, fmt.Errorf("could not store http cache file: %w", err)
+	}
+
+	if previous, ok := state.entries[key]; ok {
+		state.size -= previous.Size
+	}
+
+	entry.Size = size
+	entry.LastUsed = time.Now()
+	state.entries[key] = &entry
+	state.size += size
+
+	err = c.writeEntry(key, &entry)
+	if err != nil {
+		c.remove(key)
+	}
+
+	c.evict(key)
+
+	return file, nil
+}
+
+// load reads the entries already on disk. It must be called with the mutex
+// held.
+func (c HTTPCache) load() {
+	state := c.state
+	if state.loaded {
+		return
+	}
+	state.loaded = true
+
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return
+	}
+
+	for _, path := range paths {
+		key := strings.TrimSuffix(filepath.Base(path), ".json")
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var entry httpCacheEntry
+		err = json.Unmarshal(content, &entry)
+		if err != nil {
+			continue
+		}
+
+		info, err := os.Stat(c.bodyPath(key))
+		if err != nil {
+			_ = os.Remove(path)
+			continue
+		}
+
+		entry.Size = info.Size()
+		state.entries[key] = &entry
+		state.size += entry.Size
+	}
+}
+
+// evict removes the least recently used entries, other than keep, until the
+// cache fits within its maximum size. It must be called with the mutex held.
+func (c HTTPCache) evict(keep string) {
+	state := c.state
+	if c.maxSize <= 0 || state.size <= c.maxSize {
+		return
+	}
+
+	var keys []string
+	for key := range state.entries {
+		if key != keep {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return state.entries[keys[i]].LastUsed.Before(state.entries[keys[j]].LastUsed)
+	})
+
+	for _, key := range keys {
+		if state.size <= c.maxSize {
+			return
+		}
+		c.remove(key)
+	}
+}
+
+// remove deletes the entry stored under key. It must be called with the
+// mutex held.
+func (c HTTPCache) remove(key string) {
+	state := c.state
+	if entry, ok := state.entries[key]; ok {
+		state.size -= entry.Size
+		delete(state.entries, key)
+	}
+
+	_ = os.Remove(c.entryPath(key))
+	_ = os.Remove(c.bodyPath(key))
+}
+
+func (c HTTPCache) writeEntry(key string, entry *httpCacheEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.entryPath(key), content, 0644)
+}
+
+func (c HTTPCache) entryPath(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func (c HTTPCache) bodyPath(key string) string {
+	return filepath.Join(c.dir, key+".body")
+}
+
+type removeOnClose struct {
+	*os.File
+}
+
+func (r *removeOnClose) Close() error {
+	err := r.File.Close()
+	_ = os.Remove(r.File.Name())
+	return err
+}
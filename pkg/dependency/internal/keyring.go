@@ -0,0 +1,338 @@
+package internal
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+//go:embed keyrings
+var committedKeys embed.FS
+
+// Keyring is the set of keys trusted to sign the artifacts of a dependency.
+type Keyring struct {
+	// Fingerprints pins the primary keys trusted to sign artifacts, along
+	// with the fingerprints of its KeyURLs. Keys with other fingerprints are
+	// ignored.
+	Fingerprints []string
+
+	// Keys are the armored public keys. A single key may hold several
+	// armored blocks.
+	Keys []string
+
+	// KeyURLs are where the upstream publishes its keys. A URL is only
+	// fetched while the key of one of its pinned fingerprints is not
+	// committed, or to refresh the committed keys.
+	KeyURLs []KeySource
+
+	// PendingKeyURLs are where the upstream publishes keys whose
+	// fingerprints are not pinned yet. They are only fetched to report the
+	// keys published there, which are never trusted.
+	PendingKeyURLs []string
+}
+
+// KeySource is a URL an upstream publishes keys at, and the fingerprints of
+// the keys published there that are trusted.
+type KeySource struct {
+	URL          string
+	Fingerprints []string
+}
+
+// Pinned reports whether the keyring pins the keys it trusts. Signatures are
+// never verified against a keyring without pinned fingerprints.
+func (k Keyring) Pinned() bool {
+	return len(k.pins()) > 0
+}
+
+// Validate returns an error unless the keyring and every URL it fetches keys
+// from pin the keys they trust.
+func (k Keyring) Validate() error {
+	if !k.Pinned() {
+		return errors.New("keyring pins no fingerprints")
+	}
+
+	for _, source := range k.KeyURLs {
+		if len(source.Fingerprints) == 0 {
+			return fmt.Errorf("key URL %s pins no fingerprints", source.URL)
+		}
+	}
+
+	return nil
+}
+
+func (k Keyring) pins() []string {
+	pins := append([]string{}, k.Fingerprints...)
+	for _, source := range k.KeyURLs {
+		pins = append(pins, source.Fingerprints...)
+	}
+	return pins
+}
+
+// UncommittedFingerprints returns the pinned fingerprints that none of the
+// keys of the keyring have, whose keys have to be fetched from their URLs.
+func (k Keyring) UncommittedFingerprints() []string {
+	entities, _ := readKeyring(k)
+
+	have := map[string]bool{}
+	for _, entity := range entities {
+		have[fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint)] = true
+	}
+
+	var uncommitted []string
+	for _, fingerprint := range k.pins() {
+		if !have[normalizeFingerprint(fingerprint)] {
+			uncommitted = append(uncommitted, normalizeFingerprint(fingerprint))
+		}
+	}
+	return uncommitted
+}
+
+// UncommittedSources returns the key URLs that publish a pinned key that is
+// not committed.
+func (k Keyring) UncommittedSources() []KeySource {
+	uncommitted := map[string]bool{}
+	for _, fingerprint := range k.UncommittedFingerprints() {
+		uncommitted[fingerprint] = true
+	}
+
+	var sources []KeySource
+	for _, source := range k.KeyURLs {
+		for _, fingerprint := range source.Fingerprints {
+			if uncommitted[normalizeFingerprint(fingerprint)] {
+				sources = append(sources, source)
+				break
+			}
+		}
+	}
+	return sources
+}
+
+// PinnedKeys returns the armored public key of every key of the keyring
+// whose fingerprint is pinned, keyed by fingerprint, in the form they are
+// committed in. When the keyring holds several copies of a key, the last one
+// wins.
+func (k Keyring) PinnedKeys() (map[string]string, error) {
+	entities, errs := readKeyring(k)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	keys := map[string]string{}
+	for _, entity := range entities {
+		fingerprint := fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint)
+		if !k.trusts(fingerprint) {
+			continue
+		}
+
+		key, err := armorEntity(entity)
+		if err != nil {
+			return nil, fmt.Errorf("could not armor key %s: %w", fingerprint, err)
+		}
+		keys[fingerprint] = key
+	}
+
+	return keys, nil
+}
+
+func (k Keyring) trusts(fingerprint string) bool {
+	for _, pinned := range k.pins() {
+		if normalizeFingerprint(pinned) == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultKeyrings returns the keyrings of the dependencies whose artifacts
+// are signed, with the keys committed in the keyrings directory. It panics if
+// a committed key is invalid, as those are part of the binary.
+func DefaultKeyrings() map[string]Keyring {
+	// httpd and ICU have no keyring until the fingerprints of their release
+	// managers are pinned, so their signatures are recorded as unverified.
+	keyrings := map[string]Keyring{
+		"composer": {
+			KeyURLs: []KeySource{{
+				URL: "https://keys.openpgp.org/vks/v1/by-fingerprint/161DFBE342889F01DDAC4E61CBB3D576F2A0946F",
+				Fingerprints: []string{
+					"161DFBE342889F01DDAC4E61CBB3D576F2A0946F", // Composer Release Signing Key
+				},
+			}},
+		},
+		"curl": {
+			KeyURLs: []KeySource{{
+				URL: "https://daniel.haxx.se/mykey.asc",
+				Fingerprints: []string{
+					"27EDEAF22F3ABCEB50DB9A125CC908FDB71E12C2", // Daniel Stenberg
+				},
+			}},
+		},
+		"httpd": {
+			// The KEYS file lists the keys of every past httpd release
+			// manager. Until their fingerprints are verified and pinned from
+			// the check-keyrings report, httpd releases are checked against
+			// their published sha256.
+			PendingKeyURLs: []string{"https://downloads.apache.org/httpd/KEYS"},
+		},
+		"icu": {
+			// The KEYS file lists the keys of every past ICU release
+			// manager. Until their fingerprints are verified and pinned from
+			// the check-keyrings report, ICU releases are recorded as
+			// unverified.
+			PendingKeyURLs: []string{"https://raw.githubusercontent.com/unicode-org/icu/main/KEYS"},
+		},
+		"nginx": {
+			// Key URLs from https://nginx.org/en/pgp_keys.html. Maxim
+			// Konovalov and Sergey Budnevitch signed older releases, and their
+			// keys are verified and pinned from the check-keyrings report.
+			PendingKeyURLs: []string{
+				"https://nginx.org/keys/maxim.key",
+				"https://nginx.org/keys/sb.key",
+			},
+			KeyURLs: []KeySource{
+				{
+					URL: "https://nginx.org/keys/mdounin.key",
+					Fingerprints: []string{
+						"B0F4253373F8F6F510D42178520A9993A1C052F8", // Maxim Dounin
+					},
+				},
+				{
+					URL: "https://nginx.org/keys/thresh.key",
+					Fingerprints: []string{
+						"13C82A63B603576156E30A4EA0EA981B66B0D967", // Konstantin Pavlov
+					},
+				},
+			},
+		},
+		"rust": {
+			KeyURLs: []KeySource{{
+				URL: "https://static.rust-lang.org/rust-key.gpg.ascii",
+				Fingerprints: []string{
+					"108F66205EAEB0AAA8DD5E1C85AB96E6FA1BE5FE", // Rust Language (Tag and Release Signing Key)
+				},
+			}},
+		},
+		"yarn": {
+			KeyURLs: []KeySource{{
+				URL: "https://dl.yarnpkg.com/debian/pubkey.gpg",
+				Fingerprints: []string{
+					"72ECF46A56B4AD39C907BBB71646B01B86E50310", // Yarn Packaging
+					"6A010C5166006599AA17F08146C2130DFD2497F5", // Yarn Packaging
+				},
+			}},
+		},
+	}
+
+	for name, keyring := range keyrings {
+		keyring, err := LoadCommittedKeys(committedKeys, path.Join("keyrings", name), keyring)
+		if err != nil {
+			panic(err)
+		}
+
+		keyrings[name] = keyring
+	}
+
+	return keyrings
+}
+
+// LoadCommittedKeys returns keyring with the keys committed in dir of fsys,
+// one key per <fingerprint>.asc file. Every file must hold the key its name
+// says, and that key must be pinned by the keyring.
+func LoadCommittedKeys(fsys fs.FS, dir string, keyring Keyring) (Keyring, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return keyring, nil
+		}
+		return Keyring{}, fmt.Errorf("could not read keyring %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".asc") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var keys []string
+	for _, name := range names {
+		key, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			return Keyring{}, fmt.Errorf("could not read key %s: %w", name, err)
+		}
+
+		fingerprint := normalizeFingerprint(strings.TrimSuffix(name, ".asc"))
+		entities, errs := readKeyring(Keyring{Keys: []string{string(key)}})
+		if len(errs) > 0 {
+			return Keyring{}, fmt.Errorf("could not read key %s: %w", path.Join(dir, name), errs[0])
+		}
+		if len(entities) != 1 || fmt.Sprintf("%X", entities[0].PrimaryKey.Fingerprint) != fingerprint {
+			return Keyring{}, fmt.Errorf("key %s does not hold exactly the key with fingerprint %s", path.Join(dir, name), fingerprint)
+		}
+		if !keyring.trusts(fingerprint) {
+			return Keyring{}, fmt.Errorf("key %s is committed but its fingerprint is not pinned", path.Join(dir, name))
+		}
+
+		keys = append(keys, string(key))
+	}
+
+	keyring.Keys = append(keys, keyring.Keys...)
+	return keyring, nil
+}
+
+// armorEntity returns the armored public key of entity, along with its
+// revocations, so that a refreshed key keeps them.
+func armorEntity(entity *openpgp.Entity) (string, error) {
+	var buffer bytes.Buffer
+	writer, err := armor.Encode(&buffer, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return "", err
+	}
+
+	packets := []interface{ Serialize(io.Writer) error }{entity.PrimaryKey}
+	for _, revocation := range entity.Revocations {
+		packets = append(packets, revocation)
+	}
+	for _, identity := range entity.Identities {
+		packets = append(packets, identity.UserId)
+		if identity.SelfSignature != nil {
+			packets = append(packets, identity.SelfSignature)
+		}
+		for _, signature := range identity.Signatures {
+			packets = append(packets, signature)
+		}
+	}
+	for _, subkey := range entity.Subkeys {
+		packets = append(packets, subkey.PublicKey)
+		if subkey.Sig != nil {
+			packets = append(packets, subkey.Sig)
+		}
+	}
+
+	for _, p := range packets {
+		err = p.Serialize(writer)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return buffer.String() + "\n", nil
+}
+
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToUpper(strings.ReplaceAll(fingerprint, " ", ""))
+}
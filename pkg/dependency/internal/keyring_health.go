@@ -0,0 +1,326 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+// DefaultKeyExpiryWarning is how long before a key expires that it is
+// reported as expiring.
+const DefaultKeyExpiryWarning = 30 * 24 * time.Hour
+
+// KeyState is the health of a single PGP key.
+type KeyState string
+
+const (
+	KeyValid    KeyState = "valid"
+	KeyExpiring KeyState = "expiring"
+	KeyExpired  KeyState = "expired"
+	KeyRevoked  KeyState = "revoked"
+)
+
+// KeyStatus describes a key of a keyring.
+type KeyStatus struct {
+	Fingerprint string   `json:"fingerprint"`
+	UserID      string   `json:"user_id,omitempty"`
+	State       KeyState `json:"state"`
+	// Trusted is false for keys whose fingerprint the keyring does not pin.
+	Trusted bool `json:"trusted"`
+	// ExpiresAt is when the key can no longer make signatures: when its
+	// primary key expires, or when the last of its signing subkeys does.
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	RevocationReason string     `json:"revocation_reason,omitempty"`
+}
+
+// KeyringReport is the health of the keyring of a dependency.
+type KeyringReport struct {
+	Dependency string      `json:"dependency"`
+	Keys       []KeyStatus `json:"keys"`
+	// MissingFingerprints are pinned fingerprints that none of the keys
+	// have.
+	MissingFingerprints []string `json:"missing_fingerprints,omitempty"`
+	// UncommittedFingerprints are pinned fingerprints whose keys are not
+	// committed, and are fetched every time they are needed.
+	UncommittedFingerprints []string `json:"uncommitted_fingerprints,omitempty"`
+	// Errors are the problems reading or fetching the keys.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Healthy reports whether the keyring has a trusted key that can still make
+// signatures.
+func (r KeyringReport) Healthy() bool {
+	for _, key := range r.Keys {
+		if key.Trusted && (key.State == KeyValid || key.State == KeyExpiring) {
+			return true
+		}
+	}
+	return false
+}
+
+// Warnings describes every problem with the keyring that needs attention
+// before it stops verifying new releases.
+func (r KeyringReport) Warnings() []string {
+	var warnings []string
+	for _, key := range r.Keys {
+		if !key.Trusted {
+			// A new key whose fingerprint is not pinned yet is most likely
+			// the upstream rotating its signing key.
+			if key.State == KeyValid || key.State == KeyExpiring {
+				warnings = append(warnings, fmt.Sprintf("%s: pgp key %s is published but its fingerprint is not pinned", r.Dependency, key.Fingerprint))
+			}
+			continue
+		}
+
+		switch key.State {
+		case KeyExpiring:
+			warnings = append(warnings, fmt.Sprintf("%s: pgp key %s expires on %s", r.Dependency, key.Fingerprint, key.ExpiresAt.Format("2006-01-02")))
+		case KeyExpired:
+			warnings = append(warnings, fmt.Sprintf("%s: pgp key %s expired on %s", r.Dependency, key.Fingerprint, key.ExpiresAt.Format("2006-01-02")))
+		case KeyRevoked:
+			warning := fmt.Sprintf("%s: pgp key %s is revoked", r.Dependency, key.Fingerprint)
+			if key.RevocationReason != "" {
+				warning = fmt.Sprintf("%s: %s", warning, key.RevocationReason)
+			}
+			warnings = append(warnings, warning)
+		}
+	}
+
+	for _, fingerprint := range r.MissingFingerprints {
+		warnings = append(warnings, fmt.Sprintf("%s: no pgp key has pinned fingerprint %s", r.Dependency, fingerprint))
+	}
+
+	for _, fingerprint := range r.UncommittedFingerprints {
+		warnings = append(warnings, fmt.Sprintf("%s: pgp key %s is not committed", r.Dependency, fingerprint))
+	}
+
+	for _, err := range r.Errors {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", r.Dependency, err))
+	}
+
+	if !r.Healthy() {
+		warnings = append(warnings, fmt.Sprintf("%s: no trusted pgp key can verify new signatures", r.Dependency))
+	}
+
+	return warnings
+}
+
+// CheckKeyring reports the state of every key of keyring at now. Keys that
+// expire within warnWithin of now are reported as expiring. When the keyring
+// holds several copies of a key, such as a committed copy and a freshly
+// published one, a revocation in any copy wins, and otherwise the copy that
+// expires last is reported.
+func CheckKeyring(dependency string, keyring Keyring, now time.Time, warnWithin time.Duration) KeyringReport {
+	report := KeyringReport{Dependency: dependency, UncommittedFingerprints: keyring.UncommittedFingerprints()}
+
+	entities, unreadable := readKeyring(keyring)
+	for _, err := range unreadable {
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	err := keyring.Validate()
+	if err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	statuses := map[string]KeyStatus{}
+	for _, entity := range entities {
+		status := keyStatus(entity, now, warnWithin)
+		status.Trusted = keyring.trusts(status.Fingerprint)
+
+		if existing, ok := statuses[status.Fingerprint]; ok && !supersedes(status, existing) {
+			continue
+		}
+		statuses[status.Fingerprint] = status
+	}
+
+	for _, status := range statuses {
+		report.Keys = append(report.Keys, status)
+	}
+
+	sort.Slice(report.Keys, func(i, j int) bool {
+		return report.Keys[i].Fingerprint < report.Keys[j].Fingerprint
+	})
+
+	for _, fingerprint := range keyring.pins() {
+		if _, ok := statuses[normalizeFingerprint(fingerprint)]; !ok {
+			report.MissingFingerprints = append(report.MissingFingerprints, normalizeFingerprint(fingerprint))
+		}
+	}
+
+	return report
+}
+
+// supersedes reports whether status describes a key better than existing,
+// another copy of the same key.
+func supersedes(status, existing KeyStatus) bool {
+	if existing.State == KeyRevoked {
+		return false
+	}
+
+	if status.State == KeyRevoked {
+		return true
+	}
+
+	if existing.ExpiresAt == nil {
+		return false
+	}
+
+	return status.ExpiresAt == nil || status.ExpiresAt.After(*existing.ExpiresAt)
+}
+
+// readKeyring parses every armored key of keyring, returning the errors of
+// the keys that could not be read alongside the ones that could.
+func readKeyring(keyring Keyring) (openpgp.EntityList, []error) {
+	var keys []string
+	for _, key := range keyring.Keys {
+		blocks := Checksummer{}.SplitPGPKeys(key)
+		if len(blocks) == 0 {
+			blocks = []string{key}
+		}
+		keys = append(keys, blocks...)
+	}
+
+	var (
+		entities openpgp.EntityList
+		errs     []error
+	)
+	for _, key := range keys {
+		keyEntities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not read armored key ring: %w", err))
+			continue
+		}
+		entities = append(entities, keyEntities...)
+	}
+
+	return entities, errs
+}
+
+func keyStatus(entity *openpgp.Entity, now time.Time, warnWithin time.Duration) KeyStatus {
+	status := KeyStatus{
+		Fingerprint: fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint),
+		State:       KeyValid,
+	}
+
+	identity := primaryIdentity(entity)
+	if identity != nil {
+		status.UserID = identity.Name
+	}
+
+	if len(entity.Revocations) > 0 {
+		status.State = KeyRevoked
+		status.RevocationReason = revocationReason(entity.Revocations[0])
+		return status
+	}
+
+	expiresAt := signingExpiry(entity, identity)
+	if expiresAt == nil {
+		return status
+	}
+
+	status.ExpiresAt = expiresAt
+	switch {
+	case !now.Before(*expiresAt):
+		status.State = KeyExpired
+	case now.Add(warnWithin).After(*expiresAt):
+		status.State = KeyExpiring
+	}
+
+	return status
+}
+
+// signingExpiry returns when entity can no longer make signatures, or nil if
+// it does not expire.
+func signingExpiry(entity *openpgp.Entity, identity *openpgp.Identity) *time.Time {
+	var primaryExpiry *time.Time
+	if identity != nil && identity.SelfSignature != nil {
+		primaryExpiry = keyExpiry(entity.PrimaryKey, identity.SelfSignature)
+	}
+
+	// Keys with signing subkeys sign with the last of them to expire, as
+	// long as the primary key has not expired.
+	var (
+		signingSubkeys bool
+		subkeyExpiry   *time.Time
+	)
+	for _, subkey := range entity.Subkeys {
+		if subkey.Sig == nil || !subkey.Sig.FlagsValid || !subkey.Sig.FlagSign || subkey.Sig.SigType == packet.SigTypeSubkeyRevocation {
+			continue
+		}
+
+		expiry := keyExpiry(subkey.PublicKey, subkey.Sig)
+		if expiry == nil {
+			signingSubkeys, subkeyExpiry = true, nil
+			break
+		}
+
+		if !signingSubkeys || expiry.After(*subkeyExpiry) {
+			subkeyExpiry = expiry
+		}
+		signingSubkeys = true
+	}
+
+	if !signingSubkeys || subkeyExpiry == nil {
+		return primaryExpiry
+	}
+
+	if primaryExpiry != nil && primaryExpiry.Before(*subkeyExpiry) {
+		return primaryExpiry
+	}
+	return subkeyExpiry
+}
+
+func keyExpiry(key *packet.PublicKey, sig *packet.Signature) *time.Time {
+	if sig.KeyLifetimeSecs == nil || *sig.KeyLifetimeSecs == 0 {
+		return nil
+	}
+
+	expiry := key.CreationTime.Add(time.Duration(*sig.KeyLifetimeSecs) * time.Second).UTC()
+	return &expiry
+}
+
+func primaryIdentity(entity *openpgp.Entity) *openpgp.Identity {
+	var names []string
+	for name := range entity.Identities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var first *openpgp.Identity
+	for _, name := range names {
+		identity := entity.Identities[name]
+		if first == nil {
+			first = identity
+		}
+		if identity.SelfSignature != nil && identity.SelfSignature.IsPrimaryId != nil && *identity.SelfSignature.IsPrimaryId {
+			return identity
+		}
+	}
+	return first
+}
+
+func revocationReason(sig *packet.Signature) string {
+	if sig.RevocationReasonText != "" {
+		return sig.RevocationReasonText
+	}
+
+	if sig.RevocationReason == nil {
+		return ""
+	}
+
+	switch *sig.RevocationReason {
+	case 1:
+		return "key is superseded"
+	case 2:
+		return "key material has been compromised"
+	case 3:
+		return "key is retired and no longer used"
+	default:
+		return ""
+	}
+}
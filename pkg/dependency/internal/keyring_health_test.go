@@ -0,0 +1,236 @@
+package internal_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+func TestKeyringHealth(t *testing.T) {
+	spec.Run(t, "keyringHealth", testKeyringHealth, spec.Report(report.Terminal{}))
+}
+
+func testKeyringHealth(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+
+		now = time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	// newKey returns a key created at created that expires after lifetime,
+	// unless lifetime is zero, with its armored public key.
+	newKey := func(name string, created time.Time, lifetime time.Duration) (*openpgp.Entity, string) {
+		config := &packet.Config{RSABits: 1024, Time: func() time.Time { return created }}
+		entity, err := openpgp.NewEntity(name, "", name+"@example.com", config)
+		require.NoError(err)
+
+		if lifetime != 0 {
+			for id, identity := range entity.Identities {
+				seconds := uint32(lifetime.Seconds())
+				identity.SelfSignature.KeyLifetimeSecs = &seconds
+				require.NoError(identity.SelfSignature.SignUserId(id, entity.PrimaryKey, entity.PrivateKey, config))
+			}
+		}
+
+		return entity, armorKey(t, entity, nil)
+	}
+
+	revoke := func(entity *openpgp.Entity) string {
+		return revokeKey(t, entity, now)
+	}
+
+	fingerprint := func(entity *openpgp.Entity) string {
+		return fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint)
+	}
+
+	when("CheckKeyring", func() {
+		it("reports the state of every key", func() {
+			valid, validKey := newKey("valid", now.AddDate(-1, 0, 0), 0)
+			expiring, expiringKey := newKey("expiring", now.AddDate(-1, 0, 0), 375*24*time.Hour)
+			expired, expiredKey := newKey("expired", now.AddDate(-2, 0, 0), 365*24*time.Hour)
+			revoked, _ := newKey("revoked", now.AddDate(-1, 0, 0), 0)
+			unpinned, unpinnedKey := newKey("unpinned", now.AddDate(-1, 0, 0), 0)
+
+			report := internal.CheckKeyring("some-dep", internal.Keyring{
+				Fingerprints: []string{fingerprint(valid), fingerprint(expiring), fingerprint(expired), fingerprint(revoked), "AAAA BBBB"},
+				Keys:         []string{validKey, expiringKey + "\n" + expiredKey, revoke(revoked), unpinnedKey, "some-bad-key"},
+			}, now, internal.DefaultKeyExpiryWarning)
+
+			assert.Equal("some-dep", report.Dependency)
+
+			states := map[string]internal.KeyStatus{}
+			for _, key := range report.Keys {
+				states[key.Fingerprint] = key
+			}
+			require.Len(states, 5)
+
+			assert.Equal(internal.KeyValid, states[fingerprint(valid)].State)
+			assert.Equal("valid <valid@example.com>", states[fingerprint(valid)].UserID)
+			assert.Nil(states[fingerprint(valid)].ExpiresAt)
+			assert.True(states[fingerprint(valid)].Trusted)
+
+			assert.Equal(internal.KeyExpiring, states[fingerprint(expiring)].State)
+			assert.Equal(now.AddDate(-1, 0, 0).Add(375*24*time.Hour), *states[fingerprint(expiring)].ExpiresAt)
+
+			assert.Equal(internal.KeyExpired, states[fingerprint(expired)].State)
+			assert.Equal(internal.KeyRevoked, states[fingerprint(revoked)].State)
+
+			assert.Equal(internal.KeyValid, states[fingerprint(unpinned)].State)
+			assert.False(states[fingerprint(unpinned)].Trusted)
+
+			assert.Equal([]string{"AAAABBBB"}, report.MissingFingerprints)
+			assert.Len(report.Errors, 1)
+
+			assert.True(report.Healthy())
+
+			warnings := strings.Join(report.Warnings(), "\n")
+			assert.Contains(warnings, fmt.Sprintf("some-dep: pgp key %s expires on 2022-06-11", fingerprint(expiring)))
+			assert.Contains(warnings, fmt.Sprintf("some-dep: pgp key %s expired on 2021-06-01", fingerprint(expired)))
+			assert.Contains(warnings, fmt.Sprintf("some-dep: pgp key %s is revoked", fingerprint(revoked)))
+			assert.Contains(warnings, "some-dep: no pgp key has pinned fingerprint AAAABBBB")
+			assert.Contains(warnings, fmt.Sprintf("some-dep: pgp key %s is published but its fingerprint is not pinned", fingerprint(unpinned)))
+			assert.NotContains(warnings, fingerprint(valid))
+		})
+
+		it("prefers the revoked or longest lived copy of a key", func() {
+			revoked, revokedKey := newKey("revoked", now.AddDate(-1, 0, 0), 0)
+			extended, extendedKey := newKey("extended", now.AddDate(-1, 0, 0), 375*24*time.Hour)
+
+			for id, identity := range extended.Identities {
+				seconds := uint32((2 * 365 * 24 * time.Hour).Seconds())
+				identity.SelfSignature.KeyLifetimeSecs = &seconds
+				require.NoError(identity.SelfSignature.SignUserId(id, extended.PrimaryKey, extended.PrivateKey, nil))
+			}
+
+			report := internal.CheckKeyring("some-dep", internal.Keyring{
+				Fingerprints: []string{fingerprint(revoked), fingerprint(extended)},
+				Keys:         []string{revoke(revoked), revokedKey, extendedKey, armorKey(t, extended, nil), extendedKey},
+			}, now, internal.DefaultKeyExpiryWarning)
+
+			require.Len(report.Keys, 2)
+
+			states := map[string]internal.KeyStatus{}
+			for _, key := range report.Keys {
+				states[key.Fingerprint] = key
+			}
+
+			assert.Equal(internal.KeyRevoked, states[fingerprint(revoked)].State)
+			assert.Equal(internal.KeyValid, states[fingerprint(extended)].State)
+			assert.Equal(now.AddDate(1, 0, 0), *states[fingerprint(extended)].ExpiresAt)
+		})
+
+		it("is unhealthy when no trusted key can make signatures", func() {
+			expired, expiredKey := newKey("expired", now.AddDate(-2, 0, 0), 365*24*time.Hour)
+			_, unpinnedKey := newKey("unpinned", now.AddDate(-1, 0, 0), 0)
+
+			report := internal.CheckKeyring("some-dep", internal.Keyring{
+				Fingerprints: []string{fingerprint(expired)},
+				Keys:         []string{expiredKey, unpinnedKey},
+			}, now, internal.DefaultKeyExpiryWarning)
+
+			assert.False(report.Healthy())
+			assert.Contains(report.Warnings(), "some-dep: no trusted pgp key can verify new signatures")
+		})
+	})
+
+	when("VerifyASC", func() {
+		var (
+			filePath string
+			signer   *openpgp.Entity
+			asc      string
+		)
+
+		it.Before(func() {
+			filePath = filepath.Join(t.TempDir(), "some-file")
+			require.NoError(os.WriteFile(filePath, []byte("some-content"), 0600))
+
+			signer, _ = newKey("signer", time.Now().AddDate(-1, 0, 0), 0)
+
+			var signature bytes.Buffer
+			require.NoError(openpgp.ArmoredDetachSign(&signature, signer, strings.NewReader("some-content"), nil))
+			asc = signature.String()
+		})
+
+		it("refuses a revoked key", func() {
+			_, err := internal.NewChecksummer().VerifyASC(asc, filePath, internal.Keyring{
+				Fingerprints: []string{fingerprint(signer)},
+				Keys:         []string{revoke(signer)},
+			})
+			assert.Equal(depErrors.SignatureInvalidError{Reason: "all of the pinned pgp keys are revoked"}, err)
+		})
+
+		it("still verifies signatures with a key that is not revoked", func() {
+			actual, err := internal.NewChecksummer().VerifyASC(asc, filePath, internal.Keyring{
+				Fingerprints: []string{fingerprint(signer)},
+				Keys:         []string{armorKey(t, signer, nil)},
+			})
+			require.NoError(err)
+			assert.Equal(fingerprint(signer), actual)
+		})
+	})
+}
+
+// armorKey returns the armored public key of entity, with revocation if it
+// is set.
+// revokeKey returns the armored public key of entity with a revocation
+// signature made at now.
+func revokeKey(t *testing.T, entity *openpgp.Entity, now time.Time) string {
+	revocation := &packet.Signature{
+		SigType:      packet.SigTypeKeyRevocation,
+		PubKeyAlgo:   entity.PrimaryKey.PubKeyAlgo,
+		Hash:         crypto.SHA256,
+		CreationTime: now,
+		IssuerKeyId:  &entity.PrimaryKey.KeyId,
+	}
+
+	var prefix, body bytes.Buffer
+	entity.PrimaryKey.SerializeSignaturePrefix(&prefix)
+	require.NoError(t, entity.PrimaryKey.Serialize(&body))
+	length := int(prefix.Bytes()[1])<<8 | int(prefix.Bytes()[2])
+
+	h := sha256.New()
+	h.Write(prefix.Bytes())
+	h.Write(body.Bytes()[body.Len()-length:])
+	require.NoError(t, revocation.Sign(h, entity.PrivateKey, nil))
+
+	return armorKey(t, entity, revocation)
+}
+
+func armorKey(t *testing.T, entity *openpgp.Entity, revocation *packet.Signature) string {
+	var buffer bytes.Buffer
+	writer, err := armor.Encode(&buffer, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, entity.PrimaryKey.Serialize(writer))
+	if revocation != nil {
+		require.NoError(t, revocation.Serialize(writer))
+	}
+	for _, identity := range entity.Identities {
+		require.NoError(t, identity.UserId.Serialize(writer))
+		require.NoError(t, identity.SelfSignature.Serialize(writer))
+	}
+	for _, subkey := range entity.Subkeys {
+		require.NoError(t, subkey.PublicKey.Serialize(writer))
+		require.NoError(t, subkey.Sig.Serialize(writer))
+	}
+
+	require.NoError(t, writer.Close())
+	return buffer.String()
+}
@@ -0,0 +1,138 @@
+package internal_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+func TestKeyring(t *testing.T) {
+	spec.Run(t, "keyring", testKeyring, spec.Report(report.Terminal{}))
+}
+
+func testKeyring(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	newKey := func(name string) (string, string) {
+		entity, err := openpgp.NewEntity(name, "", name+"@example.com", &packet.Config{RSABits: 1024})
+		require.NoError(err)
+
+		return fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint), armorKey(t, entity, nil)
+	}
+
+	when("DefaultKeyrings", func() {
+		it("has a keyring for every dependency with signed artifacts whose keys are pinned", func() {
+			keyrings := internal.DefaultKeyrings()
+
+			for _, name := range []string{"composer", "curl", "nginx", "rust", "yarn"} {
+				assert.Contains(keyrings, name)
+				assert.NotEmpty(keyrings[name].KeyURLs, name)
+			}
+
+			assert.Equal([]internal.KeySource{{
+				URL:          "https://daniel.haxx.se/mykey.asc",
+				Fingerprints: []string{"27EDEAF22F3ABCEB50DB9A125CC908FDB71E12C2"},
+			}}, keyrings["curl"].KeyURLs)
+		})
+
+		it("pins the keys of every keyring and every key URL", func() {
+			for name, keyring := range internal.DefaultKeyrings() {
+				if len(keyring.PendingKeyURLs) > 0 && !keyring.Pinned() {
+					assert.Empty(keyring.KeyURLs, name)
+					continue
+				}
+				assert.NoError(keyring.Validate(), name)
+			}
+		})
+
+		it("reports the keys of ICU's release managers until they are pinned", func() {
+			icu := internal.DefaultKeyrings()["icu"]
+			assert.False(icu.Pinned())
+			assert.Equal([]string{"https://raw.githubusercontent.com/unicode-org/icu/main/KEYS"}, icu.PendingKeyURLs)
+		})
+
+		it("reports the keys of httpd's release managers until they are pinned", func() {
+			httpd := internal.DefaultKeyrings()["httpd"]
+			assert.False(httpd.Pinned())
+			assert.Equal([]string{"https://downloads.apache.org/httpd/KEYS"}, httpd.PendingKeyURLs)
+		})
+	})
+
+	when("LoadCommittedKeys", func() {
+		var (
+			fingerprint, key string
+			keyring          internal.Keyring
+		)
+
+		it.Before(func() {
+			fingerprint, key = newKey("some-signer")
+			keyring = internal.Keyring{KeyURLs: []internal.KeySource{{URL: "https://example.org/key.asc", Fingerprints: []string{fingerprint}}}}
+		})
+
+		it("adds the keys committed under their fingerprint", func() {
+			fsys := fstest.MapFS{
+				"keyrings/some-dep/" + fingerprint + ".asc": {Data: []byte(key)},
+				"keyrings/some-dep/README.md":               {Data: []byte("some-readme")},
+			}
+
+			loaded, err := internal.LoadCommittedKeys(fsys, "keyrings/some-dep", keyring)
+			require.NoError(err)
+
+			assert.Equal([]string{key}, loaded.Keys)
+			assert.Empty(loaded.UncommittedFingerprints())
+			assert.Empty(loaded.UncommittedSources())
+		})
+
+		it("leaves the keyring as it is when no keys are committed", func() {
+			loaded, err := internal.LoadCommittedKeys(fstest.MapFS{}, "keyrings/some-dep", keyring)
+			require.NoError(err)
+
+			assert.Empty(loaded.Keys)
+			assert.Equal([]string{fingerprint}, loaded.UncommittedFingerprints())
+			assert.Equal(keyring.KeyURLs, loaded.UncommittedSources())
+		})
+
+		it("refuses a key committed under another fingerprint", func() {
+			fsys := fstest.MapFS{"keyrings/some-dep/0000000000000000000000000000000000000000.asc": {Data: []byte(key)}}
+
+			_, err := internal.LoadCommittedKeys(fsys, "keyrings/some-dep", keyring)
+			assert.EqualError(err, "key keyrings/some-dep/0000000000000000000000000000000000000000.asc does not hold exactly the key with fingerprint 0000000000000000000000000000000000000000")
+		})
+
+		it("refuses a committed key whose fingerprint is not pinned", func() {
+			otherFingerprint, otherKey := newKey("other-signer")
+			fsys := fstest.MapFS{"keyrings/some-dep/" + otherFingerprint + ".asc": {Data: []byte(otherKey)}}
+
+			_, err := internal.LoadCommittedKeys(fsys, "keyrings/some-dep", keyring)
+			assert.EqualError(err, fmt.Sprintf("key keyrings/some-dep/%s.asc is committed but its fingerprint is not pinned", otherFingerprint))
+		})
+	})
+
+	when("PinnedKeys", func() {
+		it("returns the armored key of every pinned fingerprint", func() {
+			fingerprint, key := newKey("some-signer")
+			_, otherKey := newKey("other-signer")
+
+			keys, err := internal.Keyring{Fingerprints: []string{fingerprint}, Keys: []string{key + otherKey}}.PinnedKeys()
+			require.NoError(err)
+
+			require.Len(keys, 1)
+			entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keys[fingerprint]))
+			require.NoError(err)
+			require.Len(entities, 1)
+			assert.Equal(fingerprint, fmt.Sprintf("%X", entities[0].PrimaryKey.Fingerprint))
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package internal
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Masterminds/semver"
+	"gopkg.in/yaml.v2"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/licenses"
+)
+
+// LicenseOverridesVersion is the version of the license overrides file
+// format understood by ParseLicenseOverrides.
+const LicenseOverridesVersion = 1
+
+//go:embed license_overrides.yml
+var defaultLicenseOverrides []byte
+
+// LicenseOverride replaces the detected licenses of the versions of a
+// dependency matching Versions with the SPDX license expression Expression.
+// Versions is a semver constraint such as ">= 1.0, < 2.0", or a version
+// matched exactly when the dependency does not use semver.
+type LicenseOverride struct {
+	Dependency    string `yaml:"dependency"`
+	Versions      string `yaml:"versions"`
+	Expression    string `yaml:"expression"`
+	Justification string `yaml:"justification"`
+
+	constraints *semver.Constraints
+}
+
+// LicenseOverrides is the registry of license overrides.
+type LicenseOverrides struct {
+	Version   int               `yaml:"version"`
+	Overrides []LicenseOverride `yaml:"overrides"`
+}
+
+// DefaultLicenseOverrides returns the overrides embedded from
+// license_overrides.yml. It panics if that file is invalid, as it is part
+// of the binary.
+func DefaultLicenseOverrides() LicenseOverrides {
+	overrides, err := ParseLicenseOverrides(defaultLicenseOverrides)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded license overrides: %s", err))
+	}
+	return overrides
+}
+
+// LoadLicenseOverrides reads and validates a license overrides file.
+func LoadLicenseOverrides(path string) (LicenseOverrides, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return LicenseOverrides{}, fmt.Errorf("could not read license overrides: %w", err)
+	}
+
+	overrides, err := ParseLicenseOverrides(content)
+	if err != nil {
+		return LicenseOverrides{}, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	return overrides, nil
+}
+
+// ParseLicenseOverrides parses and validates the content of a license
+// overrides file.
+func ParseLicenseOverrides(content []byte) (LicenseOverrides, error) {
+	var overrides LicenseOverrides
+	err := yaml.UnmarshalStrict(content, &overrides)
+	if err != nil {
+		return LicenseOverrides{}, fmt.Errorf("could not unmarshal license overrides: %w", err)
+	}
+
+	if overrides.Version != LicenseOverridesVersion {
+		return LicenseOverrides{}, fmt.Errorf("unsupported license overrides version %d", overrides.Version)
+	}
+
+	for i, override := range overrides.Overrides {
+		err := override.validate()
+		if err != nil {
+			return LicenseOverrides{}, err
+		}
+
+		// versions that are not constraints are matched exactly
+		constraints, err := semver.NewConstraint(override.Versions)
+		if err == nil {
+			overrides.Overrides[i].constraints = constraints
+		}
+	}
+
+	return overrides, nil
+}
+
+// Lookup returns the first override matching version of the named
+// dependency.
+func (l LicenseOverrides) Lookup(dependency, version string) (LicenseOverride, bool) {
+	for _, override := range l.Overrides {
+		if override.Dependency == dependency && override.matches(version) {
+			return override, true
+		}
+	}
+	return LicenseOverride{}, false
+}
+
+func (l LicenseOverride) matches(version string) bool {
+	if l.Versions == version {
+		return true
+	}
+
+	if l.constraints == nil {
+		return false
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+
+	return l.constraints.Check(v)
+}
+
+func (l LicenseOverride) validate() error {
+	if l.Dependency == "" || l.Versions == "" {
+		return errors.New("license overrides must have a dependency and versions")
+	}
+
+	if l.Justification == "" {
+		return fmt.Errorf("license override for %s@%s must have a justification", l.Dependency, l.Versions)
+	}
+
+	err := licenses.ValidateLicenseExpression(l.Expression)
+	if err != nil {
+		return fmt.Errorf("license override for %s@%s: %w", l.Dependency, l.Versions, err)
+	}
+
+	return nil
+}
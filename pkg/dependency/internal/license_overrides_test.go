@@ -0,0 +1,96 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseOverrides(t *testing.T) {
+	spec.Run(t, "licenseOverrides", testLicenseOverrides, spec.Report(report.Terminal{}))
+}
+
+func testLicenseOverrides(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+	)
+
+	when("DefaultLicenseOverrides", func() {
+		it("parses the embedded overrides", func() {
+			overrides := internal.DefaultLicenseOverrides()
+			assert.Equal(internal.LicenseOverridesVersion, overrides.Version)
+		})
+	})
+
+	when("ParseLicenseOverrides", func() {
+		it("matches versions against semver constraints or exactly", func() {
+			overrides, err := internal.ParseLicenseOverrides([]byte(`
+version: 1
+overrides:
+- dependency: some-dep
+  versions: ">= 1.0, < 2.0"
+  expression: MIT OR Apache-2.0
+  justification: some-justification
+- dependency: some-dep
+  versions: some-release
+  expression: BSD-3-Clause
+  justification: some-other-justification
+`))
+			require.NoError(err)
+
+			override, ok := overrides.Lookup("some-dep", "v1.2.3")
+			require.True(ok)
+			assert.Equal("MIT OR Apache-2.0", override.Expression)
+			assert.Equal("some-justification", override.Justification)
+
+			override, ok = overrides.Lookup("some-dep", "some-release")
+			require.True(ok)
+			assert.Equal("BSD-3-Clause", override.Expression)
+
+			_, ok = overrides.Lookup("some-dep", "2.0.0")
+			assert.False(ok)
+
+			_, ok = overrides.Lookup("some-other-dep", "1.2.3")
+			assert.False(ok)
+		})
+
+		it("rejects invalid overrides", func() {
+			for name, content := range map[string]string{
+				"unsupported version":   "version: 2\noverrides: []",
+				"unknown field":         "version: 1\noverrides:\n- dependency: a\n  versions: 1.0.0\n  expression: MIT\n  justification: j\n  reason: r",
+				"missing justification": "version: 1\noverrides:\n- dependency: a\n  versions: 1.0.0\n  expression: MIT",
+				"missing versions":      "version: 1\noverrides:\n- dependency: a\n  expression: MIT\n  justification: j",
+				"missing expression":    "version: 1\noverrides:\n- dependency: a\n  versions: 1.0.0\n  justification: j",
+				"unknown license":       "version: 1\noverrides:\n- dependency: a\n  versions: 1.0.0\n  expression: MIT OR Some-License\n  justification: j",
+			} {
+				_, err := internal.ParseLicenseOverrides([]byte(content))
+				assert.Error(err, name)
+			}
+		})
+	})
+
+	when("LoadLicenseOverrides", func() {
+		it("reads the overrides from a file", func() {
+			path := filepath.Join(t.TempDir(), "overrides.yml")
+			require.NoError(os.WriteFile(path, []byte("version: 1\noverrides:\n- dependency: a\n  versions: 1.0.0\n  expression: MIT\n  justification: j\n"), 0600))
+
+			overrides, err := internal.LoadLicenseOverrides(path)
+			require.NoError(err)
+
+			_, ok := overrides.Lookup("a", "1.0.0")
+			assert.True(ok)
+		})
+
+		it("returns an error when the file does not exist", func() {
+			_, err := internal.LoadLicenseOverrides(filepath.Join(t.TempDir(), "missing.yml"))
+			assert.Error(err)
+		})
+	})
+}
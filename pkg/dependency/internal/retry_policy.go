@@ -0,0 +1,117 @@
+package internal
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how often, and how patiently, a WebClient retries a
+// request that failed because of a network error or a 5xx or 429 response.
+// Only idempotent requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times a request is made. Zero or one
+	// disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry. It doubles on every
+	// following retry, up to MaxBackoff, and is jittered so that concurrent
+	// clients do not retry in lockstep.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy is used by factories created with
+// NewDependencyFactory.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: time.Second,
+	MaxBackoff:     30 * time.Second,
+}
+
+// RetryError is returned once a request has failed on every attempt.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e RetryError) Error() string {
+	return fmt.Sprintf("gave up after %d attempts: %s", e.Attempts, e.Err)
+}
+
+func (e RetryError) Unwrap() error {
+	return e.Err
+}
+
+// wait returns how long to wait before retrying after the given attempt,
+// or false if the request should not be retried.
+func (p RetryPolicy) wait(attempt int, response *http.Response) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	if response != nil {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			if retryAfter > p.MaxBackoff {
+				return 0, false
+			}
+			return retryAfter, true
+		}
+	}
+
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1)), true
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// isRateLimited reports whether the response is a rate limit, either a 429
+// or a 403 from GitHub once the rate limit has been used up.
+func isRateLimited(response *http.Response) bool {
+	return response.StatusCode == http.StatusTooManyRequests ||
+		(response.StatusCode == http.StatusForbidden && response.Header.Get("X-RateLimit-Remaining") == "0")
+}
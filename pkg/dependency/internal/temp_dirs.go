@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// TempDirs creates temporary directories and keeps track of them, so that
+// any left behind can be removed together with RemoveAll. Copies of a
+// TempDirs share the same directories.
+type TempDirs struct {
+	state *tempDirsState
+}
+
+type tempDirsState struct {
+	mutex sync.Mutex
+	dirs  map[string]struct{}
+}
+
+func NewTempDirs() TempDirs {
+	return TempDirs{
+		state: &tempDirsState{
+			dirs: map[string]struct{}{},
+		},
+	}
+}
+
+// MkdirTemp creates a new temporary directory, as os.MkdirTemp does in the
+// default temporary directory.
+func (t TempDirs) MkdirTemp(pattern string) (string, error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("could not create temp directory: %w", err)
+	}
+
+	t.state.mutex.Lock()
+	t.state.dirs[dir] = struct{}{}
+	t.state.mutex.Unlock()
+
+	return dir, nil
+}
+
+// Remove removes a directory created by MkdirTemp.
+func (t TempDirs) Remove(dir string) error {
+	t.state.mutex.Lock()
+	delete(t.state.dirs, dir)
+	t.state.mutex.Unlock()
+
+	return os.RemoveAll(dir)
+}
+
+// RemoveAll removes every directory created by MkdirTemp that has not been
+// removed yet.
+func (t TempDirs) RemoveAll() error {
+	t.state.mutex.Lock()
+	defer t.state.mutex.Unlock()
+
+	var errs []error
+	for dir := range t.state.dirs {
+		err := os.RemoveAll(dir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(t.state.dirs, dir)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("could not remove %d temp directories: %w", len(errs), errs[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTempDirs(t *testing.T) {
+	spec.Run(t, "tempDirs", testTempDirs, spec.Report(report.Terminal{}))
+}
+
+func testTempDirs(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert   = assert.New(t)
+		require  = require.New(t)
+		tempDirs internal.TempDirs
+	)
+
+	it.Before(func() {
+		tempDirs = internal.NewTempDirs()
+	})
+
+	when("RemoveAll", func() {
+		it("removes every directory that has not been removed yet", func() {
+			firstDir, err := tempDirs.MkdirTemp("first")
+			require.NoError(err)
+			assert.DirExists(firstDir)
+
+			secondDir, err := tempDirs.MkdirTemp("second")
+			require.NoError(err)
+
+			require.NoError(tempDirs.Remove(firstDir))
+			assert.NoDirExists(firstDir)
+			assert.DirExists(secondDir)
+
+			require.NoError(tempDirs.RemoveAll())
+			assert.NoDirExists(secondDir)
+		})
+
+		it("is shared between copies", func() {
+			dir, err := tempDirs.MkdirTemp("some-dir")
+			require.NoError(err)
+
+			tempDirsCopy := tempDirs
+			require.NoError(tempDirsCopy.RemoveAll())
+			assert.NoDirExists(dir)
+		})
+	})
+}
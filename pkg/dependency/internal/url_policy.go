@@ -0,0 +1,249 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+)
+
+// DefaultMaxRedirects is the number of redirects followed when a URLPolicy
+// does not set MaxRedirects.
+const DefaultMaxRedirects = 10
+
+// URLPolicy limits the URLs a WebClient may request, including the targets
+// of any redirects. Requests to loopback, private, link-local and other
+// non-public addresses are refused unless AllowPrivateNetworks is set, and
+// the addresses are checked once resolved so that a public host name cannot
+// be pointed at an internal address. Violations are returned as a
+// URLNotAllowedError.
+type URLPolicy struct {
+	// Schemes lists the allowed URL schemes. It defaults to https only.
+	Schemes []string
+
+	// Hosts lists the allowed host names. A name starting with "*." allows
+	// any subdomain of the rest of the name. An empty list allows any host.
+	Hosts []string
+
+	// MaxRedirects bounds the number of redirects followed for a request.
+	// It defaults to DefaultMaxRedirects, and a negative value refuses to
+	// follow any.
+	MaxRedirects int
+
+	AllowPrivateNetworks bool
+}
+
+type urlPolicyKey struct{}
+
+// WithURLPolicy makes the WebClient enforce policy for every request.
+func WithURLPolicy(policy URLPolicy) WebClientOption {
+	return func(w *WebClient) {
+		w.urlPolicy = &policy
+	}
+}
+
+// WithRequestURLPolicy enforces policy for a single request, in place of the
+// WebClient's own policy.
+func WithRequestURLPolicy(policy URLPolicy) RequestOption {
+	return func(r *http.Request) {
+		*r = *r.WithContext(context.WithValue(r.Context(), urlPolicyKey{}, policy))
+	}
+}
+
+// check returns a URLNotAllowedError if policy does not allow u.
+func (p URLPolicy) check(u *url.URL) error {
+	schemes := p.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+
+	if !containsFold(schemes, u.Scheme) {
+		return depErrors.URLNotAllowedError{URL: u.String(), Reason: fmt.Sprintf("scheme %q is not allowed", u.Scheme)}
+	}
+
+	hostname := strings.ToLower(u.Hostname())
+	if len(p.Hosts) > 0 && !p.allowsHost(hostname) {
+		return depErrors.URLNotAllowedError{URL: u.String(), Reason: fmt.Sprintf("host %q is not allowed", hostname)}
+	}
+
+	if ip := net.ParseIP(hostname); ip != nil && !p.AllowPrivateNetworks && isPrivateIP(ip) {
+		return depErrors.URLNotAllowedError{URL: u.String(), Reason: fmt.Sprintf("address %s is not public", ip)}
+	}
+
+	return nil
+}
+
+func (p URLPolicy) allowsHost(hostname string) bool {
+	for _, host := range p.Hosts {
+		host = strings.ToLower(host)
+		if strings.HasPrefix(host, "*.") {
+			if strings.HasSuffix(hostname, host[1:]) {
+				return true
+			}
+			continue
+		}
+
+		if hostname == host {
+			return true
+		}
+	}
+	return false
+}
+
+func (p URLPolicy) maxRedirects() int {
+	switch {
+	case p.MaxRedirects < 0:
+		return 0
+	case p.MaxRedirects == 0:
+		return DefaultMaxRedirects
+	default:
+		return p.MaxRedirects
+	}
+}
+
+// urlPolicyFor returns the policy that applies to a request, if any.
+func (w WebClient) urlPolicyFor(ctx context.Context) (URLPolicy, bool) {
+	if policy, ok := ctx.Value(urlPolicyKey{}).(URLPolicy); ok {
+		return policy, true
+	}
+
+	if w.urlPolicy != nil {
+		return *w.urlPolicy, true
+	}
+
+	return URLPolicy{}, false
+}
+
+func (w WebClient) checkURL(request *http.Request) error {
+	policy, ok := w.urlPolicyFor(request.Context())
+	if !ok {
+		return nil
+	}
+
+	return policy.check(request.URL)
+}
+
+// checkRedirect is used as the http.Client's CheckRedirect. Every redirect
+// target must be allowed by the policy of the original request, and https
+// requests are never redirected to plain http.
+func (w WebClient) checkRedirect(request *http.Request, via []*http.Request) error {
+	policy, ok := w.urlPolicyFor(request.Context())
+	if !ok {
+		if len(via) >= DefaultMaxRedirects {
+			return fmt.Errorf("stopped after %d redirects", DefaultMaxRedirects)
+		}
+		return nil
+	}
+
+	if len(via) > policy.maxRedirects() {
+		return depErrors.URLNotAllowedError{URL: request.URL.String(), Reason: fmt.Sprintf("more than %d redirects", policy.maxRedirects())}
+	}
+
+	if via[len(via)-1].URL.Scheme == "https" && request.URL.Scheme != "https" {
+		return depErrors.URLNotAllowedError{URL: request.URL.String(), Reason: "redirect from https to " + request.URL.Scheme}
+	}
+
+	return policy.check(request.URL)
+}
+
+// dialContext refuses connections to non-public addresses once the host
+// name of a request has been resolved, unless its policy allows them.
+func (w WebClient) dialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	// The configured proxy is trusted wherever it is; the URL policy still
+	// checks the URLs requested through it.
+	if policy, ok := w.urlPolicyFor(ctx); ok && !policy.AllowPrivateNetworks && !w.isProxyAddress(address) {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip != nil && isPrivateIP(ip) {
+				return depErrors.URLNotAllowedError{URL: address, Reason: fmt.Sprintf("address %s is not public", ip)}
+			}
+
+			return nil
+		}
+	}
+
+	return dialer.DialContext(ctx, network, address)
+}
+
+// isProxyAddress reports whether address is the host and port of the proxy
+// the WebClient sends its requests through.
+func (w WebClient) isProxyAddress(address string) bool {
+	if w.proxyConfig == nil {
+		return false
+	}
+
+	for _, proxy := range []string{w.proxyConfig.HTTPProxy, w.proxyConfig.HTTPSProxy} {
+		if proxy != "" && proxyAddress(proxy) == address {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyAddress returns the host and port dialed for proxy, which, like in
+// HTTP_PROXY, may omit its scheme.
+func proxyAddress(proxy string) string {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		proxyURL, err = url.Parse("http://" + proxy)
+		if err != nil {
+			return ""
+		}
+	}
+
+	port := proxyURL.Port()
+	if port == "" {
+		switch proxyURL.Scheme {
+		case "https":
+			port = "443"
+		case "socks5":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+
+	return net.JoinHostPort(proxyURL.Hostname(), port)
+}
+
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		carrierGradeNAT.Contains(ip)
+}
+
+func isURLNotAllowed(err error) bool {
+	var urlNotAllowedErr depErrors.URLNotAllowedError
+	return errors.As(err, &urlNotAllowedErr)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -5,28 +5,123 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
+
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"golang.org/x/net/http/httpproxy"
 )
 
 type WebClient struct {
 	httpClient *http.Client
+	httpCache  *HTTPCache
+
+	retryPolicy       RetryPolicy
+	hostRetryPolicies map[string]RetryPolicy
+
+	maxDownloadSize int64
+
+	urlPolicy *URLPolicy
+
+	// proxyConfig is read from HTTP_PROXY, HTTPS_PROXY and NO_PROXY when the
+	// WebClient is created.
+	proxyConfig *httpproxy.Config
+
+	redactor Redactor
+}
+
+type WebClientOption func(*WebClient)
+
+// WithHTTPCache serves GET requests through the given on-disk cache.
+func WithHTTPCache(httpCache HTTPCache) WebClientOption {
+	return func(w *WebClient) {
+		w.httpCache = &httpCache
+	}
+}
+
+// WithRetryPolicy retries failed idempotent requests according to policy.
+func WithRetryPolicy(policy RetryPolicy) WebClientOption {
+	return func(w *WebClient) {
+		w.retryPolicy = policy
+	}
+}
+
+// WithHostRetryPolicy overrides the retry policy for requests to host.
+func WithHostRetryPolicy(host string, policy RetryPolicy) WebClientOption {
+	return func(w *WebClient) {
+		policies := map[string]RetryPolicy{}
+		for h, p := range w.hostRetryPolicies {
+			policies[h] = p
+		}
+		policies[host] = policy
+		w.hostRetryPolicies = policies
+	}
+}
+
+// WithMaxDownloadSize makes Download fail for files larger than size bytes,
+// unless overridden for a request with WithMaxSize.
+func WithMaxDownloadSize(size int64) WebClientOption {
+	return func(w *WebClient) {
+		w.maxDownloadSize = size
+	}
+}
+
+// WithSecrets removes the given secrets from the errors returned by the
+// WebClient, in addition to the credentials a Redactor always removes.
+func WithSecrets(secrets ...string) WebClientOption {
+	return func(w *WebClient) {
+		w.redactor = w.redactor.With(secrets...)
+	}
 }
 
-func NewWebClient() WebClient {
-	return WebClient{
-		httpClient: &http.Client{
-			Timeout: 5 * time.Minute,
-			Transport: &http.Transport{
-				TLSHandshakeTimeout: 10 * time.Second,
-			},
-		},
+func NewWebClient(options ...WebClientOption) WebClient {
+	webClient := WebClient{}
+
+	for _, option := range options {
+		option(&webClient)
+	}
+
+	webClient.proxyConfig = httpproxy.FromEnvironment()
+	proxy := webClient.proxyConfig.ProxyFunc()
+
+	// The transport keeps the proxy, connection pooling and HTTP/2 settings
+	// of the default one, and only dials through the URL policy.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(request *http.Request) (*url.URL, error) {
+		return proxy(request.URL)
 	}
+	transport.DialContext = webClient.dialContext
+	transport.TLSHandshakeTimeout = 10 * time.Second
+
+	webClient.httpClient = &http.Client{
+		Timeout:       5 * time.Minute,
+		CheckRedirect: webClient.checkRedirect,
+		Transport:     transport,
+	}
+
+	return webClient
 }
 
 type RequestOption func(r *http.Request)
 
 func (w WebClient) Download(url, filename string, options ...RequestOption) error {
+	options = append([]RequestOption{WithMaxSize(w.maxDownloadSize)}, options...)
+
+	var err error
+	if w.httpCache != nil {
+		err = w.cachedDownload(url, filename, options...)
+	} else {
+		err = w.resumableDownload(url, filename, options...)
+	}
+	if err != nil {
+		return w.redactor.Error(err)
+	}
+
+	return verifyDownload(filename, options...)
+}
+
+func (w WebClient) cachedDownload(url, filename string, options ...RequestOption) error {
 	responseBody, err := w.makeRequest("GET", url, nil, options...)
 	if err != nil {
 		return err
@@ -50,7 +145,7 @@ func (w WebClient) Download(url, filename string, options ...RequestOption) erro
 func (w WebClient) Get(url string, options ...RequestOption) ([]byte, error) {
 	responseBody, err := w.makeRequest("GET", url, nil, options...)
 	if err != nil {
-		return nil, err
+		return nil, w.redactor.Error(err)
 	}
 	defer responseBody.Close()
 
@@ -64,7 +159,7 @@ func (w WebClient) Get(url string, options ...RequestOption) ([]byte, error) {
 func (w WebClient) Post(url string, requestBody []byte, options ...RequestOption) ([]byte, error) {
 	responseBody, err := w.makeRequest("POST", url, bytes.NewReader(requestBody), options...)
 	if err != nil {
-		return nil, err
+		return nil, w.redactor.Error(err)
 	}
 	defer responseBody.Close()
 
@@ -79,7 +174,7 @@ func WithHeader(name, value string) RequestOption {
 	return func(r *http.Request) { r.Header.Add(name, value) }
 }
 
-func (w WebClient) makeRequest(method string, url string, body io.Reader, options ...RequestOption) (io.ReadCloser, error) {
+func newRequest(method string, url string, body io.Reader, options ...RequestOption) (*http.Request, error) {
 	request, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("could not create request: %w", err)
@@ -89,14 +184,179 @@ func (w WebClient) makeRequest(method string, url string, body io.Reader, option
 		option(request)
 	}
 
-	response, err := w.httpClient.Do(request)
+	return request, nil
+}
+
+func (w WebClient) makeRequest(method string, url string, body io.Reader, options ...RequestOption) (io.ReadCloser, error) {
+	request, err := newRequest(method, url, body, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.checkURL(request)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, err
 	}
 
+	if w.httpCache != nil && method == http.MethodGet {
+		return w.makeCachedRequest(request)
+	}
+
+	response, err := w.do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.responseBody(response)
+}
+
+// makeCachedRequest answers a GET request from the http cache when the
+// cached response is immutable or the upstream reports it as not modified,
+// and otherwise stores the fresh response in the cache.
+func (w WebClient) makeCachedRequest(request *http.Request) (io.ReadCloser, error) {
+	url := request.URL.String()
+	key := w.httpCache.key(request)
+	immutable := w.httpCache.isImmutable(url)
+
+	entry, cached, ok := w.httpCache.get(key)
+	if ok {
+		if immutable {
+			w.httpCache.touch(key)
+			return cached, nil
+		}
+
+		if entry.ETag != "" {
+			request.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			request.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	response, err := w.do(request)
+	if err != nil {
+		if ok {
+			cached.Close()
+		}
+		return nil, err
+	}
+
+	if ok {
+		if response.StatusCode == http.StatusNotModified {
+			response.Body.Close()
+			w.httpCache.touch(key)
+			return cached, nil
+		}
+		cached.Close()
+	}
+
+	body, err := w.responseBody(response)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkContentLength(request, response)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+
+	etag := response.Header.Get("ETag")
+	lastModified := response.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" && !immutable {
+		return struct {
+			io.Reader
+			io.Closer
+		}{limitSize(request, body, 0), body}, nil
+	}
+	defer body.Close()
+
+	return w.httpCache.store(key, httpCacheEntry{
+		URL:          url,
+		ETag:         etag,
+		LastModified: lastModified,
+	}, limitSize(request, body, 0))
+}
+
+// do sends the request, retrying network errors and 5xx or 429 responses
+// of idempotent requests according to the retry policy for its host. Any
+// other response is returned for the caller to check. Requests that are not
+// allowed by the URL policy are never sent or retried.
+func (w WebClient) do(request *http.Request) (*http.Response, error) {
+	err := w.checkURL(request)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := w.retryPolicyFor(request)
+
+	for attempt := 1; ; attempt++ {
+		response, err := w.httpClient.Do(request)
+		if err != nil {
+			err = fmt.Errorf("failed to make request: %w", err)
+			if !isURLNotAllowed(err) {
+				err = depErrors.UpstreamUnavailableError{URL: w.redactor.Redact(request.URL.String()), Err: err}
+			}
+		} else if !isRetryableStatus(response.StatusCode) {
+			return response, nil
+		} else {
+			_, err = w.responseBody(response)
+		}
+
+		wait, retry := policy.wait(attempt, response)
+		if !retry || !isIdempotent(request.Method) || isURLNotAllowed(err) {
+			if attempt > 1 {
+				return nil, RetryError{Attempts: attempt, Err: err}
+			}
+			return nil, err
+		}
+
+		time.Sleep(wait)
+
+		if request.GetBody != nil {
+			request.Body, err = request.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("could not rewind request body: %w", err)
+			}
+		}
+	}
+}
+
+func (w WebClient) retryPolicyFor(request *http.Request) RetryPolicy {
+	if policy, ok := w.hostRetryPolicies[request.URL.Hostname()]; ok {
+		return policy
+	}
+	return w.retryPolicy
+}
+
+// responseBody returns the body of a successful response, or an HTTPError
+// holding a redacted excerpt of the body otherwise. Rate limits and server
+// errors are marked as a RateLimitedError or UpstreamUnavailableError.
+func (w WebClient) responseBody(response *http.Response) (io.ReadCloser, error) {
 	if response.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(response.Body)
-		return nil, fmt.Errorf("got unsuccessful response: status code: %d, body: %s", response.StatusCode, body)
+		defer response.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(response.Body, MaxErrorBodyLength+1))
+
+		var url string
+		if response.Request != nil {
+			url = w.redactor.Redact(response.Request.URL.String())
+		}
+
+		httpErr := depErrors.HTTPError{
+			StatusCode: response.StatusCode,
+			URL:        url,
+			Body:       w.redactor.Excerpt(body),
+		}
+
+		switch {
+		case isRateLimited(response):
+			retryAfter, _ := parseRetryAfter(response.Header.Get("Retry-After"))
+			return nil, depErrors.RateLimitedError{URL: url, RetryAfter: retryAfter, Err: httpErr}
+		case response.StatusCode >= 500:
+			return nil, depErrors.UpstreamUnavailableError{URL: url, Err: httpErr}
+		default:
+			return nil, httpErr
+		}
 	}
 
 	return response.Body, nil
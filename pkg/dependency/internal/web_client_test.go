@@ -1,7 +1,9 @@
 package internal_test
 
 import (
+	"errors"
 	"fmt"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
@@ -10,9 +12,14 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestWebClient(t *testing.T) {
@@ -26,6 +33,8 @@ func testWebClient(t *testing.T, when spec.G, it spec.S) {
 		server    *httptest.Server
 		webClient internal.WebClient
 		testDir   string
+		requests  map[string]int
+		ranges    []string
 	)
 	const (
 		fileContents = "some-contents"
@@ -36,7 +45,11 @@ func testWebClient(t *testing.T, when spec.G, it spec.S) {
 		testDir, err = os.MkdirTemp("", "external-dependency-resource-web-client")
 		require.NoError(err)
 
+		requests = map[string]int{}
+		ranges = nil
 		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests[r.URL.Path]++
+
 			switch r.URL.Path {
 			case "/file":
 				_, _ = fmt.Fprint(w, fileContents)
@@ -47,6 +60,67 @@ func testWebClient(t *testing.T, when spec.G, it spec.S) {
 				body, err := io.ReadAll(r.Body)
 				require.NoError(err)
 				_, _ = fmt.Fprint(w, string(body))
+			case "/etag":
+				if r.Header.Get("If-None-Match") == `"some-etag"` {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("ETag", `"some-etag"`)
+				_, _ = fmt.Fprint(w, fileContents)
+			case "/other-etag":
+				w.Header().Set("ETag", `"other-etag"`)
+				_, _ = fmt.Fprint(w, "other-contents")
+			case "/last-modified":
+				if r.Header.Get("If-Modified-Since") == "Wed, 21 Oct 2015 07:28:00 GMT" {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+				_, _ = fmt.Fprint(w, fileContents)
+			case "/flaky":
+				if requests[r.URL.Path] < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					_, _ = fmt.Fprint(w, "some-unavailable-error")
+					return
+				}
+				_, _ = fmt.Fprint(w, fileContents)
+			case "/rate-limited":
+				if requests[r.URL.Path] < 2 {
+					w.Header().Set("Retry-After", r.URL.Query().Get("retry-after"))
+					w.WriteHeader(http.StatusTooManyRequests)
+					return
+				}
+				_, _ = fmt.Fprint(w, fileContents)
+			case "/resumable", "/no-ranges":
+				ranges = append(ranges, r.Header.Get("Range"))
+				w.Header().Set("ETag", `"some-etag"`)
+
+				var start int
+				if r.URL.Path == "/resumable" && r.Header.Get("If-Range") == `"some-etag"` {
+					_, _ = fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &start)
+				}
+
+				if start > 0 {
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(fileContents)-1, len(fileContents)))
+					w.WriteHeader(http.StatusPartialContent)
+					_, _ = fmt.Fprint(w, fileContents[start:])
+					return
+				}
+
+				w.Header().Set("Content-Length", strconv.Itoa(len(fileContents)))
+				if requests[r.URL.Path] == 1 {
+					_, _ = fmt.Fprint(w, fileContents[:5])
+					w.(http.Flusher).Flush()
+					panic(http.ErrAbortHandler)
+				}
+				_, _ = fmt.Fprint(w, fileContents)
+			case "/echo-secret":
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = fmt.Fprintf(w, "bad credentials: %s %s", r.Header.Get("Authorization"), strings.Repeat("a", internal.MaxErrorBodyLength))
+			case "/redirect":
+				http.Redirect(w, r, r.URL.Query().Get("to"), http.StatusFound)
+			case "/404":
+				w.WriteHeader(http.StatusNotFound)
 			case "/500":
 				w.WriteHeader(500)
 				_, _ = fmt.Fprint(w, "some-server-error")
@@ -73,6 +147,106 @@ func testWebClient(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(fileContents, string(contents))
 		})
 
+		when("the connection drops part way through", func() {
+			it.Before(func() {
+				webClient = internal.NewWebClient(internal.WithRetryPolicy(internal.RetryPolicy{MaxAttempts: 2}))
+			})
+
+			it("resumes the download from where it stopped", func() {
+				outputPath := filepath.Join(testDir, "some-file.txt")
+
+				err := webClient.Download(server.URL+"/resumable", outputPath)
+				require.NoError(err)
+
+				contents, err := os.ReadFile(outputPath)
+				require.NoError(err)
+				assert.Equal(fileContents, string(contents))
+				assert.Equal([]string{"", "bytes=5-"}, ranges)
+			})
+
+			when("the server does not support ranges", func() {
+				it("downloads the whole file again", func() {
+					outputPath := filepath.Join(testDir, "some-file.txt")
+
+					err := webClient.Download(server.URL+"/no-ranges", outputPath)
+					require.NoError(err)
+
+					contents, err := os.ReadFile(outputPath)
+					require.NoError(err)
+					assert.Equal(fileContents, string(contents))
+				})
+			})
+
+			when("there are no attempts left", func() {
+				it.Before(func() {
+					webClient = internal.NewWebClient()
+				})
+
+				it("returns an error", func() {
+					err := webClient.Download(server.URL+"/resumable", filepath.Join(testDir, "some-file.txt"))
+					assert.ErrorContains(err, "failed to write to file: unexpected EOF")
+				})
+			})
+		})
+
+		when("the file is larger than the maximum download size", func() {
+			it.Before(func() {
+				webClient = internal.NewWebClient(internal.WithMaxDownloadSize(int64(len(fileContents) - 1)))
+			})
+
+			it("returns an error", func() {
+				err := webClient.Download(server.URL+"/file", filepath.Join(testDir, "some-file.txt"))
+
+				var sizeLimitErr internal.SizeLimitError
+				require.ErrorAs(err, &sizeLimitErr)
+				assert.Equal(int64(len(fileContents)-1), sizeLimitErr.Limit)
+			})
+
+			it("checks the announced Content-Length before downloading", func() {
+				err := webClient.Download(server.URL+"/resumable", filepath.Join(testDir, "some-file.txt"))
+				assert.EqualError(err, fmt.Sprintf("download of %s/resumable exceeds the maximum size of %d bytes", server.URL, len(fileContents)-1))
+				assert.NoFileExists(filepath.Join(testDir, "some-file.txt"))
+			})
+
+			it("can be overridden for a single download", func() {
+				err := webClient.Download(server.URL+"/file", filepath.Join(testDir, "some-file.txt"), internal.WithMaxSize(int64(len(fileContents))))
+				assert.NoError(err)
+			})
+
+			when("the response is cached", func() {
+				it.Before(func() {
+					webClient = internal.NewWebClient(
+						internal.WithMaxDownloadSize(int64(len(fileContents)-1)),
+						internal.WithHTTPCache(internal.NewHTTPCache(filepath.Join(testDir, "http-cache"), 0)),
+					)
+				})
+
+				it("does not store it", func() {
+					err := webClient.Download(server.URL+"/etag", filepath.Join(testDir, "some-file.txt"))
+
+					var sizeLimitErr internal.SizeLimitError
+					require.ErrorAs(err, &sizeLimitErr)
+
+					bodies, err := filepath.Glob(filepath.Join(testDir, "http-cache", "*.body"))
+					require.NoError(err)
+					assert.Empty(bodies)
+				})
+			})
+		})
+
+		when("WithExpectedSHA256 is specified", func() {
+			it("downloads a file with that SHA256", func() {
+				err := webClient.Download(server.URL+"/file", filepath.Join(testDir, "some-file.txt"),
+					internal.WithExpectedSHA256("6E32EA34DB1B3755D7DEC972EB72C705338F0DD8E0BE881D966963438FB2E800"))
+				assert.NoError(err)
+			})
+
+			it("returns an error when the file has a different SHA256", func() {
+				err := webClient.Download(server.URL+"/file", filepath.Join(testDir, "some-file.txt"), internal.WithExpectedSHA256("some-other-sha"))
+				assert.EqualError(err, "downloaded file does not match expected SHA256: expected SHA256 'some-other-sha' but got '6e32ea34db1b3755d7dec972eb72c705338f0dd8e0be881d966963438fb2e800'")
+			})
+		})
+
 		when("the response is not a 200", func() {
 			it("returns an error", func() {
 				err := webClient.Download(server.URL+"/500", "")
@@ -133,4 +307,366 @@ func testWebClient(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+
+	when("WithHTTPCache", func() {
+		var cacheDir string
+
+		it.Before(func() {
+			cacheDir = filepath.Join(testDir, "http-cache")
+			webClient = internal.NewWebClient(internal.WithHTTPCache(internal.NewHTTPCache(cacheDir, 0)))
+		})
+
+		it("revalidates cached responses with their ETag", func() {
+			responseBody, err := webClient.Get(server.URL + "/etag")
+			require.NoError(err)
+			assert.Equal(fileContents, string(responseBody))
+
+			responseBody, err = webClient.Get(server.URL + "/etag")
+			require.NoError(err)
+			assert.Equal(fileContents, string(responseBody))
+
+			assert.Equal(2, requests["/etag"])
+		})
+
+		it("revalidates cached responses with their Last-Modified date", func() {
+			outputPath := filepath.Join(testDir, "some-file.txt")
+			require.NoError(webClient.Download(server.URL+"/last-modified", outputPath))
+			require.NoError(os.Remove(outputPath))
+
+			require.NoError(webClient.Download(server.URL+"/last-modified", outputPath))
+
+			contents, err := os.ReadFile(outputPath)
+			require.NoError(err)
+			assert.Equal(fileContents, string(contents))
+		})
+
+		it("keeps responses between web clients sharing a directory", func() {
+			_, err := webClient.Get(server.URL + "/etag")
+			require.NoError(err)
+
+			webClient = internal.NewWebClient(internal.WithHTTPCache(internal.NewHTTPCache(cacheDir, 0)))
+			responseBody, err := webClient.Get(server.URL + "/etag")
+			require.NoError(err)
+			assert.Equal(fileContents, string(responseBody))
+		})
+
+		it("does not cache responses without validators", func() {
+			_, err := webClient.Get(server.URL + "/file")
+			require.NoError(err)
+
+			entries, err := os.ReadDir(cacheDir)
+			if err == nil {
+				assert.Empty(entries)
+			}
+		})
+
+		it("does not cache unsuccessful responses", func() {
+			_, err := webClient.Get(server.URL + "/500")
+			assert.EqualError(err, "got unsuccessful response: status code: 500, body: some-server-error")
+		})
+
+		when("the url is immutable", func() {
+			it.Before(func() {
+				immutable := regexp.MustCompile("^" + regexp.QuoteMeta(server.URL) + "/etag$")
+				webClient = internal.NewWebClient(internal.WithHTTPCache(internal.NewHTTPCache(cacheDir, 0, immutable)))
+			})
+
+			it("serves the cached response without revalidating it", func() {
+				_, err := webClient.Get(server.URL + "/etag")
+				require.NoError(err)
+
+				responseBody, err := webClient.Get(server.URL + "/etag")
+				require.NoError(err)
+				assert.Equal(fileContents, string(responseBody))
+
+				assert.Equal(1, requests["/etag"])
+			})
+		})
+
+		when("the cache is full", func() {
+			it.Before(func() {
+				webClient = internal.NewWebClient(internal.WithHTTPCache(internal.NewHTTPCache(cacheDir, int64(len(fileContents)+len("other-contents")-1))))
+			})
+
+			it("evicts the least recently used response", func() {
+				_, err := webClient.Get(server.URL + "/etag")
+				require.NoError(err)
+
+				_, err = webClient.Get(server.URL + "/other-etag")
+				require.NoError(err)
+
+				bodies, err := filepath.Glob(filepath.Join(cacheDir, "*.body"))
+				require.NoError(err)
+				require.Len(bodies, 1)
+
+				contents, err := os.ReadFile(bodies[0])
+				require.NoError(err)
+				assert.Equal("other-contents", string(contents))
+			})
+		})
+	})
+
+	when("WithRetryPolicy", func() {
+		it.Before(func() {
+			webClient = internal.NewWebClient(internal.WithRetryPolicy(internal.RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     2 * time.Second,
+			}))
+		})
+
+		it("retries server errors until the request succeeds", func() {
+			responseBody, err := webClient.Get(server.URL + "/flaky")
+			require.NoError(err)
+
+			assert.Equal(fileContents, string(responseBody))
+			assert.Equal(3, requests["/flaky"])
+		})
+
+		it("does not retry other unsuccessful responses", func() {
+			_, err := webClient.Get(server.URL + "/404")
+			assert.EqualError(err, "got unsuccessful response: status code: 404, body: ")
+			assert.Equal(1, requests["/404"])
+		})
+
+		it("does not retry requests that are not idempotent", func() {
+			_, err := webClient.Post(server.URL+"/flaky", nil)
+			assert.EqualError(err, "got unsuccessful response: status code: 503, body: some-unavailable-error")
+			assert.Equal(1, requests["/flaky"])
+		})
+
+		it("reports the attempts made once it gives up", func() {
+			_, err := webClient.Get(server.URL + "/500")
+			assert.EqualError(err, "gave up after 3 attempts: got unsuccessful response: status code: 500, body: some-server-error")
+
+			var retryErr internal.RetryError
+			require.ErrorAs(err, &retryErr)
+			assert.Equal(3, retryErr.Attempts)
+			assert.Equal(3, requests["/500"])
+		})
+
+		when("the response has a Retry-After header", func() {
+			it("waits as long as it asks", func() {
+				start := time.Now()
+				responseBody, err := webClient.Get(server.URL + "/rate-limited?retry-after=1")
+				require.NoError(err)
+
+				assert.Equal(fileContents, string(responseBody))
+				assert.GreaterOrEqual(time.Since(start), time.Second)
+			})
+
+			it("gives up when it asks for longer than the maximum backoff", func() {
+				_, err := webClient.Get(server.URL + "/rate-limited?retry-after=120")
+				assert.EqualError(err, "got unsuccessful response: status code: 429, body: ")
+				assert.Equal(1, requests["/rate-limited"])
+			})
+		})
+
+		when("the host has its own retry policy", func() {
+			it.Before(func() {
+				serverURL, err := url.Parse(server.URL)
+				require.NoError(err)
+
+				webClient = internal.NewWebClient(
+					internal.WithRetryPolicy(internal.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+					internal.WithHostRetryPolicy(serverURL.Hostname(), internal.RetryPolicy{MaxAttempts: 1}),
+				)
+			})
+
+			it("uses it instead", func() {
+				_, err := webClient.Get(server.URL + "/flaky")
+				assert.EqualError(err, "got unsuccessful response: status code: 503, body: some-unavailable-error")
+				assert.Equal(1, requests["/flaky"])
+			})
+		})
+	})
+
+	when("WithURLPolicy", func() {
+		var serverURL *url.URL
+
+		it.Before(func() {
+			var err error
+			serverURL, err = url.Parse(server.URL)
+			require.NoError(err)
+		})
+
+		assertNotAllowed := func(err error, reason string) {
+			var urlNotAllowedErr depErrors.URLNotAllowedError
+			require.True(errors.As(err, &urlNotAllowedErr), "expected a URLNotAllowedError, got %v", err)
+			assert.Equal(reason, urlNotAllowedErr.Reason)
+		}
+
+		it("only allows https by default", func() {
+			webClient = internal.NewWebClient(internal.WithURLPolicy(internal.URLPolicy{}))
+
+			_, err := webClient.Get(server.URL + "/file")
+			assertNotAllowed(err, `scheme "http" is not allowed`)
+			assert.Equal(0, requests["/file"])
+		})
+
+		it("blocks hosts that are not allowed", func() {
+			webClient = internal.NewWebClient(internal.WithURLPolicy(internal.URLPolicy{
+				Schemes: []string{"http"},
+				Hosts:   []string{"*.example.com"},
+			}))
+
+			err := webClient.Download(server.URL+"/file", filepath.Join(testDir, "some-file"))
+			assertNotAllowed(err, fmt.Sprintf("host %q is not allowed", serverURL.Hostname()))
+			assert.Equal(0, requests["/file"])
+		})
+
+		it("blocks private addresses", func() {
+			webClient = internal.NewWebClient(internal.WithURLPolicy(internal.URLPolicy{
+				Schemes: []string{"http"},
+			}))
+
+			_, err := webClient.Get(server.URL + "/file")
+			assertNotAllowed(err, "address 127.0.0.1 is not public")
+			assert.Equal(0, requests["/file"])
+		})
+
+		it("blocks host names that resolve to private addresses", func() {
+			webClient = internal.NewWebClient(internal.WithURLPolicy(internal.URLPolicy{
+				Schemes: []string{"http"},
+				Hosts:   []string{"localhost"},
+			}))
+
+			_, err := webClient.Get(fmt.Sprintf("http://localhost:%s/file", serverURL.Port()))
+			var urlNotAllowedErr depErrors.URLNotAllowedError
+			assert.True(errors.As(err, &urlNotAllowedErr), "expected a URLNotAllowedError, got %v", err)
+			assert.Equal(0, requests["/file"])
+		})
+
+		when("private networks are allowed", func() {
+			it.Before(func() {
+				webClient = internal.NewWebClient(internal.WithURLPolicy(internal.URLPolicy{
+					Schemes:              []string{"http"},
+					Hosts:                []string{serverURL.Hostname()},
+					AllowPrivateNetworks: true,
+				}))
+			})
+
+			it("allows the request", func() {
+				body, err := webClient.Get(server.URL + "/file")
+				require.NoError(err)
+				assert.Equal(fileContents, string(body))
+			})
+
+			it("checks every redirect", func() {
+				_, err := webClient.Get(server.URL + "/redirect?to=" + url.QueryEscape(fmt.Sprintf("http://localhost:%s/file", serverURL.Port())))
+				assertNotAllowed(err, `host "localhost" is not allowed`)
+				assert.Equal(1, requests["/redirect"])
+				assert.Equal(0, requests["/file"])
+			})
+
+			it("follows allowed redirects", func() {
+				body, err := webClient.Get(server.URL + "/redirect?to=/file")
+				require.NoError(err)
+				assert.Equal(fileContents, string(body))
+			})
+
+			it("does not retry the request", func() {
+				webClient = internal.NewWebClient(
+					internal.WithRetryPolicy(internal.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+					internal.WithURLPolicy(internal.URLPolicy{
+						Schemes:              []string{"http"},
+						Hosts:                []string{serverURL.Hostname()},
+						AllowPrivateNetworks: true,
+						MaxRedirects:         -1,
+					}),
+				)
+
+				_, err := webClient.Get(server.URL + "/redirect?to=/file")
+				assertNotAllowed(err, "more than 0 redirects")
+				assert.Equal(1, requests["/redirect"])
+			})
+		})
+
+		when("a request has its own policy", func() {
+			it("uses it instead", func() {
+				webClient = internal.NewWebClient(internal.WithURLPolicy(internal.URLPolicy{}))
+
+				body, err := webClient.Get(server.URL+"/file", internal.WithRequestURLPolicy(internal.URLPolicy{
+					Schemes:              []string{"http"},
+					AllowPrivateNetworks: true,
+				}))
+				require.NoError(err)
+				assert.Equal(fileContents, string(body))
+			})
+		})
+	})
+
+	when("a proxy is configured in the environment", func() {
+		var (
+			proxy          *httptest.Server
+			proxiedTargets []string
+		)
+
+		it.Before(func() {
+			proxiedTargets = nil
+			proxy = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxiedTargets = append(proxiedTargets, r.URL.String())
+				_, _ = w.Write([]byte("some-proxied-contents"))
+			}))
+
+			t.Setenv("HTTP_PROXY", proxy.URL)
+			t.Setenv("NO_PROXY", "")
+		})
+
+		it.After(func() {
+			proxy.Close()
+		})
+
+		it("sends requests through the proxy", func() {
+			webClient = internal.NewWebClient()
+
+			body, err := webClient.Get("http://example.org/file")
+			require.NoError(err)
+			assert.Equal("some-proxied-contents", string(body))
+			assert.Equal([]string{"http://example.org/file"}, proxiedTargets)
+		})
+
+		it("connects to the proxy even when private networks are not allowed", func() {
+			webClient = internal.NewWebClient(internal.WithURLPolicy(internal.URLPolicy{
+				Schemes: []string{"http"},
+			}))
+
+			body, err := webClient.Get("http://example.org/file")
+			require.NoError(err)
+			assert.Equal("some-proxied-contents", string(body))
+		})
+	})
+
+	when("the response is unsuccessful", func() {
+		it("marks server errors as the upstream being unavailable", func() {
+			_, err := webClient.Get(server.URL + "/500")
+
+			var unavailableErr depErrors.UpstreamUnavailableError
+			require.True(errors.As(err, &unavailableErr), "expected an UpstreamUnavailableError, got %v", err)
+			assert.Equal(server.URL+"/500", unavailableErr.URL)
+			assert.EqualError(err, "got unsuccessful response: status code: 500, body: some-server-error")
+		})
+
+		it("marks rate limits", func() {
+			_, err := webClient.Get(server.URL + "/rate-limited?retry-after=30")
+
+			var rateLimitedErr depErrors.RateLimitedError
+			require.True(errors.As(err, &rateLimitedErr), "expected a RateLimitedError, got %v", err)
+			assert.Equal(30*time.Second, rateLimitedErr.RetryAfter)
+		})
+
+		it("returns an HTTPError with a redacted and truncated body", func() {
+			webClient = internal.NewWebClient(internal.WithSecrets("some-secret"))
+
+			_, err := webClient.Get(server.URL+"/echo-secret?token=some-token", internal.WithHeader("Authorization", "Bearer some-secret"))
+
+			var httpErr depErrors.HTTPError
+			require.True(errors.As(err, &httpErr), "expected an HTTPError, got %v", err)
+			assert.Equal(http.StatusUnauthorized, httpErr.StatusCode)
+			assert.Equal(server.URL+"/echo-secret?token=[REDACTED]", httpErr.URL)
+			assert.True(strings.HasPrefix(httpErr.Body, "bad credentials: Bearer [REDACTED] aaa"), httpErr.Body)
+			assert.True(strings.HasSuffix(httpErr.Body, "... (truncated)"), httpErr.Body)
+			assert.NotContains(err.Error(), "some-secret")
+		})
+	})
 }
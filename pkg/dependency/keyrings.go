@@ -0,0 +1,151 @@
+package dependency
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+)
+
+// Keyring is the set of PGP keys trusted to sign a dependency's artifacts,
+// pinned by fingerprint.
+type Keyring = internal.Keyring
+
+// KeySource is a URL an upstream publishes its keys at, and the fingerprints
+// of the keys published there that are trusted.
+type KeySource = internal.KeySource
+
+// WithKeyring replaces the keyring used to verify the signatures of the
+// named dependency's artifacts.
+func WithKeyring(name string, keyring Keyring) DepFactoryOption {
+	return func(d *DepFactory) {
+		keyrings := map[string]Keyring{}
+		for n, k := range d.keyrings {
+			keyrings[n] = k
+		}
+		keyrings[name] = keyring
+		d.keyrings = keyrings
+	}
+}
+
+// KeyringReport is the health of the keys trusted to sign a dependency's
+// artifacts.
+type KeyringReport = internal.KeyringReport
+
+// KeyStatus describes a key of a keyring.
+type KeyStatus = internal.KeyStatus
+
+// DefaultKeyExpiryWarning is how long before a key expires that it is
+// reported as expiring.
+const DefaultKeyExpiryWarning = internal.DefaultKeyExpiryWarning
+
+// CheckKeyrings reports the health of the keyring of every dependency with
+// signed artifacts at now, sorted by dependency name. The keys each
+// dependency publishes are fetched even when keys are committed for it, so
+// that revocations and new keys are reported before verification fails.
+// Keys expiring within warnWithin of now are reported as expiring.
+func (d DepFactory) CheckKeyrings(now time.Time, warnWithin time.Duration) []KeyringReport {
+	var reports []KeyringReport
+	for _, name := range d.KeyringDependencies() {
+		keyring := d.keyrings[name]
+		webClient := d.webClientFor(name)
+
+		uncommitted := keyring.UncommittedFingerprints()
+		keyring.Keys = append([]string{}, keyring.Keys...)
+
+		var errs []string
+		urls := append([]string{}, keyring.PendingKeyURLs...)
+		for _, source := range keyring.KeyURLs {
+			urls = append(urls, source.URL)
+		}
+
+		for _, url := range urls {
+			key, err := cachedGet(d.cache, webClient, url)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("could not get %s: %s", url, err))
+				continue
+			}
+			keyring.Keys = append(keyring.Keys, string(key))
+		}
+
+		report := internal.CheckKeyring(name, keyring, now, warnWithin)
+		report.UncommittedFingerprints = uncommitted
+		report.Errors = append(errs, report.Errors...)
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// KeyringDependencies returns the names of the dependencies with a keyring,
+// sorted by name.
+func (d DepFactory) KeyringDependencies() []string {
+	var names []string
+	for name := range d.keyrings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// PublishedKeys fetches the keys the named dependency publishes at the URLs
+// of its keyring, whether or not they are committed, and returns the armored
+// key of every pinned fingerprint, keyed by fingerprint. It is used to
+// refresh the committed keys, and fails if a pinned key is not published.
+// A keyring that only has pending key URLs has no keys to commit.
+func (d DepFactory) PublishedKeys(name string) (map[string]string, error) {
+	keyring, ok := d.keyrings[name]
+	if !ok {
+		return nil, fmt.Errorf("dependency %s has no keyring", name)
+	}
+
+	if !keyring.Pinned() && len(keyring.PendingKeyURLs) > 0 {
+		return map[string]string{}, nil
+	}
+
+	err := keyring.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	published := Keyring{Fingerprints: keyring.Fingerprints, KeyURLs: keyring.KeyURLs}
+	for _, source := range keyring.KeyURLs {
+		key, err := cachedGet(d.cache, d.webClientFor(name), source.URL)
+		if err != nil {
+			return nil, fmt.Errorf("could not get %s: %w", source.URL, err)
+		}
+		published.Keys = append(published.Keys, string(key))
+	}
+
+	missing := published.UncommittedFingerprints()
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("pinned pgp keys %s are not published", strings.Join(missing, ", "))
+	}
+
+	return published.PinnedKeys()
+}
+
+// fetchKeys returns keyring with the keys it publishes for the pinned
+// fingerprints whose keys are not committed. Keyrings that do not pin the
+// keys of every URL they are fetched from are refused, and fetched keys are
+// still only trusted if their fingerprint is pinned.
+func fetchKeys(cache Cache, webClient WebClient, keyring Keyring) (Keyring, error) {
+	err := keyring.Validate()
+	if err != nil {
+		return Keyring{}, err
+	}
+
+	keyring.Keys = append([]string{}, keyring.Keys...)
+	for _, source := range keyring.UncommittedSources() {
+		key, err := cachedGet(cache, webClient, source.URL)
+		if err != nil {
+			return Keyring{}, fmt.Errorf("could not get %s: %w", source.URL, err)
+		}
+		keyring.Keys = append(keyring.Keys, string(key))
+	}
+
+	return keyring, nil
+}
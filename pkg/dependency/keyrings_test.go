@@ -0,0 +1,188 @@
+package dependency_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	"golang.org/x/crypto/openpgp/packet"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+)
+
+func TestKeyrings(t *testing.T) {
+	spec.Run(t, "keyrings", testKeyrings, spec.Report(report.Terminal{}))
+}
+
+func testKeyrings(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert        = assert.New(t)
+		require       = require.New(t)
+		fakeWebClient *dependencyfakes.FakeWebClient
+	)
+
+	it.Before(func() {
+		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeWebClient.GetReturns([]byte("some-published-key"), nil)
+	})
+
+	when("CheckKeyrings", func() {
+		it("reports on the keyring of every dependency with signed artifacts", func() {
+			factory := dependency.NewCustomDependencyFactory(nil, nil, nil, fakeWebClient, nil, nil)
+
+			reports := factory.CheckKeyrings(time.Now(), dependency.DefaultKeyExpiryWarning)
+
+			var names []string
+			for _, report := range reports {
+				names = append(names, report.Dependency)
+			}
+			assert.Equal([]string{"composer", "curl", "httpd", "icu", "nginx", "rust", "yarn"}, names)
+		})
+
+		it("fetches the published keys even when keys are committed", func() {
+			fakeWebClient.GetReturnsOnCall(2, nil, errors.New("some-error"))
+
+			factory := dependency.NewCustomDependencyFactory(nil, nil, nil, fakeWebClient, nil, nil,
+				dependency.WithKeyring("curl", dependency.Keyring{
+					Keys: []string{"some-committed-key"},
+					KeyURLs: []dependency.KeySource{
+						{URL: "https://example.org/key.asc", Fingerprints: []string{"some-fingerprint"}},
+						{URL: "https://example.org/other-key.asc", Fingerprints: []string{"other-fingerprint"}},
+					},
+				}),
+			)
+
+			var report dependency.KeyringReport
+			for _, r := range factory.CheckKeyrings(time.Now(), dependency.DefaultKeyExpiryWarning) {
+				if r.Dependency == "curl" {
+					report = r
+				}
+			}
+
+			// composer is checked first and fetches its key in the first call
+			urlArg, _ := fakeWebClient.GetArgsForCall(1)
+			assert.Equal("https://example.org/key.asc", urlArg)
+
+			urlArg, _ = fakeWebClient.GetArgsForCall(2)
+			assert.Equal("https://example.org/other-key.asc", urlArg)
+
+			require.Len(report.Errors, 3)
+			assert.Equal("could not get https://example.org/other-key.asc: some-error", report.Errors[0])
+			assert.Contains(report.Errors[1], "could not read armored key ring")
+			assert.Contains(report.Errors[2], "could not read armored key ring")
+
+			assert.Empty(report.Keys)
+			assert.Equal([]string{"SOME-FINGERPRINT", "OTHER-FINGERPRINT"}, report.MissingFingerprints)
+			assert.Equal([]string{"SOME-FINGERPRINT", "OTHER-FINGERPRINT"}, report.UncommittedFingerprints)
+			assert.False(report.Healthy())
+		})
+
+		it("reports the keys published at pending key urls without trusting them", func() {
+			entity, err := openpgp.NewEntity("some-signer", "", "some-signer@example.com", &packet.Config{RSABits: 1024})
+			require.NoError(err)
+			fingerprint := fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint)
+
+			var buffer bytes.Buffer
+			writer, err := armor.Encode(&buffer, openpgp.PublicKeyType, nil)
+			require.NoError(err)
+			require.NoError(entity.Serialize(writer))
+			require.NoError(writer.Close())
+
+			fakeWebClient.GetStub = func(url string, _ ...internal.RequestOption) ([]byte, error) {
+				if url == "https://example.org/pending-key.asc" {
+					return buffer.Bytes(), nil
+				}
+				return []byte("some-published-key"), nil
+			}
+
+			factory := dependency.NewCustomDependencyFactory(nil, nil, nil, fakeWebClient, nil, nil,
+				dependency.WithKeyring("curl", dependency.Keyring{
+					PendingKeyURLs: []string{"https://example.org/pending-key.asc"},
+				}),
+			)
+
+			var report dependency.KeyringReport
+			for _, r := range factory.CheckKeyrings(time.Now(), dependency.DefaultKeyExpiryWarning) {
+				if r.Dependency == "curl" {
+					report = r
+				}
+			}
+
+			require.Len(report.Keys, 1)
+			assert.Equal(fingerprint, report.Keys[0].Fingerprint)
+			assert.False(report.Keys[0].Trusted)
+			assert.Contains(report.Warnings(), fmt.Sprintf("curl: pgp key %s is published but its fingerprint is not pinned", fingerprint))
+			assert.False(report.Healthy())
+		})
+	})
+
+	when("PublishedKeys", func() {
+		var fingerprint, key string
+
+		it.Before(func() {
+			entity, err := openpgp.NewEntity("some-signer", "", "some-signer@example.com", &packet.Config{RSABits: 1024})
+			require.NoError(err)
+			fingerprint = fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint)
+
+			var buffer bytes.Buffer
+			writer, err := armor.Encode(&buffer, openpgp.PublicKeyType, nil)
+			require.NoError(err)
+			require.NoError(entity.Serialize(writer))
+			require.NoError(writer.Close())
+			key = buffer.String()
+
+			fakeWebClient.GetReturns([]byte(key), nil)
+		})
+
+		it("returns the pinned keys the dependency publishes, keyed by fingerprint", func() {
+			factory := dependency.NewCustomDependencyFactory(nil, nil, nil, fakeWebClient, nil, nil,
+				dependency.WithKeyring("curl", dependency.Keyring{
+					KeyURLs: []dependency.KeySource{{URL: "https://example.org/key.asc", Fingerprints: []string{fingerprint}}},
+				}),
+			)
+
+			keys, err := factory.PublishedKeys("curl")
+			require.NoError(err)
+
+			require.Len(keys, 1)
+			assert.Contains(keys, fingerprint)
+			urlArg, _ := fakeWebClient.GetArgsForCall(0)
+			assert.Equal("https://example.org/key.asc", urlArg)
+		})
+
+		it("fails when a pinned key is not published", func() {
+			factory := dependency.NewCustomDependencyFactory(nil, nil, nil, fakeWebClient, nil, nil,
+				dependency.WithKeyring("curl", dependency.Keyring{
+					KeyURLs: []dependency.KeySource{{URL: "https://example.org/key.asc", Fingerprints: []string{fingerprint, "some-fingerprint"}}},
+				}),
+			)
+
+			_, err := factory.PublishedKeys("curl")
+			assert.EqualError(err, "pinned pgp keys SOME-FINGERPRINT are not published")
+		})
+
+		it("returns no keys for a keyring that only has pending key urls", func() {
+			factory := dependency.NewCustomDependencyFactory(nil, nil, nil, fakeWebClient, nil, nil,
+				dependency.WithKeyring("curl", dependency.Keyring{
+					PendingKeyURLs: []string{"https://example.org/pending-key.asc"},
+				}),
+			)
+
+			keys, err := factory.PublishedKeys("curl")
+			require.NoError(err)
+
+			assert.Empty(keys)
+			assert.Equal(0, fakeWebClient.GetCallCount())
+		})
+	})
+}
@@ -0,0 +1,60 @@
+package dependency
+
+import (
+	"fmt"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/licenses"
+)
+
+// LicenseOverride replaces the licenses detected for a range of versions of
+// a dependency with an SPDX license expression, and justifies it.
+type LicenseOverride = internal.LicenseOverride
+
+// LicenseOverrides is the registry consulted for every dependency version
+// after its licenses are detected.
+type LicenseOverrides = internal.LicenseOverrides
+
+// LoadLicenseOverrides reads a license overrides file in the format of the
+// embedded internal/license_overrides.yml.
+func LoadLicenseOverrides(path string) (LicenseOverrides, error) {
+	return internal.LoadLicenseOverrides(path)
+}
+
+// WithLicenseOverrides replaces the embedded license overrides.
+func WithLicenseOverrides(overrides LicenseOverrides) DepFactoryOption {
+	return func(d *DepFactory) {
+		d.licenseOverrides = overrides
+	}
+}
+
+// licenseOverridingDependency applies the license overrides of the named
+// dependency to the versions it gets.
+type licenseOverridingDependency struct {
+	Dependency
+	name      string
+	overrides LicenseOverrides
+}
+
+func (l licenseOverridingDependency) GetDependencyVersion(version string) (DepVersion, error) {
+	depVersion, err := l.Dependency.GetDependencyVersion(version)
+	if err != nil {
+		return DepVersion{}, err
+	}
+
+	override, ok := l.overrides.Lookup(l.name, depVersion.Version)
+	if !ok {
+		return depVersion, nil
+	}
+
+	ids, err := licenses.ExpressionLicenses(override.Expression)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not apply license override: %w", err)
+	}
+
+	depVersion.Licenses = ids
+	depVersion.LicenseExpression = override.Expression
+	depVersion.LicenseSources = licenseSources(ids, LicenseSourceOverride)
+
+	return depVersion, nil
+}
@@ -0,0 +1,88 @@
+package dependency_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+)
+
+func TestLicenseOverrides(t *testing.T) {
+	spec.Run(t, "LicenseOverrides", testLicenseOverrides, spec.Report(report.Terminal{}))
+}
+
+func testLicenseOverrides(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert               = assert.New(t)
+		require              = require.New(t)
+		fakeChecksummer      *dependencyfakes.FakeChecksummer
+		fakeGithubClient     *dependencyfakes.FakeGithubClient
+		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
+		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
+		factoryOptions       []dependency.DepFactoryOption
+	)
+
+	it.Before(func() {
+		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
+		fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha"}, nil)
+		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
+		fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{{TagName: "v1.0.0"}, {TagName: "v2.0.0"}}, nil)
+		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
+		fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-0"}, nil)
+		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
+
+		path := filepath.Join(t.TempDir(), "overrides.yml")
+		require.NoError(os.WriteFile(path, []byte(`
+version: 1
+overrides:
+- dependency: tini
+  versions: "< 2.0.0"
+  expression: MIT OR Apache-2.0
+  justification: some-justification
+`), 0600))
+
+		overrides, err := dependency.LoadLicenseOverrides(path)
+		require.NoError(err)
+		factoryOptions = []dependency.DepFactoryOption{dependency.WithLicenseOverrides(overrides)}
+	})
+
+	getDependencyVersion := func(version string) dependency.DepVersion {
+		tini, err := dependency.NewCustomDependencyFactory(fakeChecksummer, nil, fakeGithubClient, nil, fakeLicenseRetriever, fakePURLGenerator, factoryOptions...).NewDependency("tini")
+		require.NoError(err)
+
+		depVersion, err := tini.GetDependencyVersion(version)
+		require.NoError(err)
+
+		return depVersion
+	}
+
+	it("replaces the detected licenses of the versions it matches", func() {
+		depVersion := getDependencyVersion("v1.0.0")
+
+		assert.Equal([]string{"Apache-2.0", "MIT"}, depVersion.Licenses)
+		assert.Equal("MIT OR Apache-2.0", depVersion.LicenseExpression)
+		assert.Equal(map[string]dependency.LicenseSource{
+			"Apache-2.0": dependency.LicenseSourceOverride,
+			"MIT":        dependency.LicenseSourceOverride,
+		}, depVersion.LicenseSources)
+	})
+
+	it("keeps the detected licenses of the versions it does not match", func() {
+		depVersion := getDependencyVersion("v2.0.0")
+
+		assert.Equal([]string{"MIT", "MIT-0"}, depVersion.Licenses)
+		assert.Empty(depVersion.LicenseExpression)
+		assert.Equal(map[string]dependency.LicenseSource{
+			"MIT":   dependency.LicenseSourceFileScan,
+			"MIT-0": dependency.LicenseSourceFileScan,
+		}, depVersion.LicenseSources)
+	})
+}
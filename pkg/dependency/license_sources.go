@@ -0,0 +1,177 @@
+package dependency
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/licenses"
+)
+
+// LicenseSource is how a license of a dependency version was found.
+type LicenseSource string
+
+const (
+	LicenseSourcePyPI     LicenseSource = "pypi"
+	LicenseSourceRubyGems LicenseSource = "rubygems"
+	LicenseSourceNPM      LicenseSource = "npm"
+	LicenseSourceGitHub   LicenseSource = "github"
+	LicenseSourceFileScan LicenseSource = "file-scan"
+	LicenseSourceOverride LicenseSource = "override"
+)
+
+// licenseMetadata looks up the licenses a package registry declares for a
+// dependency version, as the registry names them.
+type licenseMetadata struct {
+	source LicenseSource
+	lookup func() ([]string, error)
+}
+
+// resolveLicenses returns the licenses of a dependency version, and the
+// source of each, from the first registry whose metadata declares licenses
+// that all normalize to SPDX IDs. When none does, it scans the artifact for
+// license files. Registry metadata is only a shortcut, so failing to look it
+// up falls back to scanning rather than failing the version.
+//
+// The SPDX license expression is only returned when a registry declares a
+// valid one, as a flat list of licenses does not say how they combine.
+func resolveLicenses(licenseRetriever LicenseRetriever, dependencyName, artifactPath string, metadata ...licenseMetadata) ([]string, string, map[string]LicenseSource, error) {
+	for _, m := range metadata {
+		declared, err := m.lookup()
+		if err != nil {
+			continue
+		}
+
+		ids, ok := licenses.NormalizeLicenses(declared)
+		if !ok {
+			continue
+		}
+
+		var expression string
+		if len(declared) == 1 && licenses.ValidateLicenseExpression(declared[0]) == nil {
+			expression = declared[0]
+		}
+
+		return ids, expression, licenseSources(ids, m.source), nil
+	}
+
+	ids, err := licenseRetriever.LookupLicenses(dependencyName, artifactPath)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return ids, "", licenseSources(ids, LicenseSourceFileScan), nil
+}
+
+func licenseSources(ids []string, source LicenseSource) map[string]LicenseSource {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	sources := map[string]LicenseSource{}
+	for _, id := range ids {
+		sources[id] = source
+	}
+	return sources
+}
+
+// declaredLicenses is license metadata that was already fetched along with
+// the rest of a release.
+func declaredLicenses(source LicenseSource, declared []string) licenseMetadata {
+	return licenseMetadata{
+		source: source,
+		lookup: func() ([]string, error) { return declared, nil },
+	}
+}
+
+// pypiLicenses reads the license of a version from its PyPI metadata:
+// its license expression, its license classifiers, or its license field, in
+// that order, as the license field often holds the whole license text.
+func pypiLicenses(cache Cache, webClient WebClient, project, version string) licenseMetadata {
+	return licenseMetadata{
+		source: LicenseSourcePyPI,
+		lookup: func() ([]string, error) {
+			body, err := cachedGet(cache, webClient, fmt.Sprintf("https://pypi.org/pypi/%s/%s/json", project, version))
+			if err != nil {
+				return nil, fmt.Errorf("could not get version metadata: %w", err)
+			}
+
+			var versionMetadata struct {
+				Info struct {
+					License           string   `json:"license"`
+					LicenseExpression string   `json:"license_expression"`
+					Classifiers       []string `json:"classifiers"`
+				} `json:"info"`
+			}
+			err = json.Unmarshal(body, &versionMetadata)
+			if err != nil {
+				return nil, fmt.Errorf("could not unmarshal version metadata: %w", depErrors.ParseError{Err: err})
+			}
+
+			if versionMetadata.Info.LicenseExpression != "" {
+				return []string{versionMetadata.Info.LicenseExpression}, nil
+			}
+
+			var classifiers []string
+			for _, classifier := range versionMetadata.Info.Classifiers {
+				// "License :: OSI Approved" on its own names no license
+				if strings.HasPrefix(classifier, "License :: ") && classifier != "License :: OSI Approved" {
+					classifiers = append(classifiers, classifier)
+				}
+			}
+			if len(classifiers) > 0 {
+				return classifiers, nil
+			}
+
+			if versionMetadata.Info.License != "" {
+				return []string{versionMetadata.Info.License}, nil
+			}
+
+			return nil, nil
+		},
+	}
+}
+
+// npmLicenses reads the license of a version from the npm registry.
+func npmLicenses(cache Cache, webClient WebClient, pkg, version string) licenseMetadata {
+	return licenseMetadata{
+		source: LicenseSourceNPM,
+		lookup: func() ([]string, error) {
+			body, err := cachedGet(cache, webClient, fmt.Sprintf("https://registry.npmjs.org/%s/%s", pkg, version))
+			if err != nil {
+				return nil, fmt.Errorf("could not get version metadata: %w", err)
+			}
+
+			var versionMetadata struct {
+				License string `json:"license"`
+			}
+			err = json.Unmarshal(body, &versionMetadata)
+			if err != nil {
+				return nil, fmt.Errorf("could not unmarshal version metadata: %w", depErrors.ParseError{Err: err})
+			}
+
+			if versionMetadata.License == "" {
+				return nil, nil
+			}
+
+			return []string{versionMetadata.License}, nil
+		},
+	}
+}
+
+// githubLicenses reads the license GitHub detects for a repository at the
+// tag of a version.
+func githubLicenses(githubClient GithubClient, org, repo, ref string) licenseMetadata {
+	return licenseMetadata{
+		source: LicenseSourceGitHub,
+		lookup: func() ([]string, error) {
+			license, err := githubClient.GetLicense(org, repo, ref)
+			if err != nil || license == "" {
+				return nil, err
+			}
+
+			return []string{license}, nil
+		},
+	}
+}
@@ -3,9 +3,6 @@ package licenses_test
 import (
 	"archive/tar"
 	"bytes"
-	"fmt"
-	"net/http"
-	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -20,13 +17,17 @@ func testBundlerLicenseCase(t *testing.T, context spec.G, it spec.S) {
 	var (
 		Expect = NewWithT(t).Expect
 
-		mockServer       *httptest.Server
+		artifactDir      string
 		licenseRetriever licenses.LicenseRetriever
 	)
 
 	it.Before(func() {
 		var err error
 		licenseRetriever = licenses.NewLicenseRetriever()
+
+		artifactDir, err = os.MkdirTemp("", "artifacts")
+		Expect(err).NotTo(HaveOccurred())
+
 		// Set up tar files
 		buffer := bytes.NewBuffer(nil)
 		tw := tar.NewWriter(buffer)
@@ -50,76 +51,48 @@ func testBundlerLicenseCase(t *testing.T, context spec.G, it spec.S) {
 
 		Expect(outerTw.Close()).To(Succeed())
 
-		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			if req.Method == http.MethodHead {
-				http.Error(w, "NotFound", http.StatusNotFound)
-
-				return
-			}
-
-			switch req.URL.Path {
-			case "/":
-				w.WriteHeader(http.StatusOK)
-
-			case "/bundler-source-url.gem":
-				w.WriteHeader(http.StatusOK)
-				fmt.Fprint(w, outerBuffer.String())
-
-			case "/non-tar-file-outer-artifact":
-				w.WriteHeader(http.StatusOK)
-				// return a flac header, which is an unrecognized mime-type
-				fmt.Fprint(w, bytes.NewBuffer([]byte("\x66\x4C\x61\x43\x00\x00\x00\x22")))
-
-			case "/non-tar-file-inner-artifact":
-				outerBuffer := bytes.NewBuffer(nil)
-				outerTw := tar.NewWriter(outerBuffer)
-
-				flacHeader := []byte("\x66\x4C\x61\x43\x00\x00\x00\x22")
+		Expect(os.WriteFile(filepath.Join(artifactDir, "bundler-source.gem"), outerBuffer.Bytes(), 0644)).To(Succeed())
 
-				Expect(outerTw.WriteHeader(&tar.Header{Name: "data.tar.gz", Mode: 0755, Size: int64(len(flacHeader))})).To(Succeed())
-				_, err = outerTw.Write(flacHeader)
-				Expect(err).NotTo(HaveOccurred())
+		// return a flac header, which is an unrecognized mime-type
+		flacHeader := []byte("\x66\x4C\x61\x43\x00\x00\x00\x22")
+		Expect(os.WriteFile(filepath.Join(artifactDir, "non-tar-file-outer-artifact"), flacHeader, 0644)).To(Succeed())
 
-				Expect(outerTw.Close()).To(Succeed())
+		outerBuffer = bytes.NewBuffer(nil)
+		outerTw = tar.NewWriter(outerBuffer)
 
-				w.WriteHeader(http.StatusOK)
-				fmt.Fprint(w, outerBuffer.String())
-
-			case "/no-license.tgz":
-				// Set up tar files
-				buffer := bytes.NewBuffer(nil)
-				tw := tar.NewWriter(buffer)
+		Expect(outerTw.WriteHeader(&tar.Header{Name: "data.tar.gz", Mode: 0755, Size: int64(len(flacHeader))})).To(Succeed())
+		_, err = outerTw.Write(flacHeader)
+		Expect(err).NotTo(HaveOccurred())
 
-				Expect(tw.WriteHeader(&tar.Header{Name: "./", Mode: 0755, Typeflag: tar.TypeDir})).To(Succeed())
-				_, err = tw.Write(nil)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(tw.Close()).To(Succeed())
+		Expect(outerTw.Close()).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(artifactDir, "non-tar-file-inner-artifact"), outerBuffer.Bytes(), 0644)).To(Succeed())
 
-				outerBuffer := bytes.NewBuffer(nil)
-				outerTw := tar.NewWriter(outerBuffer)
+		buffer = bytes.NewBuffer(nil)
+		tw = tar.NewWriter(buffer)
 
-				Expect(outerTw.WriteHeader(&tar.Header{Name: "data.tar.gz", Mode: 0755, Size: int64(buffer.Len())})).To(Succeed())
-				_, err = outerTw.Write(buffer.Bytes())
-				Expect(err).NotTo(HaveOccurred())
+		Expect(tw.WriteHeader(&tar.Header{Name: "./", Mode: 0755, Typeflag: tar.TypeDir})).To(Succeed())
+		_, err = tw.Write(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tw.Close()).To(Succeed())
 
-				Expect(outerTw.Close()).To(Succeed())
+		outerBuffer = bytes.NewBuffer(nil)
+		outerTw = tar.NewWriter(outerBuffer)
 
-				w.WriteHeader(http.StatusOK)
-				fmt.Fprint(w, outerBuffer.String())
+		Expect(outerTw.WriteHeader(&tar.Header{Name: "data.tar.gz", Mode: 0755, Size: int64(buffer.Len())})).To(Succeed())
+		_, err = outerTw.Write(buffer.Bytes())
+		Expect(err).NotTo(HaveOccurred())
 
-			default:
-				t.Fatal(fmt.Sprintf("unknown path: %s", req.URL.Path))
-			}
-		}))
+		Expect(outerTw.Close()).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(artifactDir, "no-license.gem"), outerBuffer.Bytes(), 0644)).To(Succeed())
 	})
 
 	it.After(func() {
-		mockServer.Close()
+		Expect(os.RemoveAll(artifactDir)).To(Succeed())
 	})
 
-	context("given a bundler dependency URL to get the license for", func() {
-		it("gets the artifact and retrieves the license from it", func() {
-			licenses, err := licenseRetriever.LookupLicenses("bundler", fmt.Sprintf("%s/bundler-source-url.gem", mockServer.URL))
+	context("given a bundler dependency artifact to get the license for", func() {
+		it("retrieves the license from it", func() {
+			licenses, err := licenseRetriever.LookupLicenses("bundler", filepath.Join(artifactDir, "bundler-source.gem"))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
 		})
@@ -127,7 +100,7 @@ func testBundlerLicenseCase(t *testing.T, context spec.G, it spec.S) {
 
 	context("the artifact does not contain a license", func() {
 		it("returns an empty slice of licenses and no error", func() {
-			licenses, err := licenseRetriever.LookupLicenses("bundler", fmt.Sprintf("%s/no-license.tgz", mockServer.URL))
+			licenses, err := licenseRetriever.LookupLicenses("bundler", filepath.Join(artifactDir, "no-license.gem"))
 			Expect(err).ToNot(HaveOccurred())
 			Expect(licenses).To(Equal([]string{}))
 		})
@@ -136,7 +109,7 @@ func testBundlerLicenseCase(t *testing.T, context spec.G, it spec.S) {
 	context("failure cases", func() {
 		context("the outer artifact cannot be decompressed", func() {
 			it("returns an error", func() {
-				_, err := licenseRetriever.LookupLicenses("bundler", fmt.Sprintf("%s/non-tar-file-outer-artifact", mockServer.URL))
+				_, err := licenseRetriever.LookupLicenses("bundler", filepath.Join(artifactDir, "non-tar-file-outer-artifact"))
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(ContainSubstring("failed to decompress source file")))
 			})
@@ -144,7 +117,7 @@ func testBundlerLicenseCase(t *testing.T, context spec.G, it spec.S) {
 
 		context("the inner artifact cannot be decompressed", func() {
 			it("returns an error", func() {
-				_, err := licenseRetriever.LookupLicenses("bundler", fmt.Sprintf("%s/non-tar-file-inner-artifact", mockServer.URL))
+				_, err := licenseRetriever.LookupLicenses("bundler", filepath.Join(artifactDir, "non-tar-file-inner-artifact"))
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(ContainSubstring("failed to decompress inner source file")))
 			})
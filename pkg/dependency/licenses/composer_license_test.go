@@ -0,0 +1,178 @@
+package licenses_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/licenses"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testComposerLicenseCase(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		artifactDir      string
+		licenseRetriever licenses.LicenseRetriever
+		licenseContent   []byte
+	)
+
+	// writePhar writes a phar with a composer-like stub holding files, which
+	// are deflated when compress is set.
+	writePhar := func(path string, compress bool, files ...[2]string) {
+		var manifest, data bytes.Buffer
+		writeString := func(s string) {
+			Expect(binary.Write(&manifest, binary.LittleEndian, uint32(len(s)))).To(Succeed())
+			manifest.WriteString(s)
+		}
+
+		writeString("composer.phar")
+		writeString("")
+		for _, file := range files {
+			content := []byte(file[1])
+			flags := uint32(0)
+			if compress {
+				var compressed bytes.Buffer
+				writer, err := flate.NewWriter(&compressed, flate.DefaultCompression)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = writer.Write(content)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(writer.Close()).To(Succeed())
+
+				content = compressed.Bytes()
+				flags = 0x1000
+			}
+
+			writeString(file[0])
+			Expect(binary.Write(&manifest, binary.LittleEndian, []uint32{uint32(len(file[1])), 0, uint32(len(content)), 0, flags})).To(Succeed())
+			writeString("")
+			data.Write(content)
+		}
+
+		var phar bytes.Buffer
+		phar.WriteString("#!/usr/bin/env php\n<?php\nPhar::mapPhar('composer.phar');\n__HALT_COMPILER(); ?>\r\n")
+		header := manifest.Bytes()
+		Expect(binary.Write(&phar, binary.LittleEndian, uint32(len(header)+10))).To(Succeed())
+		Expect(binary.Write(&phar, binary.LittleEndian, uint32(len(files)))).To(Succeed())
+		Expect(binary.Write(&phar, binary.LittleEndian, uint16(0x1100))).To(Succeed())
+		Expect(binary.Write(&phar, binary.LittleEndian, uint32(0x10000))).To(Succeed())
+		phar.Write(header)
+		phar.Write(data.Bytes())
+		phar.WriteString("some-signature")
+
+		Expect(os.WriteFile(path, phar.Bytes(), 0644)).To(Succeed())
+	}
+
+	it.Before(func() {
+		var err error
+		licenseRetriever = licenses.NewLicenseRetriever()
+
+		artifactDir = t.TempDir()
+
+		licenseContent, err = os.ReadFile(filepath.Join("testdata", "LICENSE"))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	context("given a composer.phar with a LICENSE", func() {
+		it("detects the license of the LICENSE", func() {
+			path := filepath.Join(artifactDir, "composer.phar")
+			writePhar(path, false,
+				[2]string{"src/Composer/Composer.php", "<?php"},
+				[2]string{"LICENSE", string(licenseContent)},
+				[2]string{"vendor/some/LICENSE", "Apache License"},
+			)
+
+			licenses, err := licenseRetriever.LookupLicenses("composer", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
+		})
+
+		it("detects the license of a deflated LICENSE", func() {
+			path := filepath.Join(artifactDir, "composer.phar")
+			writePhar(path, true, [2]string{"LICENSE", string(licenseContent)})
+
+			licenses, err := licenseRetriever.LookupLicenses("composer", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
+		})
+	})
+
+	context("given a composer.phar with only a composer.json", func() {
+		it("returns the licenses declared by the composer.json", func() {
+			path := filepath.Join(artifactDir, "composer.phar")
+			writePhar(path, false, [2]string{"composer.json", `{"name": "composer/composer", "license": "MIT"}`})
+
+			licenses, err := licenseRetriever.LookupLicenses("composer", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"MIT"}))
+
+			writePhar(path, false, [2]string{"composer.json", `{"license": ["MIT", "BSD-3-Clause"]}`})
+
+			licenses, err = licenseRetriever.LookupLicenses("composer", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"BSD-3-Clause", "MIT"}))
+		})
+
+		it("reports the composer.json as the file that declared them", func() {
+			path := filepath.Join(artifactDir, "composer.phar")
+			writePhar(path, false, [2]string{"composer.json", `{"license": "MIT"}`})
+
+			report, err := licenseRetriever.LookupLicenseReport("composer", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Matches).To(Equal([]licenses.LicenseMatch{
+				{License: "MIT", File: "composer.json", Confidence: 1},
+			}))
+		})
+	})
+
+	context("given a composer.phar without license files", func() {
+		it("returns no licenses", func() {
+			path := filepath.Join(artifactDir, "composer.phar")
+			writePhar(path, false, [2]string{"bin/composer", "<?php"})
+
+			licenses, err := licenseRetriever.LookupLicenses("composer", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{}))
+		})
+	})
+
+	context("given a truncated composer.phar", func() {
+		it("returns an error", func() {
+			path := filepath.Join(artifactDir, "composer.phar")
+			Expect(os.WriteFile(path, []byte("#!/usr/bin/env php\n<?php\n__HALT_COMPILER(); ?>\r\n\x01"), 0644)).To(Succeed())
+
+			_, err := licenseRetriever.LookupLicenses("composer", path)
+			Expect(err).To(MatchError(ContainSubstring("failed to read phar manifest")))
+		})
+	})
+
+	context("given the composer source tarball", func() {
+		it("detects the license of the LICENSE", func() {
+			buffer := bytes.NewBuffer(nil)
+			gw := gzip.NewWriter(buffer)
+			tw := tar.NewWriter(gw)
+
+			Expect(tw.WriteHeader(&tar.Header{Name: "composer-2.0.0/LICENSE", Mode: 0644, Size: int64(len(licenseContent))})).To(Succeed())
+			_, err := tw.Write(licenseContent)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tw.Close()).To(Succeed())
+			Expect(gw.Close()).To(Succeed())
+
+			path := filepath.Join(artifactDir, "composer-2.0.0.tar.gz")
+			Expect(os.WriteFile(path, buffer.Bytes(), 0644)).To(Succeed())
+
+			licenses, err := licenseRetriever.LookupLicenses("composer", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
+		})
+	})
+}
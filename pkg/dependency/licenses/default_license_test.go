@@ -3,9 +3,7 @@ package licenses_test
 import (
 	"archive/tar"
 	"bytes"
-	"fmt"
-	"net/http"
-	"net/http/httptest"
+	"compress/gzip"
 	"os"
 	"path/filepath"
 	"testing"
@@ -20,7 +18,7 @@ func testDefaultLicenseCase(t *testing.T, context spec.G, it spec.S) {
 	var (
 		Expect = NewWithT(t).Expect
 
-		mockServer       *httptest.Server
+		artifactDir      string
 		licenseRetriever licenses.LicenseRetriever
 	)
 
@@ -28,6 +26,9 @@ func testDefaultLicenseCase(t *testing.T, context spec.G, it spec.S) {
 		var err error
 		licenseRetriever = licenses.NewLicenseRetriever()
 
+		artifactDir, err = os.MkdirTemp("", "artifacts")
+		Expect(err).NotTo(HaveOccurred())
+
 		// Set up tar files
 		buffer := bytes.NewBuffer(nil)
 		tw := tar.NewWriter(buffer)
@@ -45,127 +46,156 @@ func testDefaultLicenseCase(t *testing.T, context spec.G, it spec.S) {
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(tw.Close()).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(artifactDir, "default-dependency-source.tgz"), buffer.Bytes(), 0644)).To(Succeed())
 
-		mockServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			if req.Method == http.MethodHead {
-				http.Error(w, "NotFound", http.StatusNotFound)
-
-				return
-			}
-
-			switch req.URL.Path {
-			case "/":
-				w.WriteHeader(http.StatusOK)
-
-			case "/default-dependency-source-url.tgz":
-				w.WriteHeader(http.StatusOK)
-				fmt.Fprint(w, buffer.String())
-
-			case "/dotnet-source-url.tgz":
-				buffer := bytes.NewBuffer(nil)
-				tw := tar.NewWriter(buffer)
-
-				licenseContent, err := os.ReadFile(filepath.Join("testdata", "LICENSE.md"))
-				Expect(err).NotTo(HaveOccurred())
-
-				Expect(tw.WriteHeader(&tar.Header{Name: "LICENSE.md", Mode: 0755, Size: int64(len(licenseContent))})).To(Succeed())
-				_, err = tw.Write(licenseContent)
-				Expect(err).NotTo(HaveOccurred())
-
-				Expect(tw.Close()).To(Succeed())
-				w.WriteHeader(http.StatusOK)
-				fmt.Fprint(w, buffer.String())
+		buffer = bytes.NewBuffer(nil)
+		tw = tar.NewWriter(buffer)
 
-			case "/bad-url":
-				w.WriteHeader(http.StatusBadRequest)
+		licenseContent, err = os.ReadFile(filepath.Join("testdata", "LICENSE.md"))
+		Expect(err).NotTo(HaveOccurred())
 
-			case "/non-tar-file-artifact":
-				w.WriteHeader(http.StatusOK)
-				// return a flac header, which is an unrecognized mime-type
-				fmt.Fprint(w, bytes.NewBuffer([]byte("\x66\x4C\x61\x43\x00\x00\x00\x22")))
+		Expect(tw.WriteHeader(&tar.Header{Name: "LICENSE.md", Mode: 0755, Size: int64(len(licenseContent))})).To(Succeed())
+		_, err = tw.Write(licenseContent)
+		Expect(err).NotTo(HaveOccurred())
 
-			case "/no-license.tgz":
-				buffer = bytes.NewBuffer(nil)
-				tw = tar.NewWriter(buffer)
+		Expect(tw.Close()).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(artifactDir, "dotnet-source.tgz"), buffer.Bytes(), 0644)).To(Succeed())
 
-				Expect(tw.WriteHeader(&tar.Header{Name: "./", Mode: 0755, Typeflag: tar.TypeDir})).To(Succeed())
-				_, err = tw.Write(nil)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(tw.Close()).To(Succeed())
+		// return a flac header, which is an unrecognized mime-type
+		Expect(os.WriteFile(filepath.Join(artifactDir, "non-tar-file-artifact"), []byte("\x66\x4C\x61\x43\x00\x00\x00\x22"), 0644)).To(Succeed())
 
-				w.WriteHeader(http.StatusOK)
-				fmt.Fprint(w, buffer.String())
+		buffer = bytes.NewBuffer(nil)
+		tw = tar.NewWriter(buffer)
 
-			default:
-				t.Fatal(fmt.Sprintf("unknown path: %s", req.URL.Path))
-			}
-		}))
+		Expect(tw.WriteHeader(&tar.Header{Name: "./", Mode: 0755, Typeflag: tar.TypeDir})).To(Succeed())
+		_, err = tw.Write(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tw.Close()).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(artifactDir, "no-license.tgz"), buffer.Bytes(), 0644)).To(Succeed())
 	})
 
 	it.After(func() {
-		mockServer.Close()
+		Expect(os.RemoveAll(artifactDir)).To(Succeed())
 	})
 
-	context("given a dependency URL to get the license for", func() {
-		it("gets the artifact and retrieves the license from it", func() {
-			licenses, err := licenseRetriever.LookupLicenses("dependency", fmt.Sprintf("%s/default-dependency-source-url.tgz", mockServer.URL))
+	context("given a dependency artifact to get the license for", func() {
+		it("retrieves the license from it", func() {
+			licenses, err := licenseRetriever.LookupLicenses("dependency", filepath.Join(artifactDir, "default-dependency-source.tgz"))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
 		})
 	})
 
-	context("given a dotnet-runtime dependency URL to get the license for", func() {
-		it("gets the artifact and retrieves the license from it", func() {
-			licenses, err := licenseRetriever.LookupLicenses("dotnet-runtime", fmt.Sprintf("%s/dotnet-source-url.tgz", mockServer.URL))
+	context("given a dotnet-runtime dependency artifact to get the license for", func() {
+		it("retrieves the license from it", func() {
+			licenses, err := licenseRetriever.LookupLicenses("dotnet-runtime", filepath.Join(artifactDir, "dotnet-source.tgz"))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
 		})
 	})
 
-	context("given a dotnet-aspnetcore dependency URL to get the license for", func() {
-		it("gets the artifact and retrieves the license from it", func() {
-			licenses, err := licenseRetriever.LookupLicenses("dotnet-aspnetcore", fmt.Sprintf("%s/dotnet-source-url.tgz", mockServer.URL))
+	context("given a dotnet-aspnetcore dependency artifact to get the license for", func() {
+		it("retrieves the license from it", func() {
+			licenses, err := licenseRetriever.LookupLicenses("dotnet-aspnetcore", filepath.Join(artifactDir, "dotnet-source.tgz"))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
 		})
 	})
 
-	context("given a dotnet-sdk dependency URL to get the license for", func() {
-		it("gets the artifact and retrieves the license from it", func() {
-			licenses, err := licenseRetriever.LookupLicenses("dotnet-sdk", fmt.Sprintf("%s/dotnet-source-url.tgz", mockServer.URL))
+	context("given a dotnet-sdk dependency artifact to get the license for", func() {
+		it("retrieves the license from it", func() {
+			licenses, err := licenseRetriever.LookupLicenses("dotnet-sdk", filepath.Join(artifactDir, "dotnet-source.tgz"))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
 		})
 	})
 
+	context("LookupLicenseReport", func() {
+		it("reports the files that matched each license and their confidence", func() {
+			report, err := licenseRetriever.LookupLicenseReport("dependency", filepath.Join(artifactDir, "default-dependency-source.tgz"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Matches).To(HaveLen(2))
+
+			Expect(report.Matches[0].License).To(Equal("MIT"))
+			Expect(report.Matches[0].File).To(Equal("LICENSE"))
+			Expect(report.Matches[0].Confidence).To(BeNumerically("~", 0.94, 0.01))
+
+			Expect(report.Matches[1].License).To(Equal("MIT-0"))
+			Expect(report.Matches[1].File).To(Equal("LICENSE"))
+			Expect(report.Matches[1].Confidence).To(BeNumerically("~", 0.82, 0.01))
+
+			Expect(report.Licenses()).To(Equal([]string{"MIT", "MIT-0"}))
+		})
+
+		context("given a confidence threshold", func() {
+			it.Before(func() {
+				licenseRetriever = licenses.NewLicenseRetriever(licenses.WithConfidenceThreshold(0.9))
+			})
+
+			it("excludes the matches below it", func() {
+				report, err := licenseRetriever.LookupLicenseReport("dependency", filepath.Join(artifactDir, "default-dependency-source.tgz"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.Licenses()).To(Equal([]string{"MIT"}))
+
+				licenses, err := licenseRetriever.LookupLicenses("dependency", filepath.Join(artifactDir, "default-dependency-source.tgz"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(licenses).To(Equal([]string{"MIT"}))
+			})
+		})
+
+		context("the artifact does not contain a license", func() {
+			it("returns an empty report", func() {
+				report, err := licenseRetriever.LookupLicenseReport("dependency", filepath.Join(artifactDir, "no-license.tgz"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.Matches).To(BeEmpty())
+			})
+		})
+	})
+
 	context("the artifact does not contain a license", func() {
 		it("returns an empty slice of licenses and no error", func() {
-			licenses, err := licenseRetriever.LookupLicenses("dependency", fmt.Sprintf("%s/no-license.tgz", mockServer.URL))
+			licenses, err := licenseRetriever.LookupLicenses("dependency", filepath.Join(artifactDir, "no-license.tgz"))
 			Expect(err).ToNot(HaveOccurred())
 			Expect(licenses).To(Equal([]string{}))
 		})
 	})
 
 	context("failure cases", func() {
-		context("the request to the source URL fails", func() {
+		context("the artifact cannot be opened", func() {
 			it("returns an error and exits non-zero", func() {
-				_, err := licenseRetriever.LookupLicenses("dependency", "non-existent/url")
+				_, err := licenseRetriever.LookupLicenses("dependency", filepath.Join(artifactDir, "non-existent.tgz"))
 				Expect(err).To(HaveOccurred())
-				Expect(err).To(MatchError(ContainSubstring(`failed to query url: Get "non-existent/url"`)))
+				Expect(err).To(MatchError(ContainSubstring("failed to open artifact")))
 			})
 		})
 
-		context("the status code of the response is not OK", func() {
+		context("the license files of the artifact extract to more than the maximum size", func() {
+			it.Before(func() {
+				buffer := bytes.NewBuffer(nil)
+				gw := gzip.NewWriter(buffer)
+				tw := tar.NewWriter(gw)
+
+				content := make([]byte, 1024*1024)
+				Expect(tw.WriteHeader(&tar.Header{Name: "some-dir/LICENSE", Mode: 0644, Size: int64(len(content))})).To(Succeed())
+				_, err := tw.Write(content)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(tw.Close()).To(Succeed())
+				Expect(gw.Close()).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(artifactDir, "large-artifact.tgz"), buffer.Bytes(), 0644)).To(Succeed())
+
+				licenseRetriever = licenses.NewLicenseRetriever(licenses.WithMaxExtractedSize(64 * 1024))
+			})
+
 			it("returns an error and exits non-zero", func() {
-				_, err := licenseRetriever.LookupLicenses("dependency", fmt.Sprintf("%s/bad-url", mockServer.URL))
-				Expect(err).To(HaveOccurred())
-				Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf(`failed to query url %s/bad-url with: status code 400`, mockServer.URL))))
+				_, err := licenseRetriever.LookupLicenses("dependency", filepath.Join(artifactDir, "large-artifact.tgz"))
+				Expect(err).To(MatchError(ContainSubstring("artifact extracts to more than 65536 bytes")))
 			})
 		})
 
 		context("the artifact cannot be decompressed", func() {
 			it("returns an error and exits non-zero", func() {
-				_, err := licenseRetriever.LookupLicenses("dependency", fmt.Sprintf("%s/non-tar-file-artifact", mockServer.URL))
+				_, err := licenseRetriever.LookupLicenses("dependency", filepath.Join(artifactDir, "non-tar-file-artifact"))
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError(ContainSubstring("failed to decompress source file")))
 			})
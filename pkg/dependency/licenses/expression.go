@@ -0,0 +1,134 @@
+package licenses
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// licenseRefPattern matches the user-defined license references an SPDX
+// expression may use in place of licenses that are not on the SPDX list.
+var licenseRefPattern = regexp.MustCompile(`^(DocumentRef-[A-Za-z0-9.-]+:)?LicenseRef-[A-Za-z0-9.-]+$`)
+
+// ValidateLicenseExpression checks that expression is a well-formed SPDX
+// license expression whose licenses are all on the SPDX license list or are
+// LicenseRefs, and whose exceptions are all on the SPDX exceptions list.
+func ValidateLicenseExpression(expression string) error {
+	_, err := ExpressionLicenses(expression)
+	return err
+}
+
+// ExpressionLicenses returns the sorted licenses an SPDX license expression
+// names, without their "+" suffix or WITH exceptions.
+func ExpressionLicenses(expression string) ([]string, error) {
+	p := expressionParser{tokens: tokenizeExpression(expression), licenses: map[string]struct{}{}}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty license expression")
+	}
+
+	err := p.parseExpression()
+	if err != nil {
+		return nil, fmt.Errorf("invalid license expression '%s': %w", expression, err)
+	}
+	if p.position < len(p.tokens) {
+		return nil, fmt.Errorf("invalid license expression '%s': unexpected '%s'", expression, p.tokens[p.position])
+	}
+
+	var licenses []string
+	for license := range p.licenses {
+		licenses = append(licenses, license)
+	}
+	sort.Strings(licenses)
+
+	return licenses, nil
+}
+
+func tokenizeExpression(expression string) []string {
+	return strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expression))
+}
+
+// expressionParser is a recursive descent parser of the SPDX license
+// expression grammar:
+//
+//	expression = term *( ("AND" / "OR") term )
+//	term       = "(" expression ")" / license [ "WITH" exception ]
+type expressionParser struct {
+	tokens   []string
+	position int
+	licenses map[string]struct{}
+}
+
+func (p *expressionParser) next() (string, bool) {
+	if p.position >= len(p.tokens) {
+		return "", false
+	}
+	token := p.tokens[p.position]
+	p.position++
+	return token, true
+}
+
+func (p *expressionParser) peek() string {
+	if p.position >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.position]
+}
+
+func (p *expressionParser) parseExpression() error {
+	err := p.parseTerm()
+	if err != nil {
+		return err
+	}
+
+	for p.peek() == "AND" || p.peek() == "OR" {
+		p.position++
+		err := p.parseTerm()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *expressionParser) parseTerm() error {
+	token, ok := p.next()
+	if !ok {
+		return fmt.Errorf("missing license")
+	}
+
+	if token == "(" {
+		err := p.parseExpression()
+		if err != nil {
+			return err
+		}
+		if closing, _ := p.next(); closing != ")" {
+			return fmt.Errorf("missing ')'")
+		}
+		return nil
+	}
+
+	license := strings.TrimSuffix(token, "+")
+	if !IsSPDXLicense(license) && !licenseRefPattern.MatchString(license) {
+		return fmt.Errorf("'%s' is not an SPDX license", token)
+	}
+	p.licenses[license] = struct{}{}
+
+	if p.peek() == "WITH" {
+		p.position++
+		exception, ok := p.next()
+		if !ok || exception == "(" || exception == ")" || isOperator(exception) {
+			return fmt.Errorf("missing exception after WITH")
+		}
+		if !IsSPDXException(exception) {
+			return fmt.Errorf("'%s' is not an SPDX exception", exception)
+		}
+	}
+
+	return nil
+}
+
+func isOperator(token string) bool {
+	return token == "AND" || token == "OR" || token == "WITH"
+}
@@ -0,0 +1,59 @@
+package licenses_test
+
+import (
+	"testing"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/licenses"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testExpression(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	context("ExpressionLicenses", func() {
+		it("returns the sorted licenses of an expression", func() {
+			ids, err := licenses.ExpressionLicenses("MIT OR (Apache-2.0 AND BSD-3-Clause)")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([]string{"Apache-2.0", "BSD-3-Clause", "MIT"}))
+		})
+
+		it("strips the + suffix and WITH exceptions", func() {
+			ids, err := licenses.ExpressionLicenses("GPL-2.0-or-later WITH Classpath-exception-2.0 OR LGPL-2.1+")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([]string{"GPL-2.0-or-later", "LGPL-2.1"}))
+		})
+
+		it("accepts LicenseRefs", func() {
+			ids, err := licenses.ExpressionLicenses("LicenseRef-some-license AND MIT")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(Equal([]string{"LicenseRef-some-license", "MIT"}))
+		})
+	})
+
+	context("ValidateLicenseExpression", func() {
+		it("accepts a single license", func() {
+			Expect(licenses.ValidateLicenseExpression("MIT")).To(Succeed())
+		})
+
+		it("rejects licenses that are not on the SPDX list", func() {
+			Expect(licenses.ValidateLicenseExpression("MIT OR Some-License")).To(MatchError(ContainSubstring("'Some-License' is not an SPDX license")))
+			Expect(licenses.ValidateLicenseExpression("mit")).To(MatchError(ContainSubstring("'mit' is not an SPDX license")))
+		})
+
+		it("accepts exceptions on the SPDX exceptions list", func() {
+			Expect(licenses.ValidateLicenseExpression("Apache-2.0 WITH LLVM-exception")).To(Succeed())
+		})
+
+		it("rejects exceptions that are not on the SPDX exceptions list", func() {
+			Expect(licenses.ValidateLicenseExpression("MIT WITH Some-exception")).To(MatchError(ContainSubstring("'Some-exception' is not an SPDX exception")))
+		})
+
+		it("rejects malformed expressions", func() {
+			for _, expression := range []string{"", "MIT OR", "(MIT OR Apache-2.0", "MIT Apache-2.0", "MIT WITH", "MIT or Apache-2.0"} {
+				Expect(licenses.ValidateLicenseExpression(expression)).NotTo(Succeed(), expression)
+			}
+		})
+	})
+}
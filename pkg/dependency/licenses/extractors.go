@@ -0,0 +1,373 @@
+package licenses
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/ulikunitz/xz"
+)
+
+// Extractor extracts the candidate license files of an archive with
+// extraction.WriteFile. Extractors are registered for the MIME type of the
+// archives they read, which is detected once the compression of the
+// artifact itself, if any, is undone.
+type Extractor func(archive io.Reader, extraction *Extraction) error
+
+// WithExtractor registers extractor for the archives of mimeType, and of the
+// MIME types more specific than it, replacing any extractor already
+// registered for it.
+func WithExtractor(mimeType string, extractor Extractor) LicenseRetrieverOption {
+	return func(l *LicenseRetriever) {
+		extractors := map[string]Extractor{}
+		for m, e := range l.extractors {
+			extractors[m] = e
+		}
+		extractors[mimeType] = extractor
+		l.extractors = extractors
+	}
+}
+
+// defaultExtractors handle the archives dependencies are distributed as:
+// tarballs, including gems, zip archives, including Python wheels and NuGet
+// packages, and PHP archives.
+func defaultExtractors() map[string]Extractor {
+	return map[string]Extractor{
+		"application/x-tar": extractTar,
+		"application/zip":   extractZip,
+		pharMIMEType:        extractPhar,
+		"text/x-php":        extractPhar,
+	}
+}
+
+// These mirror the names licensedb looks for license files and license
+// directories under, along with NOTICE files and the package metadata files
+// that can declare licenses.
+var (
+	licenseFileNames = `li[cs]en[cs]e(s?)|legal|copy(left|right|ing)|unlicense|l?gpl([-_ v]?)(\d\.?\d)?|bsd|mit|apache`
+
+	candidateFileRe    = regexp.MustCompile(`^(|.*[-_. ])(` + licenseFileNames + `|notice)(|[-_. ].*)$`)
+	readmeFileRe       = regexp.MustCompile(`^(readme|guidelines)(|\.md|\.rst|\.html|\.txt)$`)
+	metadataFileRe     = regexp.MustCompile(`^(composer\.json|package\.json|metadata|pkg-info|.*\.nuspec|.*\.gemspec)$`)
+	licenseDirectoryRe = regexp.MustCompile(`^(` + licenseFileNames + `)$`)
+)
+
+// Extraction is the extraction of the candidate license files of an
+// artifact into a directory that licensedb can scan.
+type Extraction struct {
+	destination string
+	extractors  map[string]Extractor
+	limit       int64
+	remaining   int64
+
+	// archiveLimit bounds each archive read whole, separately from the
+	// license files.
+	archiveLimit int64
+
+	// depths records how deep in its archive each extracted file was, so
+	// that a file at the root wins over one with the same name in the
+	// directory the archive wraps its content in.
+	depths map[string]int
+}
+
+func newExtraction(destination string, extractors map[string]Extractor, maxExtractedSize, maxArchiveSize int64) *Extraction {
+	return &Extraction{
+		destination:  destination,
+		extractors:   extractors,
+		limit:        maxExtractedSize,
+		remaining:    maxExtractedSize,
+		archiveLimit: maxArchiveSize,
+		depths:       map[string]int{},
+	}
+}
+
+// Extract undoes the compression of artifact and extracts the candidate
+// license files of the archive inside it with the extractor registered for
+// its MIME type. Extractors call it for the archives nested in theirs.
+func (e *Extraction) Extract(artifact io.Reader) error {
+	archive, mimeType, err := e.decompress(artifact)
+	if err != nil {
+		return err
+	}
+
+	for m := mimeType; m != nil; m = m.Parent() {
+		if extractor, ok := e.extractors[m.String()]; ok {
+			return extractor(archive, e)
+		}
+	}
+
+	return fmt.Errorf("unsupported archive type %s", mimeType)
+}
+
+func (e *Extraction) decompress(artifact io.Reader) (io.Reader, *mimetype.MIME, error) {
+	// An uncompressed artifact on disk is passed on as is, so that zip
+	// archives are read in place rather than in memory.
+	if file, ok := artifact.(*os.File); ok {
+		// 3072 bytes is what the mimetype library needs to detect every
+		// type it knows about.
+		mimeType, err := mimetype.DetectReader(io.NewSectionReader(file, 0, 3072))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read artifact: %w", err)
+		}
+
+		if !isCompression(mimeType) {
+			return file, mimeType, nil
+		}
+	}
+
+	reader := artifact
+	for {
+		bufferedReader := bufio.NewReader(reader)
+
+		header, err := bufferedReader.Peek(3072)
+		if err != nil && err != io.EOF {
+			return nil, nil, fmt.Errorf("failed to read artifact: %w", err)
+		}
+
+		mimeType := mimetype.Detect(header)
+		switch mimeType.String() {
+		case "application/gzip":
+			reader, err = gzip.NewReader(bufferedReader)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create gzip reader: %w", err)
+			}
+		case "application/x-bzip2":
+			reader = bzip2.NewReader(bufferedReader)
+		case "application/x-xz":
+			reader, err = xz.NewReader(bufferedReader)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create xz reader: %w", err)
+			}
+		default:
+			return bufferedReader, mimeType, nil
+		}
+	}
+}
+
+func isCompression(mimeType *mimetype.MIME) bool {
+	return mimeType.Is("application/gzip") || mimeType.Is("application/x-bzip2") || mimeType.Is("application/x-xz")
+}
+
+// limitReader counts what is read from reader against the maximum extracted
+// size of the whole extraction.
+func (e *Extraction) limitReader(reader io.Reader) io.Reader {
+	if e.limit <= 0 {
+		return reader
+	}
+	return &extractionLimiter{reader: reader, remaining: &e.remaining, limit: e.limit}
+}
+
+// ReadArchive reads a whole archive whose candidate license files cannot be
+// found while streaming through it into memory. It fails for archives larger
+// than the maximum archive size, and does not count against the maximum
+// extracted size.
+func (e *Extraction) ReadArchive(archive io.Reader) ([]byte, error) {
+	if e.archiveLimit <= 0 {
+		return io.ReadAll(archive)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(archive, e.archiveLimit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(content)) > e.archiveLimit {
+		return nil, ExtractedSizeError{Limit: e.archiveLimit}
+	}
+
+	return content, nil
+}
+
+// spoolArchive copies a whole archive into a temporary file, which the caller
+// closes and removes, bounded like ReadArchive.
+func (e *Extraction) spoolArchive(archive io.Reader) (*os.File, error) {
+	file, err := os.CreateTemp("", "archive")
+	if err != nil {
+		return nil, err
+	}
+
+	reader := archive
+	if e.archiveLimit > 0 {
+		reader = io.LimitReader(archive, e.archiveLimit+1)
+	}
+
+	size, err := io.Copy(file, reader)
+	if err == nil && e.archiveLimit > 0 && size > e.archiveLimit {
+		err = ExtractedSizeError{Limit: e.archiveLimit}
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// IsCandidate reports whether the file at name in an archive may hold the
+// licenses of the archive. Files at its root, or in the single directory
+// archives often wrap their content in, are candidates when they are
+// license, NOTICE, README or package metadata files, or are in a license
+// directory.
+func (e *Extraction) IsCandidate(name string) bool {
+	_, _, ok := candidatePath(name)
+	return ok
+}
+
+// WriteFile extracts the file at name in an archive, unless it is not a
+// candidate or a shallower file with the same name was already extracted.
+// content is not read in either case. The extracted files share the maximum
+// extracted size.
+func (e *Extraction) WriteFile(name string, content io.Reader) error {
+	relativePath, depth, ok := candidatePath(name)
+	if !ok {
+		return nil
+	}
+
+	if extractedDepth, ok := e.depths[relativePath]; ok && extractedDepth <= depth {
+		return nil
+	}
+
+	filePath := filepath.Join(e.destination, filepath.FromSlash(relativePath))
+	err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", relativePath, err)
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", relativePath, err)
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, e.limitReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", relativePath, err)
+	}
+
+	e.depths[relativePath] = depth
+	return nil
+}
+
+// candidatePath returns where the file at name in an archive is extracted
+// to, and how deep in the archive it is, if it is a candidate license file.
+func candidatePath(name string) (string, int, bool) {
+	components := strings.Split(strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/"), "/")
+
+	var depth int
+	if len(components) > 1 && !isLicenseDirectory(components[0]) {
+		components = components[1:]
+		depth = 1
+	}
+
+	switch {
+	case len(components) == 1 && isCandidateFile(components[0]):
+	case len(components) == 2 && isLicenseDirectory(components[0]) && components[1] != "":
+	default:
+		return "", 0, false
+	}
+
+	return path.Join(components...), depth, true
+}
+
+func isCandidateFile(name string) bool {
+	name = strings.ToLower(name)
+	return candidateFileRe.MatchString(name) || readmeFileRe.MatchString(name) || metadataFileRe.MatchString(name)
+}
+
+func isLicenseDirectory(name string) bool {
+	return licenseDirectoryRe.MatchString(strings.ToLower(name))
+}
+
+// extractTar streams through a tarball, extracting its candidate license
+// files. A gem is a tarball whose files are in the data.tar.gz at its root.
+func extractTar(archive io.Reader, extraction *Extraction) error {
+	tarReader := tar.NewReader(archive)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tar archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		if path.Clean(header.Name) == "data.tar.gz" {
+			err = extraction.Extract(tarReader)
+			if err != nil {
+				return fmt.Errorf("failed to decompress inner source file: %w", err)
+			}
+			continue
+		}
+
+		err = extraction.WriteFile(header.Name, tarReader)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// extractZip extracts the candidate license files of a zip archive, whose
+// directory is at its end. An archive on disk is read in place, and any
+// other is spooled to a temporary file first. Only the candidates are
+// decompressed.
+func extractZip(archive io.Reader, extraction *Extraction) error {
+	file, ok := archive.(*os.File)
+	if !ok {
+		spooled, err := extraction.spoolArchive(archive)
+		if err != nil {
+			return fmt.Errorf("failed to read zip archive: %w", err)
+		}
+		defer os.Remove(spooled.Name())
+		defer spooled.Close()
+
+		file = spooled
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to read zip archive: %w", err)
+	}
+
+	zipReader, err := zip.NewReader(file, info.Size())
+	if err != nil {
+		return fmt.Errorf("failed to read zip archive: %w", err)
+	}
+
+	for _, file := range zipReader.File {
+		if !file.Mode().IsRegular() || !extraction.IsCandidate(file.Name) {
+			continue
+		}
+
+		err := extractZipFile(file, extraction)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractZipFile(file *zip.File, extraction *Extraction) error {
+	content, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", file.Name, err)
+	}
+	defer content.Close()
+
+	return extraction.WriteFile(file.Name, content)
+}
@@ -0,0 +1,235 @@
+package licenses_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/licenses"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testExtractors(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		artifactDir      string
+		licenseRetriever licenses.LicenseRetriever
+		licenseContent   string
+	)
+
+	writeZip := func(name string, files ...[2]string) string {
+		buffer := bytes.NewBuffer(nil)
+		zw := zip.NewWriter(buffer)
+		for _, file := range files {
+			writer, err := zw.Create(file[0])
+			Expect(err).NotTo(HaveOccurred())
+			_, err = writer.Write([]byte(file[1]))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(zw.Close()).To(Succeed())
+
+		path := filepath.Join(artifactDir, name)
+		Expect(os.WriteFile(path, buffer.Bytes(), 0644)).To(Succeed())
+		return path
+	}
+
+	writeTarball := func(name string, files ...[2]string) string {
+		buffer := bytes.NewBuffer(nil)
+		gw := gzip.NewWriter(buffer)
+		tw := tar.NewWriter(gw)
+		for _, file := range files {
+			Expect(tw.WriteHeader(&tar.Header{Name: file[0], Mode: 0644, Size: int64(len(file[1]))})).To(Succeed())
+			_, err := tw.Write([]byte(file[1]))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(tw.Close()).To(Succeed())
+		Expect(gw.Close()).To(Succeed())
+
+		path := filepath.Join(artifactDir, name)
+		Expect(os.WriteFile(path, buffer.Bytes(), 0644)).To(Succeed())
+		return path
+	}
+
+	it.Before(func() {
+		licenseRetriever = licenses.NewLicenseRetriever()
+		artifactDir = t.TempDir()
+
+		content, err := os.ReadFile(filepath.Join("testdata", "LICENSE"))
+		Expect(err).NotTo(HaveOccurred())
+		licenseContent = string(content)
+	})
+
+	context("given a zip source archive", func() {
+		it("detects the license of the LICENSE in its top-level directory", func() {
+			path := writeZip("source.zip",
+				[2]string{"some-repo-1.0.0/LICENSE", licenseContent},
+				[2]string{"some-repo-1.0.0/main.go", "package main"},
+			)
+
+			report, err := licenseRetriever.LookupLicenseReport("dependency", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Licenses()).To(Equal([]string{"MIT", "MIT-0"}))
+			Expect(report.Matches[0].File).To(Equal("LICENSE"))
+		})
+
+		context("given a maximum extracted size", func() {
+			it.Before(func() {
+				licenseRetriever = licenses.NewLicenseRetriever(licenses.WithMaxExtractedSize(64 * 1024))
+			})
+
+			it("only counts the license files against it", func() {
+				path := writeZip("source.zip",
+					[2]string{"some-repo-1.0.0/LICENSE", licenseContent},
+					[2]string{"some-repo-1.0.0/some-large-file", strings.Repeat("x", 1024*1024)},
+				)
+
+				licenses, err := licenseRetriever.LookupLicenses("dependency", path)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
+			})
+
+			it("fails for license files larger than it", func() {
+				path := writeZip("source.zip", [2]string{"some-repo-1.0.0/LICENSE", strings.Repeat("x", 1024*1024)})
+
+				_, err := licenseRetriever.LookupLicenses("dependency", path)
+				Expect(err).To(MatchError(ContainSubstring("artifact extracts to more than 65536 bytes")))
+			})
+		})
+	})
+
+	context("given a compressed zip archive", func() {
+		var path string
+
+		it.Before(func() {
+			// random content does not compress, so the archive is larger
+			// than 1MB
+			largeFile := make([]byte, 1024*1024)
+			_, err := rand.New(rand.NewSource(0)).Read(largeFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			zipPath := writeZip("source.zip",
+				[2]string{"some-repo-1.0.0/LICENSE", licenseContent},
+				[2]string{"some-repo-1.0.0/some-large-file", string(largeFile)},
+			)
+			content, err := os.ReadFile(zipPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			buffer := bytes.NewBuffer(nil)
+			gw := gzip.NewWriter(buffer)
+			_, err = gw.Write(content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gw.Close()).To(Succeed())
+
+			path = filepath.Join(artifactDir, "source.zip.gz")
+			Expect(os.WriteFile(path, buffer.Bytes(), 0644)).To(Succeed())
+		})
+
+		it("detects the license of the LICENSE in its top-level directory", func() {
+			licenses, err := licenseRetriever.LookupLicenses("dependency", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
+		})
+
+		it("does not count the archive against the maximum extracted size", func() {
+			licenseRetriever = licenses.NewLicenseRetriever(licenses.WithMaxExtractedSize(64 * 1024))
+
+			licenses, err := licenseRetriever.LookupLicenses("dependency", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
+		})
+
+		it("fails for archives larger than the maximum archive size", func() {
+			licenseRetriever = licenses.NewLicenseRetriever(licenses.WithMaxArchiveSize(64 * 1024))
+
+			_, err := licenseRetriever.LookupLicenses("dependency", path)
+			Expect(err).To(MatchError(ContainSubstring("artifact extracts to more than 65536 bytes")))
+		})
+	})
+
+	context("given a Python wheel", func() {
+		it("detects the license of the LICENSE in its dist-info directory", func() {
+			path := writeZip("some_package-1.0.0-py3-none-any.whl",
+				[2]string{"some_package/__init__.py", ""},
+				[2]string{"some_package-1.0.0.dist-info/METADATA", "Metadata-Version: 2.1\nName: some-package\n"},
+				[2]string{"some_package-1.0.0.dist-info/licenses/LICENSE", licenseContent},
+			)
+
+			report, err := licenseRetriever.LookupLicenseReport("pypi", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Licenses()).To(Equal([]string{"MIT", "MIT-0"}))
+			Expect(report.Matches[0].File).To(Equal("licenses/LICENSE"))
+		})
+	})
+
+	context("given a NuGet package", func() {
+		it("detects the license of the LICENSE at its root", func() {
+			path := writeZip("Some.Package.1.0.0.nupkg",
+				[2]string{"[Content_Types].xml", `<?xml version="1.0" encoding="utf-8"?><Types/>`},
+				[2]string{"Some.Package.nuspec", `<?xml version="1.0" encoding="utf-8"?><package/>`},
+				[2]string{"LICENSE.txt", licenseContent},
+				[2]string{"lib/net6.0/Some.Package.dll", "some-binary"},
+			)
+
+			licenses, err := licenseRetriever.LookupLicenses("dependency", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
+		})
+	})
+
+	context("given a tarball", func() {
+		it("prefers the files at its root over the ones in its top-level directory", func() {
+			path := writeTarball("source.tgz",
+				[2]string{"some-dir/LICENSE", "some-unknown-license"},
+				[2]string{"LICENSE", licenseContent},
+			)
+
+			licenses, err := licenseRetriever.LookupLicenses("dependency", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
+		})
+
+		it("detects the licenses of a license directory", func() {
+			path := writeTarball("source.tgz", [2]string{"some-dir/LICENSES/MIT.txt", licenseContent})
+
+			report, err := licenseRetriever.LookupLicenseReport("dependency", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Licenses()).To(Equal([]string{"MIT", "MIT-0"}))
+			Expect(report.Matches[0].File).To(Equal("LICENSES/MIT.txt"))
+		})
+
+		it("does not extract the license files of nested components", func() {
+			path := writeTarball("source.tgz", [2]string{"some-dir/vendor/some-library/LICENSE", licenseContent})
+
+			licenses, err := licenseRetriever.LookupLicenses("dependency", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{}))
+		})
+	})
+
+	context("given an extractor for another archive type", func() {
+		it.Before(func() {
+			licenseRetriever = licenses.NewLicenseRetriever(licenses.WithExtractor("audio/flac", func(archive io.Reader, extraction *licenses.Extraction) error {
+				return extraction.WriteFile("LICENSE", bytes.NewBufferString(licenseContent))
+			}))
+		})
+
+		it("extracts the archives of that type with it", func() {
+			path := filepath.Join(artifactDir, "artifact.flac")
+			Expect(os.WriteFile(path, []byte("\x66\x4C\x61\x43\x00\x00\x00\x22"), 0644)).To(Succeed())
+
+			licenses, err := licenseRetriever.LookupLicenses("dependency", path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"MIT", "MIT-0"}))
+		})
+	})
+}
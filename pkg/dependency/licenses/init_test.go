@@ -16,6 +16,9 @@ func TestGetLicenses(t *testing.T) {
 	suite := spec.New("getLicenses", spec.Report(report.Terminal{}))
 	suite("DefaultLicenseCase", testDefaultLicenseCase)
 	suite("BundlerLicenseCase", testBundlerLicenseCase)
-	suite("SkipLicenseScanCase", testSkipLicenseScanCase)
+	suite("ComposerLicenseCase", testComposerLicenseCase)
+	suite("SPDX", testSPDX)
+	suite("Expression", testExpression)
+	suite("Extractors", testExtractors)
 	suite.Run(t)
 }
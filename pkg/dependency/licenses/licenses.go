@@ -1,119 +1,206 @@
 package licenses
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
 
 	"github.com/go-enry/go-license-detector/v4/licensedb"
 	"github.com/go-enry/go-license-detector/v4/licensedb/filer"
-	"github.com/paketo-buildpacks/packit/vacation"
 )
 
-type LicenseRetriever struct{}
+type LicenseRetriever struct {
+	maxExtractedSize    int64
+	maxArchiveSize      int64
+	confidenceThreshold float32
+	extractors          map[string]Extractor
+}
+
+type LicenseRetrieverOption func(*LicenseRetriever)
 
-func NewLicenseRetriever() LicenseRetriever {
-	return LicenseRetriever{}
+// WithMaxExtractedSize replaces the maximum total size of the license files
+// extracted from an artifact. A size of zero or less lifts the limit.
+func WithMaxExtractedSize(size int64) LicenseRetrieverOption {
+	return func(l *LicenseRetriever) {
+		l.maxExtractedSize = size
+	}
 }
 
-func (LicenseRetriever) LookupLicenses(dependencyName, sourceURL string) ([]string, error) {
-	// composer dependency does not have an auto-retrievable license
-	// Exit the function and leave the license blank
-	if dependencyName == "composer" {
-		// skipping license retrieval
-		// license is not automatically retrievable and may need to be looked up manually
-		return []string{}, nil
+// WithMaxArchiveSize replaces the maximum size of each archive that has to be
+// read whole. A size of zero or less lifts the limit.
+func WithMaxArchiveSize(size int64) LicenseRetrieverOption {
+	return func(l *LicenseRetriever) {
+		l.maxArchiveSize = size
 	}
+}
+
+// WithConfidenceThreshold excludes the license files matched with a
+// confidence, from 0 to 1, below threshold. By default every match licensedb
+// reports is kept.
+func WithConfidenceThreshold(threshold float32) LicenseRetrieverOption {
+	return func(l *LicenseRetriever) {
+		l.confidenceThreshold = threshold
+	}
+}
 
-	// getting the dependency artifact from sourceURL
-	url := sourceURL
-	resp, err := http.Get(url)
+// LicenseMatch is a file of an artifact that matched a license.
+type LicenseMatch struct {
+	License    string  `json:"license"`
+	File       string  `json:"file"`
+	Confidence float32 `json:"confidence"`
+}
+
+// LicenseReport is the inventory of the license files found in an artifact,
+// sorted by license and then by file.
+type LicenseReport struct {
+	Matches []LicenseMatch `json:"matches"`
+}
+
+// Licenses returns the IDs of the licenses matched, in alphabetical order.
+func (r LicenseReport) Licenses() []string {
+	licenseIDs := []string{}
+	for _, match := range r.Matches {
+		if len(licenseIDs) == 0 || licenseIDs[len(licenseIDs)-1] != match.License {
+			licenseIDs = append(licenseIDs, match.License)
+		}
+	}
+
+	return licenseIDs
+}
+
+func NewLicenseRetriever(options ...LicenseRetrieverOption) LicenseRetriever {
+	licenseRetriever := LicenseRetriever{
+		maxExtractedSize: DefaultMaxExtractedSize,
+		maxArchiveSize:   DefaultMaxArchiveSize,
+		extractors:       defaultExtractors(),
+	}
+
+	for _, option := range options {
+		option(&licenseRetriever)
+	}
+
+	return licenseRetriever
+}
+
+// LookupLicenses detects the licenses of a dependency from a local copy of
+// its artifact.
+func (l LicenseRetriever) LookupLicenses(dependencyName, artifactPath string) ([]string, error) {
+	report, err := l.LookupLicenseReport(dependencyName, artifactPath)
 	if err != nil {
-		return []string{}, fmt.Errorf("failed to query url: %w", err)
+		return []string{}, err
 	}
-	if resp.StatusCode != http.StatusOK {
-		return []string{}, fmt.Errorf("failed to query url %s with: status code %d", url, resp.StatusCode)
+
+	return report.Licenses(), nil
+}
+
+// LookupLicenseReport detects the licenses of a dependency from a local copy
+// of its artifact, along with the files that matched each license and how
+// confidently they did.
+func (l LicenseRetriever) LookupLicenseReport(dependencyName, artifactPath string) (LicenseReport, error) {
+	artifact, err := os.Open(artifactPath)
+	if err != nil {
+		return LicenseReport{}, fmt.Errorf("failed to open artifact: %w", err)
 	}
+	defer artifact.Close()
 
-	// decompressing the dependency artifact
+	// extracting the candidate license files of the dependency artifact
 	tempDir, err := os.MkdirTemp("", "destination")
 	if err != nil {
-		return []string{}, err
+		return LicenseReport{}, err
 	}
 	defer os.RemoveAll(tempDir)
 
-	switch dependencyName {
-	case "bundler":
-		err := bundlerDecompress(resp.Body, tempDir)
-		if err != nil {
-			return []string{}, err
-		}
-	case "dotnet-runtime", "dotnet-aspnetcore", "dotnet-sdk":
-		err := defaultDecompress(resp.Body, tempDir, 0)
-		if err != nil {
-			return []string{}, err
-		}
-	default:
-		err := defaultDecompress(resp.Body, tempDir, 1)
-		if err != nil {
-			return []string{}, err
-		}
+	err = newExtraction(tempDir, l.extractors, l.maxExtractedSize, l.maxArchiveSize).Extract(artifact)
+	if err != nil {
+		return LicenseReport{}, fmt.Errorf("failed to decompress source file: %w", err)
 	}
 
 	// scanning artifact for license file
 	filer, err := filer.FromDirectory(tempDir)
 	if err != nil {
-		return []string{}, fmt.Errorf("failed to setup a licensedb filer: %w", err)
+		return LicenseReport{}, fmt.Errorf("failed to setup a licensedb filer: %w", err)
 	}
 
 	licenses, err := licensedb.Detect(filer)
-	// if no licenses are found, just return an empty slice.
+	// if no licenses are found, just return an empty report.
 	if err != nil {
 		if err.Error() != "no license file was found" {
-			return []string{}, fmt.Errorf("failed to detect licenses: %w", err)
+			return LicenseReport{}, fmt.Errorf("failed to detect licenses: %w", err)
+		}
+
+		if dependencyName == "composer" {
+			return composerJSONLicenseReport(tempDir)
 		}
-		return []string{}, nil
+		return LicenseReport{}, nil
 	}
 
-	// Only return the license IDs, in alphabetical order
-	var licenseIDs []string
-	for key := range licenses {
-		licenseIDs = append(licenseIDs, key)
+	var report LicenseReport
+	for license, match := range licenses {
+		for file, confidence := range match.Files {
+			if confidence < l.confidenceThreshold {
+				continue
+			}
+
+			report.Matches = append(report.Matches, LicenseMatch{
+				License:    license,
+				File:       file,
+				Confidence: confidence,
+			})
+		}
 	}
-	sort.Strings(licenseIDs)
+	sort.Slice(report.Matches, func(i, j int) bool {
+		if report.Matches[i].License != report.Matches[j].License {
+			return report.Matches[i].License < report.Matches[j].License
+		}
+		return report.Matches[i].File < report.Matches[j].File
+	})
 
-	return licenseIDs, nil
+	return report, nil
 }
 
-func defaultDecompress(artifact io.Reader, destination string, stripComponents int) error {
-	archive := vacation.NewArchive(artifact)
-
-	err := archive.StripComponents(stripComponents).Decompress(destination)
+// composerJSONLicenseReport reports the licenses declared by the
+// composer.json in dir, for when it has no license file that licensedb
+// recognizes. Declared licenses are matched with full confidence.
+func composerJSONLicenseReport(dir string) (LicenseReport, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "composer.json"))
 	if err != nil {
-		return fmt.Errorf("failed to decompress source file: %w", err)
+		if errors.Is(err, os.ErrNotExist) {
+			return LicenseReport{}, nil
+		}
+		return LicenseReport{}, fmt.Errorf("failed to read composer.json: %w", err)
 	}
 
-	return nil
-}
-
-// The bundler dependency comes as a .gem file (tar.gz mime type) with a
-// data.tar.gz file inside that contains the license.
-func bundlerDecompress(artifact io.Reader, destination string) error {
-	archive := vacation.NewArchive(artifact)
-	err := archive.Decompress(destination)
+	var composerJSON struct {
+		License json.RawMessage `json:"license"`
+	}
+	err = json.Unmarshal(content, &composerJSON)
 	if err != nil {
-		return fmt.Errorf("failed to decompress source file: %w", err)
+		return LicenseReport{}, fmt.Errorf("failed to parse composer.json: %w", err)
 	}
 
-	innerArtifact, _ := os.Open(filepath.Join(destination, "data.tar.gz"))
-	innerArchive := vacation.NewArchive(innerArtifact)
-	err = innerArchive.Decompress(destination)
-	if err != nil {
-		return fmt.Errorf("failed to decompress inner source file: %w", err)
+	// The license is either a single SPDX ID or a list of them.
+	var licenseIDs []string
+	var licenseID string
+	if json.Unmarshal(composerJSON.License, &licenseID) == nil {
+		if licenseID != "" {
+			licenseIDs = append(licenseIDs, licenseID)
+		}
+	} else if json.Unmarshal(composerJSON.License, &licenseIDs) != nil {
+		return LicenseReport{}, fmt.Errorf("failed to parse composer.json license %s", composerJSON.License)
+	}
+	sort.Strings(licenseIDs)
+
+	var report LicenseReport
+	for _, licenseID := range licenseIDs {
+		report.Matches = append(report.Matches, LicenseMatch{
+			License:    licenseID,
+			File:       "composer.json",
+			Confidence: 1,
+		})
 	}
 
-	return nil
+	return report, nil
 }
@@ -0,0 +1,45 @@
+package licenses
+
+import (
+	"fmt"
+	"io"
+)
+
+// DefaultMaxExtractedSize bounds the total size of the candidate license
+// files extracted from an artifact, so that a small artifact crafted to
+// decompress into a huge one cannot fill the disk. License, README and
+// metadata files rarely add up to more than a few hundred kilobytes.
+const DefaultMaxExtractedSize = 8 << 20
+
+// DefaultMaxArchiveSize bounds the size of each archive that has to be read
+// whole to find its candidate license files, such as a phar or a zip nested
+// in a compressed artifact. Phars are read into memory, and nested zips are
+// spooled to a temporary file.
+const DefaultMaxArchiveSize = 64 << 20
+
+// ExtractedSizeError is returned when the license files of an artifact, or
+// an archive read whole, are larger than their maximum size.
+type ExtractedSizeError struct {
+	Limit int64
+}
+
+func (e ExtractedSizeError) Error() string {
+	return fmt.Sprintf("artifact extracts to more than %d bytes", e.Limit)
+}
+
+// extractionLimiter fails with an ExtractedSizeError once more than limit
+// bytes have been read from it and the other limiters sharing remaining.
+type extractionLimiter struct {
+	reader    io.Reader
+	remaining *int64
+	limit     int64
+}
+
+func (l *extractionLimiter) Read(p []byte) (int, error) {
+	n, err := l.reader.Read(p)
+	*l.remaining -= int64(n)
+	if *l.remaining < 0 {
+		return n, ExtractedSizeError{Limit: l.limit}
+	}
+	return n, err
+}
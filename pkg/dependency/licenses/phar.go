@@ -0,0 +1,199 @@
+package licenses
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	pharMIMEType     = "application/x-phar"
+	pharHaltCompiler = "__HALT_COMPILER();"
+
+	pharEntryDeflate = 0x1000
+	pharEntryBzip2   = 0x2000
+)
+
+// The stub of a phar makes it look like a PHP script, or like binary data
+// once its manifest follows, so phars whose stub ends in the header are
+// detected as a type of their own.
+func init() {
+	mimetype.Extend(func(header []byte, _ uint32) bool {
+		return isPhar(header) && bytes.Contains(header, []byte(pharHaltCompiler))
+	}, pharMIMEType, ".phar")
+}
+
+// isPhar reports whether header is the start of a PHP archive, whose stub is
+// a PHP script.
+func isPhar(header []byte) bool {
+	if bytes.HasPrefix(header, []byte("#!")) {
+		line := header
+		if i := bytes.IndexByte(header, '\n'); i >= 0 {
+			line = header[:i]
+		}
+		return bytes.Contains(line, []byte("php"))
+	}
+	return bytes.HasPrefix(header, []byte("<?php"))
+}
+
+type pharEntry struct {
+	name           string
+	size           uint32
+	compressedSize uint32
+	flags          uint32
+}
+
+// extractPhar extracts the candidate license files of a PHP archive, such
+// as its LICENSE files and its composer.json. The archive is read whole, as
+// its manifest precedes the data of its entries, but only the candidates are
+// decompressed. See https://www.php.net/manual/en/phar.fileformat.php for
+// the format.
+func extractPhar(archive io.Reader, extraction *Extraction) error {
+	content, err := extraction.ReadArchive(archive)
+	if err != nil {
+		return fmt.Errorf("failed to read phar: %w", err)
+	}
+
+	if !isPhar(content) {
+		return errors.New("failed to read phar: not a PHP archive")
+	}
+
+	data, entries, err := readPharManifest(content)
+	if err != nil {
+		return fmt.Errorf("failed to read phar manifest: %w", err)
+	}
+
+	for _, entry := range entries {
+		if uint64(entry.compressedSize) > uint64(len(data)) {
+			return errors.New("failed to read phar: entry is larger than the archive")
+		}
+		compressed := data[:entry.compressedSize]
+		data = data[entry.compressedSize:]
+
+		if !extraction.IsCandidate(entry.name) {
+			continue
+		}
+
+		var file io.Reader = bytes.NewReader(compressed)
+		switch {
+		case entry.flags&pharEntryDeflate != 0:
+			file = flate.NewReader(file)
+		case entry.flags&pharEntryBzip2 != 0:
+			file = bzip2.NewReader(file)
+		}
+
+		err = extraction.WriteFile(entry.name, io.LimitReader(file, int64(entry.size)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readPharManifest returns the entries of the phar in content, and the data
+// of those entries, which follows the manifest in the same order.
+func readPharManifest(content []byte) ([]byte, []pharEntry, error) {
+	halt := bytes.Index(content, []byte(pharHaltCompiler))
+	if halt < 0 {
+		return nil, nil, errors.New("could not find the end of the stub")
+	}
+
+	rest := content[halt+len(pharHaltCompiler):]
+	rest = bytes.TrimPrefix(rest, []byte(" "))
+	rest = bytes.TrimPrefix(rest, []byte("?>"))
+	if !bytes.HasPrefix(rest, []byte("\r\n")) {
+		rest = bytes.TrimPrefix(rest, []byte("\n"))
+	} else {
+		rest = rest[2:]
+	}
+
+	manifest := bufio.NewReader(bytes.NewReader(rest))
+
+	var header struct {
+		Length     uint32
+		Count      uint32
+		APIVersion uint16
+		Flags      uint32
+	}
+	err := binary.Read(manifest, binary.LittleEndian, &header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if uint64(header.Length)+4 > uint64(len(rest)) {
+		return nil, nil, errors.New("manifest is larger than the archive")
+	}
+
+	_, err = readPharString(manifest, rest) // alias
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = readPharString(manifest, rest) // metadata
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entries []pharEntry
+	for i := uint32(0); i < header.Count; i++ {
+		name, err := readPharString(manifest, rest)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var fields struct {
+			Size           uint32
+			Timestamp      uint32
+			CompressedSize uint32
+			CRC32          uint32
+			Flags          uint32
+		}
+		err = binary.Read(manifest, binary.LittleEndian, &fields)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		_, err = readPharString(manifest, rest) // metadata
+		if err != nil {
+			return nil, nil, err
+		}
+
+		entries = append(entries, pharEntry{
+			name:           name,
+			size:           fields.Size,
+			compressedSize: fields.CompressedSize,
+			flags:          fields.Flags,
+		})
+	}
+
+	// The manifest length does not include its own four bytes.
+	return rest[4+header.Length:], entries, nil
+}
+
+func readPharString(manifest io.Reader, archive []byte) (string, error) {
+	var length uint32
+	err := binary.Read(manifest, binary.LittleEndian, &length)
+	if err != nil {
+		return "", err
+	}
+
+	if uint64(length) > uint64(len(archive)) {
+		return "", errors.New("manifest field is larger than the archive")
+	}
+
+	value := make([]byte, length)
+	_, err = io.ReadFull(manifest, value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,202 @@
+package licenses
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// spdx/licenses.json and spdx/exceptions.json are the SPDX license list's
+// licenses.json and exceptions.json, from https://spdx.org/licenses/. Only
+// the IDs and whether they are deprecated are read.
+var (
+	//go:embed spdx/licenses.json
+	spdxLicensesJSON []byte
+
+	//go:embed spdx/exceptions.json
+	spdxExceptionsJSON []byte
+)
+
+// license_names.csv is the names.csv asset of go-license-detector: the ID and
+// name of every SPDX license it can detect. Only its names are read, to
+// normalize the licenses package registries name rather than identify.
+//
+//go:embed license_names.csv
+var licenseNamesCSV []byte
+
+var spdxIDs, spdxNames = loadSPDXLicenses()
+
+var spdxExceptionIDs = loadSPDXExceptions()
+
+// licenseAliases are names package registries commonly use for licenses
+// that are neither SPDX IDs nor SPDX license names, lower-cased. Ambiguous
+// names, like "BSD" or "Apache Software License", are left out.
+var licenseAliases = map[string]string{
+	"apache 2":                              "Apache-2.0",
+	"apache 2.0":                            "Apache-2.0",
+	"apache license 2":                      "Apache-2.0",
+	"apache license, version 2.0":           "Apache-2.0",
+	"apache license version 2.0":            "Apache-2.0",
+	"apache-2":                              "Apache-2.0",
+	"asl 2.0":                               "Apache-2.0",
+	"expat":                                 "MIT",
+	"gnu general public license v2 (gplv2)": "GPL-2.0-only",
+	"gnu general public license v3 (gplv3)": "GPL-3.0-only",
+	"gnu lesser general public license v2 (lgplv2)": "LGPL-2.0-only",
+	"gnu lesser general public license v3 (lgplv3)": "LGPL-3.0-only",
+	"gplv2":                                "GPL-2.0-only",
+	"gplv3":                                "GPL-3.0-only",
+	"isc license (iscl)":                   "ISC",
+	"mit license (mit)":                    "MIT",
+	"mozilla public license 2.0 (mpl 2.0)": "MPL-2.0",
+	"mpl 2.0":                              "MPL-2.0",
+	"python software foundation license":   "PSF-2.0",
+}
+
+// IsSPDXLicense reports whether id is the ID of an SPDX license, including
+// deprecated ones.
+func IsSPDXLicense(id string) bool {
+	return spdxIDs[strings.ToLower(id)] == id
+}
+
+// IsSPDXException reports whether id is the ID of an SPDX license exception,
+// including deprecated ones.
+func IsSPDXException(id string) bool {
+	return spdxExceptionIDs[id]
+}
+
+// NormalizeLicense returns the SPDX ID of a license as a package registry
+// names it: an SPDX ID in any case, an SPDX license name, a PyPI trove
+// classifier such as "License :: OSI Approved :: MIT License", or a common
+// alias.
+func NormalizeLicense(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, "License :: ") {
+		classifier := strings.Split(name, " :: ")
+		name = classifier[len(classifier)-1]
+	}
+
+	key := strings.ToLower(name)
+	for _, licenses := range []map[string]string{spdxIDs, spdxNames, licenseAliases} {
+		if id, ok := licenses[key]; ok {
+			return id, true
+		}
+	}
+
+	return "", false
+}
+
+// NormalizeLicenses returns the sorted SPDX IDs of the licenses a package
+// registry declares, splitting SPDX expressions like "(MIT OR Apache-2.0)"
+// into the licenses they name. It returns false if there are no licenses or
+// any of them cannot be normalized.
+func NormalizeLicenses(names []string) ([]string, bool) {
+	ids := map[string]struct{}{}
+	for _, name := range names {
+		for _, license := range splitLicenseExpression(name) {
+			id, ok := NormalizeLicense(license)
+			if !ok {
+				return nil, false
+			}
+			ids[id] = struct{}{}
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, false
+	}
+
+	var normalized []string
+	for id := range ids {
+		normalized = append(normalized, id)
+	}
+	sort.Strings(normalized)
+
+	return normalized, true
+}
+
+// splitLicenseExpression returns the licenses of an SPDX expression, or the
+// name itself when it is not one. Only upper-case operators are split on, as
+// license names such as "GNU General Public License v2 or later" contain
+// lower-case ones.
+func splitLicenseExpression(name string) []string {
+	if !strings.Contains(name, " OR ") && !strings.Contains(name, " AND ") {
+		return []string{name}
+	}
+
+	var licenses []string
+	for _, field := range strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(name)) {
+		if field == "OR" || field == "AND" {
+			continue
+		}
+		licenses = append(licenses, field)
+	}
+
+	return licenses
+}
+
+// loadSPDXLicenses returns the SPDX IDs keyed by their lower-cased ID and by
+// their lower-cased name. A name shared by a current ID and a deprecated one,
+// such as the name of GPL-2.0-only and GPL-2.0, returns the current ID.
+func loadSPDXLicenses() (map[string]string, map[string]string) {
+	var list struct {
+		Licenses []struct {
+			LicenseID    string `json:"licenseId"`
+			IsDeprecated bool   `json:"isDeprecatedLicenseId"`
+		} `json:"licenses"`
+	}
+	err := json.Unmarshal(spdxLicensesJSON, &list)
+	if err != nil {
+		panic(err)
+	}
+
+	ids := map[string]string{}
+	deprecated := map[string]bool{}
+	for _, license := range list.Licenses {
+		ids[strings.ToLower(license.LicenseID)] = license.LicenseID
+		deprecated[license.LicenseID] = license.IsDeprecated
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(licenseNamesCSV)).ReadAll()
+	if err != nil {
+		panic(err)
+	}
+
+	names := map[string]string{}
+	for _, record := range records {
+		id, name := record[0], strings.ToLower(record[1])
+		if ids[strings.ToLower(id)] != id {
+			continue
+		}
+
+		if current, ok := names[name]; ok && !deprecated[current] {
+			continue
+		}
+		names[name] = id
+	}
+
+	return ids, names
+}
+
+// loadSPDXExceptions returns the set of SPDX license exception IDs.
+func loadSPDXExceptions() map[string]bool {
+	var list struct {
+		Exceptions []struct {
+			LicenseExceptionID string `json:"licenseExceptionId"`
+		} `json:"exceptions"`
+	}
+	err := json.Unmarshal(spdxExceptionsJSON, &list)
+	if err != nil {
+		panic(err)
+	}
+
+	ids := map[string]bool{}
+	for _, exception := range list.Exceptions {
+		ids[exception.LicenseExceptionID] = true
+	}
+
+	return ids
+}
@@ -0,0 +1,99 @@
+package licenses_test
+
+import (
+	"testing"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/licenses"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testSPDX(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	context("NormalizeLicense", func() {
+		it("returns the SPDX ID of SPDX IDs in any case", func() {
+			id, ok := licenses.NormalizeLicense("apache-2.0")
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal("Apache-2.0"))
+		})
+
+		it("returns the SPDX ID of SPDX license names", func() {
+			id, ok := licenses.NormalizeLicense("MIT License")
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal("MIT"))
+		})
+
+		it("prefers current SPDX IDs over deprecated ones", func() {
+			id, ok := licenses.NormalizeLicense("GNU General Public License v2.0 only")
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal("GPL-2.0-only"))
+		})
+
+		it("returns the SPDX ID of PyPI license classifiers", func() {
+			id, ok := licenses.NormalizeLicense("License :: OSI Approved :: Mozilla Public License 2.0 (MPL 2.0)")
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal("MPL-2.0"))
+		})
+
+		it("returns the SPDX ID of common aliases", func() {
+			id, ok := licenses.NormalizeLicense("Apache License, Version 2.0")
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal("Apache-2.0"))
+		})
+
+		it("does not normalize ambiguous or unknown names", func() {
+			_, ok := licenses.NormalizeLicense("BSD")
+			Expect(ok).To(BeFalse())
+
+			_, ok = licenses.NormalizeLicense("Copyright (c) some-authors")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	context("NormalizeLicenses", func() {
+		it("returns the sorted licenses of SPDX expressions", func() {
+			ids, ok := licenses.NormalizeLicenses([]string{"(MIT OR Apache-2.0)", "MIT License"})
+			Expect(ok).To(BeTrue())
+			Expect(ids).To(Equal([]string{"Apache-2.0", "MIT"}))
+		})
+
+		it("does not split license names on lower-case operators", func() {
+			ids, ok := licenses.NormalizeLicenses([]string{"GNU General Public License v2.0 or later"})
+			Expect(ok).To(BeTrue())
+			Expect(ids).To(Equal([]string{"GPL-2.0-or-later"}))
+		})
+
+		it("fails when any license cannot be normalized", func() {
+			_, ok := licenses.NormalizeLicenses([]string{"MIT", "Some Custom License"})
+			Expect(ok).To(BeFalse())
+		})
+
+		it("fails when there are no licenses", func() {
+			_, ok := licenses.NormalizeLicenses(nil)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	context("IsSPDXLicense", func() {
+		it("only accepts SPDX IDs as the SPDX license list spells them", func() {
+			Expect(licenses.IsSPDXLicense("Apache-2.0")).To(BeTrue())
+			Expect(licenses.IsSPDXLicense("apache-2.0")).To(BeFalse())
+			Expect(licenses.IsSPDXLicense("Some-License")).To(BeFalse())
+		})
+
+		it("accepts every ID on the SPDX license list, including deprecated ones", func() {
+			Expect(licenses.IsSPDXLicense("Unicode-3.0")).To(BeTrue())
+			Expect(licenses.IsSPDXLicense("GPL-2.0")).To(BeTrue())
+		})
+	})
+
+	context("IsSPDXException", func() {
+		it("only accepts IDs on the SPDX exceptions list", func() {
+			Expect(licenses.IsSPDXException("LLVM-exception")).To(BeTrue())
+			Expect(licenses.IsSPDXException("llvm-exception")).To(BeFalse())
+			Expect(licenses.IsSPDXException("MIT")).To(BeFalse())
+		})
+	})
+}
@@ -2,10 +2,11 @@ package dependency
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Nginx struct {
@@ -15,10 +16,13 @@ type Nginx struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
+	keyring          Keyring
 }
 
 func (n Nginx) GetAllVersionRefs() ([]string, error) {
-	tags, err := n.githubClient.GetTags("nginx", "nginx")
+	tags, err := cachedTags(n.cache, n.githubClient, "nginx", "nginx")
 	if err != nil {
 		return nil, fmt.Errorf("could not get tags: %w", err)
 	}
@@ -39,12 +43,19 @@ func (n Nginx) GetDependencyVersion(version string) (DepVersion, error) {
 	}
 
 	dependencyURL := n.dependencyURL(version)
-	sha, err := n.getDependencySHA(dependencyURL, version)
+	artifactPath, err := n.artifactStore.Fetch(dependencyURL)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer n.artifactStore.Release(artifactPath)
+
+	checksums, verification, err := n.getDependencyChecksums(artifactPath, version)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get nginx sha: %w", err)
 	}
+	sha := checksums["sha256"]
 
-	licenses, err := n.licenseRetriever.LookupLicenses("nginx", dependencyURL)
+	licenses, err := n.licenseRetriever.LookupLicenses("nginx", artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
@@ -53,11 +64,14 @@ func (n Nginx) GetDependencyVersion(version string) (DepVersion, error) {
 		Version:         version,
 		URI:             dependencyURL,
 		SHA256:          sha,
+		Checksum:        prefixedChecksum(checksums),
+		Checksums:       checksums,
 		ReleaseDate:     &tagCommit.Date,
 		DeprecationDate: nil,
-		CPE:             fmt.Sprintf("cpe:2.3:a:nginx:nginx:%s:*:*:*:*:*:*:*", version),
-		PURL:            n.purlGenerator.Generate("nginx", version, sha, dependencyURL),
+		CPE:             cpe.Application("nginx", "nginx", version).String(),
+		PURL:            n.purlGenerator.Generate(purl.Generic("nginx", version).WithSource(sha, dependencyURL)),
 		Licenses:        licenses,
+		Verification:    verification,
 	}, nil
 }
 
@@ -71,55 +85,35 @@ func (n Nginx) GetReleaseDate(version string) (*time.Time, error) {
 	return &tagCommit.Date, nil
 }
 
-func (n Nginx) getDependencySHA(dependencyURL, version string) (string, error) {
-	var nginxGPGKeys []string
-	for _, keyURL := range []string{
-		// Key URLs from https://nginx.org/en/pgp_keys.html
-		"http://nginx.org/keys/mdounin.key",
-		"http://nginx.org/keys/maxim.key",
-		"http://nginx.org/keys/sb.key",
-		"http://nginx.org/keys/thresh.key",
-	} {
-		pubKey, err := n.webClient.Get(keyURL)
-		if err != nil {
-			return "", fmt.Errorf("could not get nginx GPG key: %w", err)
-		}
-		nginxGPGKeys = append(nginxGPGKeys, string(pubKey))
-	}
-
-	dependencySignature, err := n.webClient.Get(n.dependencySignatureURL(version))
-	if err != nil {
-		return "", fmt.Errorf("could not get dependency signature: %w", err)
-	}
-
-	dependencyOutputDir, err := os.MkdirTemp("", "nginx")
+func (n Nginx) getDependencyChecksums(dependencyOutputPath, version string) (map[string]string, *Verification, error) {
+	keyring, err := fetchKeys(n.cache, n.webClient, n.keyring)
 	if err != nil {
-		return "", fmt.Errorf("failed to create temp directory: %w", err)
+		return nil, nil, fmt.Errorf("could not get nginx GPG key: %w", err)
 	}
-	dependencyOutputPath := filepath.Join(dependencyOutputDir, filepath.Base(dependencyURL))
 
-	err = n.webClient.Download(dependencyURL, dependencyOutputPath)
+	signatureURL := n.dependencySignatureURL(version)
+	dependencySignature, err := n.webClient.Get(signatureURL)
 	if err != nil {
-		return "", fmt.Errorf("could not download dependency: %w", err)
+		return nil, nil, fmt.Errorf("could not get dependency signature: %w", err)
 	}
 
-	err = n.checksummer.VerifyASC(string(dependencySignature), dependencyOutputPath, nginxGPGKeys...)
+	fingerprint, err := n.checksummer.VerifyASC(string(dependencySignature), dependencyOutputPath, keyring)
 	if err != nil {
-		return "", fmt.Errorf("dependency signature verification failed: %w", err)
+		return nil, nil, fmt.Errorf("dependency signature verification failed: %w", err)
 	}
 
-	dependencySHA, err := n.checksummer.GetSHA256(dependencyOutputPath)
+	checksums, err := n.checksummer.GetChecksums(dependencyOutputPath, checksumAlgorithms...)
 	if err != nil {
-		return "", fmt.Errorf("could not get SHA256: %w", err)
+		return nil, nil, fmt.Errorf("could not get SHA256: %w", err)
 	}
 
-	return dependencySHA, nil
+	return checksums, signatureVerification(signatureURL, fingerprint), nil
 }
 
 func (n Nginx) dependencySignatureURL(version string) string {
-	return fmt.Sprintf("http://nginx.org/download/nginx-%s.tar.gz.asc", version)
+	return fmt.Sprintf("https://nginx.org/download/nginx-%s.tar.gz.asc", version)
 }
 
 func (n Nginx) dependencyURL(version string) string {
-	return fmt.Sprintf("http://nginx.org/download/nginx-%s.tar.gz", version)
+	return fmt.Sprintf("https://nginx.org/download/nginx-%s.tar.gz", version)
 }
@@ -26,6 +26,7 @@ func testNginx(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeGithubClient     *dependencyfakes.FakeGithubClient
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		nginx                dependency.Dependency
@@ -33,14 +34,17 @@ func testNginx(t *testing.T, when spec.G, it spec.S) {
 
 	it.Before(func() {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
+		fakeChecksummer.VerifyASCReturns("some-fingerprint", nil)
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		nginx, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("nginx")
+		nginx, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("nginx")
 		require.NoError(err)
 	})
 
@@ -74,10 +78,8 @@ func testNginx(t *testing.T, when spec.G, it spec.S) {
 			}, nil)
 			fakeWebClient.GetReturnsOnCall(0, []byte("some-gpg-key"), nil)
 			fakeWebClient.GetReturnsOnCall(1, []byte("other-gpg-key"), nil)
-			fakeWebClient.GetReturnsOnCall(2, []byte("another-gpg-key"), nil)
-			fakeWebClient.GetReturnsOnCall(3, []byte("yet-another-gpg-key"), nil)
-			fakeWebClient.GetReturnsOnCall(4, []byte("some-signature"), nil)
-			fakeChecksummer.GetSHA256Returns("some-source-sha", nil)
+			fakeWebClient.GetReturnsOnCall(2, []byte("some-signature"), nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha", "sha512": "some-sha512"}, nil)
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/nginx@1.0.0?checksum=some-source-sha&download_url=http://nginx.org")
 
@@ -88,38 +90,38 @@ func testNginx(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 			expectedReleaseDate := time.Date(2020, 06, 17, 0, 0, 0, 0, time.UTC)
 			expectedDepVersion := dependency.DepVersion{
-				Version:         "1.0.0",
-				URI:             "http://nginx.org/download/nginx-1.0.0.tar.gz",
-				SHA256:          "some-source-sha",
+				Version:  "1.0.0",
+				URI:      "https://nginx.org/download/nginx-1.0.0.tar.gz",
+				SHA256:   "some-source-sha",
+				Checksum: "sha256:some-source-sha",
+				Checksums: map[string]string{
+					"sha256": "some-source-sha",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: nil,
 				CPE:             "cpe:2.3:a:nginx:nginx:1.0.0:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/nginx@1.0.0?checksum=some-source-sha&download_url=http://nginx.org",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Verification:    &dependency.Verification{Method: dependency.VerificationPGP, SourceURL: "https://nginx.org/download/nginx-1.0.0.tar.gz.asc", Fingerprint: "some-fingerprint"},
 			}
 			assert.Equal(expectedDepVersion, actualDepVersion)
 
 			url, _ := fakeWebClient.GetArgsForCall(0)
-			assert.Equal("http://nginx.org/keys/mdounin.key", url)
+			assert.Equal("https://nginx.org/keys/mdounin.key", url)
 			url, _ = fakeWebClient.GetArgsForCall(1)
-			assert.Equal("http://nginx.org/keys/maxim.key", url)
-			url, _ = fakeWebClient.GetArgsForCall(2)
-			assert.Equal("http://nginx.org/keys/sb.key", url)
-			url, _ = fakeWebClient.GetArgsForCall(3)
-			assert.Equal("http://nginx.org/keys/thresh.key", url)
+			assert.Equal("https://nginx.org/keys/thresh.key", url)
 
-			urlArg, _, _ := fakeWebClient.DownloadArgsForCall(0)
-			assert.Equal("http://nginx.org/download/nginx-1.0.0.tar.gz", urlArg)
+			urlArg, _ := fakeArtifactStore.FetchArgsForCall(0)
+			assert.Equal("https://nginx.org/download/nginx-1.0.0.tar.gz", urlArg)
 
 			releaseAssetSignatureArg, _, nginxGPGKeyArg := fakeChecksummer.VerifyASCArgsForCall(0)
 			assert.Equal("some-signature", releaseAssetSignatureArg)
 			assert.Equal([]string{
 				"some-gpg-key",
 				"other-gpg-key",
-				"another-gpg-key",
-				"yet-another-gpg-key",
-			},
-				nginxGPGKeyArg)
+			}, nginxGPGKeyArg.Keys)
+			assert.True(nginxGPGKeyArg.Pinned())
 		})
 	})
 
@@ -6,6 +6,10 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Node struct {
@@ -14,6 +18,22 @@ type Node struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
+}
+
+// nodeArchitectures maps the architecture names used in the Linux binary
+// tarballs to the names of Artifact.Arch, in the order they are listed in a
+// DepVersion.
+var nodeArchitectures = []struct {
+	name string
+	arch string
+}{
+	{name: "x64", arch: "amd64"},
+	{name: "arm64", arch: "arm64"},
+	{name: "armv7l", arch: "arm/v7"},
+	{name: "ppc64le", arch: "ppc64le"},
+	{name: "s390x", arch: "s390x"},
 }
 
 type NodeRelease struct {
@@ -64,7 +84,7 @@ func (n Node) GetDependencyVersion(version string) (DepVersion, error) {
 		}
 	}
 
-	return DepVersion{}, fmt.Errorf("could not find version %s", version)
+	return DepVersion{}, depErrors.VersionNotFoundError{Version: version}
 }
 
 func (n Node) GetReleaseDate(version string) (*time.Time, error) {
@@ -77,17 +97,17 @@ func (n Node) GetReleaseDate(version string) (*time.Time, error) {
 		if release.Version == version {
 			releaseDate, err := time.Parse("2006-01-02", release.Date)
 			if err != nil {
-				return nil, fmt.Errorf("could not parse release date: %w", err)
+				return nil, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 			}
 			return &releaseDate, nil
 		}
 	}
 
-	return nil, fmt.Errorf("could not find release date for version %s", version)
+	return nil, fmt.Errorf("could not find release date: %w", depErrors.VersionNotFoundError{Version: version})
 }
 
 func (n Node) getAllReleases() ([]NodeRelease, error) {
-	body, err := n.webClient.Get("https://nodejs.org/dist/index.json")
+	body, err := cachedGet(n.cache, n.webClient, "https://nodejs.org/dist/index.json")
 	if err != nil {
 		return nil, fmt.Errorf("could not get release index: %w", err)
 	}
@@ -95,7 +115,7 @@ func (n Node) getAllReleases() ([]NodeRelease, error) {
 	var nodeReleases []NodeRelease
 	err = json.Unmarshal(body, &nodeReleases)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal response: %w\n%s", err, body)
+		return nil, fmt.Errorf("could not unmarshal response: %w\n%s", depErrors.ParseError{Err: err}, body)
 	}
 
 	return nodeReleases, nil
@@ -103,35 +123,55 @@ func (n Node) getAllReleases() ([]NodeRelease, error) {
 
 func (n Node) createDepVersion(release NodeRelease, releaseSchedule ReleaseSchedule) (DepVersion, error) {
 	deprecationDate := n.getDeprecationDate(release.Version, releaseSchedule)
-	sha, err := n.getDependencySHA(release.Version)
+	shasums, err := n.getShasums(release.Version)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not get dependency SHA256: %w", err)
+	}
+
+	sha, err := n.getDependencySHA(shasums, release.Version)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get dependency SHA256: %w", err)
 	}
 
 	releaseDate, err := time.Parse("2006-01-02", release.Date)
 	if err != nil {
-		return DepVersion{}, fmt.Errorf("could not parse release date: %w", err)
+		return DepVersion{}, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 	}
 	depURL := n.dependencyURL(release.Version)
-	licenses, err := n.licenseRetriever.LookupLicenses("node", depURL)
+	artifactPath, err := n.artifactStore.Fetch(depURL, verifySHA256(sha)...)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer n.artifactStore.Release(artifactPath)
+
+	licenses, err := n.licenseRetriever.LookupLicenses("node", artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
 
+	checksums, err := n.checksummer.GetChecksums(artifactPath, checksumAlgorithms...)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not get checksums: %w", err)
+	}
+
 	return DepVersion{
 		Version:         release.Version,
 		URI:             depURL,
-		SHA256:          sha,
+		SHA256:          checksums["sha256"],
+		Checksum:        prefixedChecksum(checksums),
+		Checksums:       checksums,
 		ReleaseDate:     &releaseDate,
 		DeprecationDate: deprecationDate,
-		CPE:             fmt.Sprintf("cpe:2.3:a:nodejs:node.js:%s:*:*:*:*:*:*:*", strings.TrimPrefix(release.Version, "v")),
-		PURL:            n.purlGenerator.Generate("node", release.Version, sha, depURL),
+		CPE:             cpe.Application("nodejs", "node.js", strings.TrimPrefix(release.Version, "v")).String(),
+		PURL:            n.purlGenerator.Generate(purl.Generic("node", release.Version).WithSource(checksums["sha256"], depURL)),
 		Licenses:        licenses,
+		Artifacts:       n.getArtifacts(shasums, release.Version),
+		Verification:    checksumVerification(VerificationSHA256, n.shaFileURL(release.Version)),
 	}, nil
 }
 
 func (n Node) getReleaseSchedule() (ReleaseSchedule, error) {
-	body, err := n.webClient.Get("https://raw.githubusercontent.com/nodejs/Release/master/schedule.json")
+	body, err := cachedGet(n.cache, n.webClient, "https://raw.githubusercontent.com/nodejs/Release/master/schedule.json")
 	if err != nil {
 		return ReleaseSchedule{}, fmt.Errorf("could not get release schedule: %w", err)
 	}
@@ -141,7 +181,7 @@ func (n Node) getReleaseSchedule() (ReleaseSchedule, error) {
 	}
 	err = json.Unmarshal(body, &releaseSchedule)
 	if err != nil {
-		return ReleaseSchedule{}, fmt.Errorf("could not unmarshal release schedule: %w\n%s", err, body)
+		return ReleaseSchedule{}, fmt.Errorf("could not unmarshal release schedule: %w\n%s", depErrors.ParseError{Err: err}, body)
 	}
 
 	return releaseSchedule, nil
@@ -165,24 +205,54 @@ func (n Node) getDeprecationDate(version string, releaseSchedule ReleaseSchedule
 	return &deprecationDate
 }
 
-func (n Node) getDependencySHA(version string) (string, error) {
+func (n Node) getShasums(version string) (map[string]string, error) {
 	body, err := n.webClient.Get(n.shaFileURL(version))
 	if err != nil {
-		return "", fmt.Errorf("could not get SHA256 file: %w", err)
+		return nil, fmt.Errorf("could not get SHA256 file: %w", err)
 	}
 
-	var dependencySHA string
+	shasums := map[string]string{}
 	for _, line := range strings.Split(string(body), "\n") {
-		if strings.HasSuffix(line, fmt.Sprintf("node-%s.tar.gz", version)) {
-			dependencySHA = strings.Fields(line)[0]
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
 		}
+		shasums[fields[1]] = fields[0]
 	}
-	if dependencySHA == "" {
-		return "", fmt.Errorf("could not find SHA256 for node-%s.tar.gz", version)
+
+	return shasums, nil
+}
+
+func (n Node) getDependencySHA(shasums map[string]string, version string) (string, error) {
+	filename := fmt.Sprintf("node-%s.tar.gz", version)
+	dependencySHA, ok := shasums[filename]
+	if !ok {
+		return "", fmt.Errorf("could not find SHA256 for %s", filename)
 	}
 	return dependencySHA, nil
 }
 
+func (n Node) getArtifacts(shasums map[string]string, version string) []Artifact {
+	var artifacts []Artifact
+	for _, architecture := range nodeArchitectures {
+		filename := fmt.Sprintf("node-%s-linux-%s.tar.gz", version, architecture.name)
+		sha, ok := shasums[filename]
+		if !ok {
+			continue
+		}
+
+		artifacts = append(artifacts, Artifact{
+			OS:       "linux",
+			Arch:     architecture.arch,
+			Libc:     "glibc",
+			URI:      fmt.Sprintf("https://nodejs.org/dist/%s/%s", version, filename),
+			Checksum: fmt.Sprintf("sha256:%s", sha),
+		})
+	}
+
+	return artifacts
+}
+
 func (n Node) dependencyURL(version string) string {
 	return fmt.Sprintf("https://nodejs.org/dist/%s/node-%s.tar.gz", version, version)
 }
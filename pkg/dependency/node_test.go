@@ -24,6 +24,7 @@ func testNode(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer      *dependencyfakes.FakeChecksummer
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		node                 dependency.Dependency
@@ -33,11 +34,13 @@ func testNode(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		node, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("node")
+		node, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("node")
 		require.NoError(err)
 	})
 
@@ -104,11 +107,18 @@ func testNode(t *testing.T, when spec.G, it spec.S) {
 
 			fakeWebClient.GetReturnsOnCall(2, []byte(`
 aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  node-v13.9.0.tar.gz
+bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb  node-v13.9.0-darwin-x64.tar.gz
+cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc  node-v13.9.0-linux-arm64.tar.gz
+1111111111111111111111111111111111111111111111111111111111111111  node-v13.9.0-linux-armv7l.tar.gz
+dddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddd  node-v13.9.0-linux-x64.tar.gz
+eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee  node-v13.9.0-linux-x64.tar.xz
 `), nil)
 
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/node@v13.9.0?checksum=aaaaa&download_url=https://nodejs.org")
 
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "sha512": "some-sha512"}, nil)
+
 			actualDep, err := node.GetDependencyVersion("v13.9.0")
 
 			require.NoError(err)
@@ -118,14 +128,43 @@ aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  node-v13.9.0.t
 			expectedReleaseDate := time.Date(2020, 02, 20, 0, 0, 0, 0, time.UTC)
 			expectedDeprecationDate := time.Date(2020, 06, 01, 0, 0, 0, 0, time.UTC)
 			expectedDep := dependency.DepVersion{
-				Version:         "v13.9.0",
-				URI:             "https://nodejs.org/dist/v13.9.0/node-v13.9.0.tar.gz",
-				SHA256:          "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Version:  "v13.9.0",
+				URI:      "https://nodejs.org/dist/v13.9.0/node-v13.9.0.tar.gz",
+				SHA256:   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Checksum: "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Checksums: map[string]string{
+					"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: &expectedDeprecationDate,
 				CPE:             "cpe:2.3:a:nodejs:node.js:13.9.0:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/node@v13.9.0?checksum=aaaaa&download_url=https://nodejs.org",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Artifacts: []dependency.Artifact{
+					{
+						OS:       "linux",
+						Arch:     "amd64",
+						Libc:     "glibc",
+						URI:      "https://nodejs.org/dist/v13.9.0/node-v13.9.0-linux-x64.tar.gz",
+						Checksum: "sha256:dddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddd",
+					},
+					{
+						OS:       "linux",
+						Arch:     "arm64",
+						Libc:     "glibc",
+						URI:      "https://nodejs.org/dist/v13.9.0/node-v13.9.0-linux-arm64.tar.gz",
+						Checksum: "sha256:cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc",
+					},
+					{
+						OS:       "linux",
+						Arch:     "arm/v7",
+						Libc:     "glibc",
+						URI:      "https://nodejs.org/dist/v13.9.0/node-v13.9.0-linux-armv7l.tar.gz",
+						Checksum: "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+					},
+				},
+				Verification: &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://nodejs.org/dist/v13.9.0/SHASUMS256.txt"},
 			}
 
 			assert.Equal(expectedDep, actualDep)
@@ -155,6 +194,8 @@ aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  node-v0.8.0.ta
 
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "sha512": "some-sha512"}, nil)
+
 				actualDep, err := node.GetDependencyVersion("v0.8.0")
 
 				require.NoError(err)
@@ -162,13 +203,19 @@ aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  node-v0.8.0.ta
 				expectedReleaseDate := time.Date(2015, 01, 30, 0, 0, 0, 0, time.UTC)
 				expectedDeprecationDate := time.Date(2016, 06, 01, 0, 0, 0, 0, time.UTC)
 				expectedDep := dependency.DepVersion{
-					Version:         "v0.8.0",
-					URI:             "https://nodejs.org/dist/v0.8.0/node-v0.8.0.tar.gz",
-					SHA256:          "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					Version:  "v0.8.0",
+					URI:      "https://nodejs.org/dist/v0.8.0/node-v0.8.0.tar.gz",
+					SHA256:   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					Checksum: "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					Checksums: map[string]string{
+						"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:nodejs:node.js:0.8.0:*:*:*:*:*:*:*",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://nodejs.org/dist/v0.8.0/SHASUMS256.txt"},
 				}
 
 				assert.Equal(expectedDep, actualDep)
@@ -196,19 +243,27 @@ aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  node-v13.9.0.t
 
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "sha512": "some-sha512"}, nil)
+
 				actualDep, err := node.GetDependencyVersion("v13.9.0")
 
 				require.NoError(err)
 
 				expectedReleaseDate := time.Date(2020, 01, 30, 0, 0, 0, 0, time.UTC)
 				expectedDep := dependency.DepVersion{
-					Version:         "v13.9.0",
-					URI:             "https://nodejs.org/dist/v13.9.0/node-v13.9.0.tar.gz",
-					SHA256:          "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					Version:  "v13.9.0",
+					URI:      "https://nodejs.org/dist/v13.9.0/node-v13.9.0.tar.gz",
+					SHA256:   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					Checksum: "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					Checksums: map[string]string{
+						"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: nil,
 					CPE:             "cpe:2.3:a:nodejs:node.js:13.9.0:*:*:*:*:*:*:*",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://nodejs.org/dist/v13.9.0/SHASUMS256.txt"},
 				}
 
 				assert.Equal(expectedDep, actualDep)
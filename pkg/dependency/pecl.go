@@ -2,13 +2,15 @@ package dependency
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/mmcdole/gofeed"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Pecl struct {
@@ -19,6 +21,8 @@ type Pecl struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
 }
 
 type PeclVersion struct {
@@ -50,23 +54,19 @@ func (p Pecl) GetDependencyVersion(version string) (DepVersion, error) {
 		if currVersion.Version == version {
 			dependencyURL := fmt.Sprintf("https://pecl.php.net/get/%s-%s", currVersion.Name, currVersion.Version)
 
-			dependencyOutputDir, err := os.MkdirTemp("", "pecl")
-			if err != nil {
-				return DepVersion{}, fmt.Errorf("failed to create temp dir: %w", err)
-			}
-
-			dependencyOutputPath := filepath.Join(dependencyOutputDir, filepath.Base(dependencyURL))
-			err = p.webClient.Download(dependencyURL, dependencyOutputPath)
+			dependencyOutputPath, err := p.artifactStore.Fetch(dependencyURL)
 			if err != nil {
 				return DepVersion{}, fmt.Errorf("failed to download dependency at %s: %w", dependencyURL, err)
 			}
+			defer p.artifactStore.Release(dependencyOutputPath)
 
-			dependencySHA, err := p.checksummer.GetSHA256(dependencyOutputPath)
+			checksums, err := p.checksummer.GetChecksums(dependencyOutputPath, checksumAlgorithms...)
 			if err != nil {
 				return DepVersion{}, fmt.Errorf("failed to generate dependency checksum: %w", err)
 			}
+			dependencySHA := checksums["sha256"]
 
-			licenses, err := p.licenseRetriever.LookupLicenses("pecl", dependencyURL)
+			licenses, err := p.licenseRetriever.LookupLicenses("pecl", dependencyOutputPath)
 			if err != nil {
 				return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 			}
@@ -75,14 +75,18 @@ func (p Pecl) GetDependencyVersion(version string) (DepVersion, error) {
 				Version:         currVersion.Version,
 				URI:             dependencyURL,
 				SHA256:          dependencySHA,
+				Checksum:        prefixedChecksum(checksums),
+				Checksums:       checksums,
 				ReleaseDate:     currVersion.ReleaseDate,
 				DeprecationDate: nil,
-				PURL:            p.purlGenerator.Generate(currVersion.Name, version, dependencySHA, dependencyURL),
+				CPE:             cpe.Application("php", p.productName, version).String(),
+				PURL:            p.purlGenerator.Generate(purl.Generic(currVersion.Name, version).WithSource(dependencySHA, dependencyURL)),
 				Licenses:        licenses,
+				Verification:    skippedVerification("pecl does not publish checksums or signatures"),
 			}, nil
 		}
 	}
-	return DepVersion{}, nil
+	return DepVersion{}, depErrors.VersionNotFoundError{Dependency: p.productName, Version: version}
 }
 
 func (p Pecl) GetReleaseDate(version string) (*time.Time, error) {
@@ -96,11 +100,11 @@ func (p Pecl) GetReleaseDate(version string) (*time.Time, error) {
 			return currVersion.ReleaseDate, nil
 		}
 	}
-	return nil, nil
+	return nil, depErrors.VersionNotFoundError{Dependency: p.productName, Version: version}
 }
 
 func (p Pecl) getVersions() ([]PeclVersion, error) {
-	body, err := p.webClient.Get(fmt.Sprintf("https://pecl.php.net/feeds/pkg_%s.rss", p.productName))
+	body, err := cachedGet(p.cache, p.webClient, fmt.Sprintf("https://pecl.php.net/feeds/pkg_%s.rss", p.productName))
 	if err != nil {
 		return nil, fmt.Errorf("could not get rss feed: %w", err)
 	}
@@ -108,7 +112,7 @@ func (p Pecl) getVersions() ([]PeclVersion, error) {
 	fp := gofeed.NewParser()
 	feed, err := fp.ParseString(string(body))
 	if err != nil {
-		return nil, fmt.Errorf("error parsing rss feed: %w", err)
+		return nil, fmt.Errorf("error parsing rss feed: %w", depErrors.ParseError{Err: err})
 	}
 
 	var feedVersions []PeclVersion
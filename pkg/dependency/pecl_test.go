@@ -1,11 +1,13 @@
 package dependency_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 	"github.com/stretchr/testify/assert"
@@ -25,6 +27,7 @@ func testPecl(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeGithubClient     *dependencyfakes.FakeGithubClient
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		pecl                 dependency.Dependency
@@ -35,11 +38,13 @@ func testPecl(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		pecl, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("apc")
+		pecl, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("apc")
 		require.NoError(err)
 	})
 
@@ -155,7 +160,7 @@ func testPecl(t *testing.T, when spec.G, it spec.S) {
             <dc:date>2010-08-05T11:04:20-05:00</dc:date>
         </item>
 </rdf:RDF>`), nil)
-			fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/pecl@3.1.6?checksum=some-sha256&download_url=https://pecl.php.net")
@@ -169,18 +174,108 @@ func testPecl(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 			expectedDep := dependency.DepVersion{
-				Version:         "3.1.6",
-				URI:             "https://pecl.php.net/get/APC-3.1.6",
-				SHA256:          "some-sha256",
+				Version:  "3.1.6",
+				URI:      "https://pecl.php.net/get/APC-3.1.6",
+				SHA256:   "some-sha256",
+				Checksum: "sha256:some-sha256",
+				Checksums: map[string]string{
+					"sha256": "some-sha256",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: nil,
+				CPE:             "cpe:2.3:a:php:apc:3.1.6:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/pecl@3.1.6?checksum=some-sha256&download_url=https://pecl.php.net",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Verification:    &dependency.Verification{Method: dependency.VerificationNone, SkipReason: "pecl does not publish checksums or signatures"},
 			}
 			assert.Equal(expectedDep, actualDep)
 
 			urlArg, _ := fakeWebClient.GetArgsForCall(0)
 			assert.Equal("https://pecl.php.net/feeds/pkg_apc.rss", urlArg)
+
+			urlArg, optionsArg := fakeArtifactStore.FetchArgsForCall(0)
+			assert.Equal("https://pecl.php.net/get/APC-3.1.6", urlArg)
+			assert.Empty(optionsArg)
+		})
+
+		when("the version does not exist", func() {
+			it("returns a VersionNotFoundError", func() {
+				fakeWebClient.GetReturns([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/"></rdf:RDF>`), nil)
+
+				_, err := pecl.GetDependencyVersion("3.1.6")
+				assert.True(errors.Is(err, depErrors.VersionNotFoundError{Dependency: "apc", Version: "3.1.6"}), "expected a VersionNotFoundError, got %v", err)
+			})
+		})
+
+		when("the dependency has its own maximum download size", func() {
+			it.Before(func() {
+				var err error
+				pecl, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator,
+					dependency.WithArtifactStore(fakeArtifactStore),
+					dependency.WithDependencyMaxDownloadSize("apc", 1024),
+				).NewDependency("apc")
+				require.NoError(err)
+			})
+
+			it("fetches the artifact with that limit", func() {
+				fakeWebClient.GetReturns([]byte(
+					`<?xml version="1.0" encoding="utf-8"?>
+<rdf:RDF
+        xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+        xmlns="http://purl.org/rss/1.0/"
+        xmlns:dc="http://purl.org/dc/elements/1.1/">
+
+            <item rdf:about="https://pecl.php.net/package-changelog.php?package=APC&amp;release=3.1.6">
+            <title>APC 3.1.6</title>
+            <link>https://pecl.php.net/package-changelog.php?package=APC&amp;amp;release=3.1.6</link>
+            <dc:date>2010-11-30T05:21:42-05:00</dc:date>
+        </item>
+</rdf:RDF>`), nil)
+
+				_, err := pecl.GetDependencyVersion("3.1.6")
+				require.NoError(err)
+
+				_, optionsArg := fakeArtifactStore.FetchArgsForCall(0)
+				assert.Len(optionsArg, 1)
+			})
+		})
+
+		when("the dependency has its own URL policy", func() {
+			it.Before(func() {
+				var err error
+				pecl, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator,
+					dependency.WithArtifactStore(fakeArtifactStore),
+					dependency.WithURLPolicy("apc", dependency.URLPolicy{Hosts: []string{"pecl.php.net"}}),
+				).NewDependency("apc")
+				require.NoError(err)
+			})
+
+			it("makes every request with that policy", func() {
+				fakeWebClient.GetReturns([]byte(
+					`<?xml version="1.0" encoding="utf-8"?>
+<rdf:RDF
+        xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+        xmlns="http://purl.org/rss/1.0/"
+        xmlns:dc="http://purl.org/dc/elements/1.1/">
+
+            <item rdf:about="https://pecl.php.net/package-changelog.php?package=APC&amp;release=3.1.6">
+            <title>APC 3.1.6</title>
+            <link>https://pecl.php.net/package-changelog.php?package=APC&amp;amp;release=3.1.6</link>
+            <dc:date>2010-11-30T05:21:42-05:00</dc:date>
+        </item>
+</rdf:RDF>`), nil)
+
+				_, err := pecl.GetDependencyVersion("3.1.6")
+				require.NoError(err)
+
+				_, getOptionsArg := fakeWebClient.GetArgsForCall(0)
+				assert.Len(getOptionsArg, 1)
+
+				_, fetchOptionsArg := fakeArtifactStore.FetchArgsForCall(0)
+				assert.Len(fetchOptionsArg, 1)
+			})
 		})
 	})
 
@@ -3,21 +3,27 @@ package dependency
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Php struct {
-	checksummer      Checksummer
-	fileSystem       FileSystem
-	webClient        WebClient
-	licenseRetriever LicenseRetriever
-	purlGenerator    PURLGenerator
+	checksummer        Checksummer
+	fileSystem         FileSystem
+	webClient          WebClient
+	licenseRetriever   LicenseRetriever
+	purlGenerator      PURLGenerator
+	cache              Cache
+	artifactStore      ArtifactStore
+	checksumExceptions ChecksumExceptions
 }
 
 type PhpSource struct {
@@ -66,10 +72,17 @@ func (p Php) GetDependencyVersion(version string) (DepVersion, error) {
 	}
 
 	dependencyURL := p.dependencyURL(release, version)
-	dependencySHA, err := p.getDependencySHA(release, version)
+	artifactPath, err := p.artifactStore.Fetch(dependencyURL)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer p.artifactStore.Release(artifactPath)
+
+	checksums, verification, err := p.getDependencyChecksums(release, version, artifactPath)
 	if err != nil {
 		return DepVersion{}, err
 	}
+	dependencySHA := checksums["sha256"]
 
 	// releaseDate is the patch releaseDate
 	releaseDate, err := p.getReleaseDate(release)
@@ -83,7 +96,7 @@ func (p Php) GetDependencyVersion(version string) (DepVersion, error) {
 		return DepVersion{}, fmt.Errorf("could not get version line deprecation date: %w", err)
 	}
 
-	licenses, err := p.licenseRetriever.LookupLicenses("php", dependencyURL)
+	licenses, err := p.licenseRetriever.LookupLicenses("php", artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
@@ -92,11 +105,14 @@ func (p Php) GetDependencyVersion(version string) (DepVersion, error) {
 		Version:         version,
 		URI:             dependencyURL,
 		SHA256:          dependencySHA,
+		Checksum:        prefixedChecksum(checksums),
+		Checksums:       checksums,
 		ReleaseDate:     releaseDate,
 		DeprecationDate: deprecationDate,
-		CPE:             fmt.Sprintf("cpe:2.3:a:php:php:%s:*:*:*:*:*:*:*", version),
-		PURL:            p.purlGenerator.Generate("php", version, dependencySHA, dependencyURL),
+		CPE:             cpe.Application("php", "php", version).String(),
+		PURL:            p.purlGenerator.Generate(purl.Generic("php", version).WithSource(dependencySHA, dependencyURL)),
 		Licenses:        licenses,
+		Verification:    verification,
 	}, nil
 }
 
@@ -110,7 +126,7 @@ func (p Php) GetReleaseDate(version string) (*time.Time, error) {
 }
 
 func (p Php) getPhpReleases() ([]PhpRelease, error) {
-	body, err := p.webClient.Get("https://raw.githubusercontent.com/brayanhenao/php-releases-information/main/releases.json")
+	body, err := cachedGet(p.cache, p.webClient, "https://raw.githubusercontent.com/brayanhenao/php-releases-information/main/releases.json")
 	if err != nil {
 		return nil, fmt.Errorf("could not hit php.net: %w", err)
 	}
@@ -118,7 +134,7 @@ func (p Php) getPhpReleases() ([]PhpRelease, error) {
 	var phpLines map[string]interface{}
 	err = json.Unmarshal(body, &phpLines)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal version lines response: %w\n%s", err, body)
+		return nil, fmt.Errorf("could not unmarshal version lines response: %w\n%s", depErrors.ParseError{Err: err}, body)
 	}
 
 	var versionLines []string
@@ -134,7 +150,7 @@ func (p Php) getPhpReleases() ([]PhpRelease, error) {
 	var allPhpReleases []PhpRelease
 
 	for _, line := range versionLines {
-		body, err = p.webClient.Get(fmt.Sprintf("https://raw.githubusercontent.com/brayanhenao/php-releases-information/main/php-%s.json", line))
+		body, err = cachedGet(p.cache, p.webClient, fmt.Sprintf("https://raw.githubusercontent.com/brayanhenao/php-releases-information/main/php-%s.json", line))
 		if err != nil {
 			return nil, fmt.Errorf("could not hit php.net: %w", err)
 		}
@@ -142,13 +158,13 @@ func (p Php) getPhpReleases() ([]PhpRelease, error) {
 		var phpRawReleases map[string]PhpRawRelease
 		err = json.Unmarshal(body, &phpRawReleases)
 		if err != nil {
-			return nil, fmt.Errorf("could not unmarshal version lines response: %w\n%s", err, body)
+			return nil, fmt.Errorf("could not unmarshal version lines response: %w\n%s", depErrors.ParseError{Err: err}, body)
 		}
 
 		for version, release := range phpRawReleases {
 			releaseDate, err := p.parseReleaseDate(release.Date)
 			if err != nil {
-				return nil, fmt.Errorf("could not parse release date: %w", err)
+				return nil, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 			}
 
 			allPhpReleases = append(allPhpReleases, PhpRelease{
@@ -175,16 +191,15 @@ func (p Php) getRelease(version string) (PhpRawRelease, error) {
 		version = strings.ReplaceAll(version, "*", "0")
 	}
 
-	body, err := p.webClient.Get(fmt.Sprintf("https://raw.githubusercontent.com/brayanhenao/php-releases-information/main/php-%s.json", searchMajorVersion))
+	body, err := cachedGet(p.cache, p.webClient, p.releasesURL(searchMajorVersion))
 	if err != nil {
-		fmt.Println(string(body))
 		return PhpRawRelease{}, fmt.Errorf("could not hit php.net: %w", err)
 	}
 
 	var phpRawReleases map[string]PhpRawRelease
 	err = json.Unmarshal(body, &phpRawReleases)
 	if err != nil {
-		return PhpRawRelease{}, fmt.Errorf("could not unmarshal version lines response: %w\n%s", err, body)
+		return PhpRawRelease{}, fmt.Errorf("could not unmarshal version lines response: %w\n%s", depErrors.ParseError{Err: err}, body)
 	}
 
 	for rawPhpVersion, release := range phpRawReleases {
@@ -193,28 +208,43 @@ func (p Php) getRelease(version string) (PhpRawRelease, error) {
 		}
 	}
 
-	return PhpRawRelease{}, nil
+	return PhpRawRelease{}, depErrors.VersionNotFoundError{Dependency: "php", Version: version}
 }
 
-func (p Php) getDependencySHA(release PhpRawRelease, version string) (string, error) {
+func (p Php) getDependencyChecksums(release PhpRawRelease, version, artifactPath string) (map[string]string, *Verification, error) {
+	if exception, ok := p.checksumExceptions.Lookup("php", version); ok {
+		return exceptionChecksums(p.checksummer, exception, artifactPath)
+	}
+
+	sourceURL := p.releasesURL(strings.Split(version, ".")[0])
 	for _, file := range release.Source {
 		if filepath.Ext(file.Filename) == ".gz" {
 			if file.SHA256 != "" {
-				return file.SHA256, nil
-			} else if file.MD5 != "" || p.dependencyVersionIsMissingChecksum(version) {
-				sha, err := p.getSHA256FromReleaseFile(release, file, version)
+				err := p.checksummer.VerifySHA256(artifactPath, file.SHA256)
+				if err != nil {
+					return nil, nil, fmt.Errorf("dependency signature verification failed: %w", err)
+				}
+
+				checksums, err := p.checksummer.GetChecksums(artifactPath, checksumAlgorithms...)
 				if err != nil {
-					return "", fmt.Errorf("could not get SHA256 from release file: %w", err)
+					return nil, nil, fmt.Errorf("could not get SHA256: %w", err)
 				}
 
-				return sha, nil
+				return checksums, checksumVerification(VerificationSHA256, sourceURL), nil
+			} else if file.MD5 != "" {
+				checksums, err := p.getChecksumsFromReleaseFile(file, artifactPath)
+				if err != nil {
+					return nil, nil, fmt.Errorf("could not get SHA256 from release file: %w", err)
+				}
+
+				return checksums, checksumVerification(VerificationMD5, sourceURL), nil
 			} else {
-				return "", fmt.Errorf("could not find SHA256 or MD5 for %s", version)
+				return nil, nil, fmt.Errorf("could not find SHA256 or MD5 for %s", version)
 			}
 		}
 	}
 
-	return "", fmt.Errorf("could not find .tar.gz file for %s", version)
+	return nil, nil, fmt.Errorf("could not find .tar.gz file for %s", version)
 }
 
 func (p Php) getReleaseDate(release PhpRawRelease) (*time.Time, error) {
@@ -234,7 +264,7 @@ func (p Php) getReleaseDate(release PhpRawRelease) (*time.Time, error) {
 		return &parsedDate, nil
 	}
 
-	return nil, fmt.Errorf("release date '%s' did not match any expected patterns", release.Date)
+	return nil, depErrors.ParseError{Err: fmt.Errorf("release date '%s' did not match any expected patterns", release.Date)}
 }
 
 // getDeprecationDate of the minor version line (ex. 7.4.*)
@@ -263,6 +293,10 @@ func (p Php) calculateDeprecationDate(releaseDate time.Time) *time.Time {
 	return &deprecationDate
 }
 
+func (p Php) releasesURL(majorVersion string) string {
+	return fmt.Sprintf("https://raw.githubusercontent.com/brayanhenao/php-releases-information/main/php-%s.json", majorVersion)
+}
+
 func (p Php) dependencyURL(release PhpRawRelease, version string) string {
 	if release.Museum {
 		majorVersion := version[0:1]
@@ -289,97 +323,19 @@ func (p Php) parseReleaseDate(date string) (*time.Time, error) {
 		return &parsedDate, nil
 	}
 
-	return nil, fmt.Errorf("release date '%s' did not match any expected patterns", date)
+	return nil, depErrors.ParseError{Err: fmt.Errorf("release date '%s' did not match any expected patterns", date)}
 }
 
-func (p Php) getSHA256FromReleaseFile(release PhpRawRelease, file PhpSource, version string) (string, error) {
-	tempDir, err := os.MkdirTemp("", "php")
+func (p Php) getChecksumsFromReleaseFile(file PhpSource, dependencyOutputPath string) (map[string]string, error) {
+	err := p.checksummer.VerifyMD5(dependencyOutputPath, file.MD5)
 	if err != nil {
-		return "", fmt.Errorf("could not create temp directory: %w", err)
+		return nil, fmt.Errorf("dependency signature verification failed: %w", err)
 	}
-	defer os.RemoveAll(tempDir)
 
-	dependencyOutputPath := filepath.Join(tempDir, file.Filename)
-	err = p.webClient.Download(p.dependencyURL(release, version), dependencyOutputPath)
+	checksums, err := p.checksummer.GetChecksums(dependencyOutputPath, checksumAlgorithms...)
 	if err != nil {
-		return "", fmt.Errorf("could not download dependency: %w", err)
-	}
-
-	if !p.dependencyVersionHasIncorrectChecksum(version) && file.MD5 != "" {
-		err = p.checksummer.VerifyMD5(dependencyOutputPath, file.MD5)
-		if err != nil {
-			return "", fmt.Errorf("dependency signature verification failed: %w", err)
-		}
-	}
-
-	sha256, err := p.checksummer.GetSHA256(dependencyOutputPath)
-	if err != nil {
-		return "", fmt.Errorf("could not get SHA256: %w", err)
-	}
-
-	return sha256, nil
-}
-
-func (p Php) dependencyVersionIsMissingChecksum(version string) bool {
-	versionsWithMissingChecksum := map[string]bool{
-		"5.1.6":  true,
-		"5.1.5":  true,
-		"5.1.4":  true,
-		"5.1.3":  true,
-		"5.1.2":  true,
-		"5.1.1":  true,
-		"5.1.0":  true,
-		"5.0.5":  true,
-		"5.0.4":  true,
-		"5.0.3":  true,
-		"5.0.2":  true,
-		"5.0.1":  true,
-		"5.0.0":  true,
-		"4.4.5":  true,
-		"4.4.4":  true,
-		"4.4.3":  true,
-		"4.4.2":  true,
-		"4.4.1":  true,
-		"4.4.0":  true,
-		"4.3.11": true,
-		"4.3.10": true,
-		"4.3.9":  true,
-		"4.3.8":  true,
-		"4.3.7":  true,
-		"4.3.6":  true,
-		"4.3.5":  true,
-		"4.3.4":  true,
-		"4.3.3":  true,
-		"4.3.2":  true,
-		"4.3.1":  true,
-		"4.3.0":  true,
-		"4.2.3":  true,
-		"4.2.2":  true,
-		"4.2.1":  true,
-		"4.2.0":  true,
-		"4.1.2":  true,
-		"4.1.1":  true,
-		"4.1.0":  true,
-		"4.0.6":  true,
-		"4.0.5":  true,
-		"4.0.4":  true,
-		"4.0.3":  true,
-		"4.0.2":  true,
-		"4.0.1":  true,
-		"4.0.0":  true,
-	}
-
-	_, shouldBeIgnored := versionsWithMissingChecksum[version]
-	return shouldBeIgnored
-}
-
-func (p Php) dependencyVersionHasIncorrectChecksum(version string) bool {
-	versionsWithWrongChecksum := map[string]bool{
-		"5.3.25": true,
-		"5.3.11": true,
-		"5.2.14": true,
+		return nil, fmt.Errorf("could not get SHA256: %w", err)
 	}
 
-	_, shouldBeIgnored := versionsWithWrongChecksum[version]
-	return shouldBeIgnored
+	return checksums, nil
 }
@@ -1,6 +1,7 @@
 package dependency_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 )
 
 func TestPhp(t *testing.T) {
@@ -24,6 +26,7 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer      *dependencyfakes.FakeChecksummer
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		php                  dependency.Dependency
@@ -33,11 +36,13 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		php, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("php")
+		php, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("php")
 		require.NoError(err)
 	})
 
@@ -191,6 +196,8 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/php@7.4.4?checksum=aaaaaa&download_url=https://www.php.net")
 
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "sha512": "some-sha512"}, nil)
+
 			actualDepVersion, err := php.GetDependencyVersion("7.4.4")
 			require.NoError(err)
 
@@ -199,14 +206,20 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
 			expectedReleaseDate := time.Date(2020, 03, 12, 0, 0, 0, 0, time.UTC)
 			expectedDeprecationDate := time.Date(2022, 11, 28, 0, 0, 0, 0, time.UTC)
 			expectedDepVersion := dependency.DepVersion{
-				Version:         "7.4.4",
-				URI:             "https://github.com/php/web-php-distributions/raw/master/php-7.4.4.tar.gz",
-				SHA256:          "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Version:  "7.4.4",
+				URI:      "https://github.com/php/web-php-distributions/raw/master/php-7.4.4.tar.gz",
+				SHA256:   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Checksum: "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+				Checksums: map[string]string{
+					"sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: &expectedDeprecationDate,
 				CPE:             "cpe:2.3:a:php:php:7.4.4:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/php@7.4.4?checksum=aaaaaa&download_url=https://www.php.net",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Verification:    &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://raw.githubusercontent.com/brayanhenao/php-releases-information/main/php-7.json"},
 			}
 
 			assert.Equal(expectedDepVersion, actualDepVersion)
@@ -216,6 +229,9 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
 			url, _ = fakeWebClient.GetArgsForCall(1)
 			assert.Equal("https://raw.githubusercontent.com/brayanhenao/php-releases-information/main/php-7.json", url)
 			assert.Equal(2, fakeWebClient.GetCallCount())
+
+			_, sha256Arg := fakeChecksummer.VerifySHA256ArgsForCall(0)
+			assert.Equal("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", sha256Arg)
 		})
 
 		when("the dependency has an MD5 instead of a SHA256", func() {
@@ -258,7 +274,7 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
    }
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 
 				actualDepVersion, err := php.GetDependencyVersion("7.4.4")
@@ -267,17 +283,23 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
 				expectedReleaseDate := time.Date(2020, 03, 19, 0, 0, 0, 0, time.UTC)
 				expectedDeprecationDate := time.Date(2022, 11, 10, 0, 0, 0, 0, time.UTC)
 				expectedDepVersion := dependency.DepVersion{
-					Version:         "7.4.4",
-					URI:             "https://github.com/php/web-php-distributions/raw/master/php-7.4.4.tar.gz",
-					SHA256:          "some-sha256",
+					Version:  "7.4.4",
+					URI:      "https://github.com/php/web-php-distributions/raw/master/php-7.4.4.tar.gz",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:php:php:7.4.4:*:*:*:*:*:*:*",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationMD5, SourceURL: "https://raw.githubusercontent.com/brayanhenao/php-releases-information/main/php-7.json"},
 				}
 				assert.Equal(expectedDepVersion, actualDepVersion)
 
-				url, _, _ := fakeWebClient.DownloadArgsForCall(0)
+				url, _ := fakeArtifactStore.FetchArgsForCall(0)
 				assert.Equal("https://github.com/php/web-php-distributions/raw/master/php-7.4.4.tar.gz", url)
 
 				url, _ = fakeWebClient.GetArgsForCall(1)
@@ -328,7 +350,7 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
    }
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 
 				actualDepVersion, err := php.GetDependencyVersion("5.3.25")
@@ -337,13 +359,19 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
 				expectedReleaseDate := time.Date(2013, 05, 9, 0, 0, 0, 0, time.UTC)
 				expectedDeprecationDate := time.Date(2016, 05, 9, 0, 0, 0, 0, time.UTC)
 				expectedDepVersion := dependency.DepVersion{
-					Version:         "5.3.25",
-					URI:             "https://github.com/php/web-php-distributions/raw/master/php-5.3.25.tar.gz",
-					SHA256:          "some-sha256",
+					Version:  "5.3.25",
+					URI:      "https://github.com/php/web-php-distributions/raw/master/php-5.3.25.tar.gz",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:php:php:5.3.25:*:*:*:*:*:*:*",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationNone, SkipReason: "the MD5 published by php.net does not match the release tarball"},
 				}
 				assert.Equal(expectedDepVersion, actualDepVersion)
 
@@ -389,7 +417,7 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
    }
 }
 `), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 
 				actualDepVersion, err := php.GetDependencyVersion("5.1.6")
@@ -398,13 +426,19 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
 				expectedReleaseDate := time.Date(2006, 8, 24, 0, 0, 0, 0, time.UTC)
 				expectedDeprecationDate := time.Date(2009, 8, 24, 0, 0, 0, 0, time.UTC)
 				expectedDepVersion := dependency.DepVersion{
-					Version:         "5.1.6",
-					URI:             "https://github.com/php/web-php-distributions/raw/master/php-5.1.6.tar.gz",
-					SHA256:          "some-sha256",
+					Version:  "5.1.6",
+					URI:      "https://github.com/php/web-php-distributions/raw/master/php-5.1.6.tar.gz",
+					SHA256:   "some-sha256",
+					Checksum: "sha256:some-sha256",
+					Checksums: map[string]string{
+						"sha256": "some-sha256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: &expectedDeprecationDate,
 					CPE:             "cpe:2.3:a:php:php:5.1.6:*:*:*:*:*:*:*",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationNone, SkipReason: "php.net does not publish a checksum for this release"},
 				}
 				assert.Equal(expectedDepVersion, actualDepVersion)
 
@@ -613,7 +647,7 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
 			it("returns an error", func() {
 				fakeWebClient.GetReturns([]byte(`
 {
-  "7.4.0": {
+  "7.4.4": {
     "announcement": true,
     "tags": [
       "security"
@@ -621,8 +655,8 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
     "date": "01 March 2020",
 	"source": [
 	 {
-	  "filename": "php-7.0.0.tar.gz",
-	  "name": "PHP 7.0.0 (tar.gz)",
+	  "filename": "php-7.4.4.tar.bz2",
+	  "name": "PHP 7.4.4 (tar.bz2)",
 	  "sha256": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
 	  "date": "01 March 2020"
 	 }
@@ -635,6 +669,15 @@ func testPhp(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal("could not find .tar.gz file for 7.4.4", err.Error())
 			})
 		})
+
+		when("the version does not exist", func() {
+			it("returns a VersionNotFoundError", func() {
+				fakeWebClient.GetReturns([]byte(`{}`), nil)
+
+				_, err := php.GetDependencyVersion("7.4.4")
+				assert.True(errors.Is(err, depErrors.VersionNotFoundError{Dependency: "php", Version: "7.4.4"}), "expected a VersionNotFoundError, got %v", err)
+			})
+		})
 	})
 
 	when("GetReleaseDate", func() {
@@ -0,0 +1,252 @@
+package provenance
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+)
+
+// PayloadType is the DSSE payload type of an in-toto Statement.
+const PayloadType = "application/vnd.in-toto+json"
+
+// Envelope is a DSSE envelope holding a signed in-toto Statement.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Signer signs statements with an ed25519 or ECDSA private key.
+type Signer struct {
+	key   crypto.Signer
+	keyID string
+}
+
+// NewSigner returns a Signer for an ed25519 or ECDSA private key.
+func NewSigner(key crypto.Signer) (Signer, error) {
+	switch key.(type) {
+	case ed25519.PrivateKey, *ecdsa.PrivateKey:
+	default:
+		return Signer{}, fmt.Errorf("unsupported signing key type %T", key)
+	}
+
+	keyID, err := KeyID(key.Public())
+	if err != nil {
+		return Signer{}, err
+	}
+
+	return Signer{key: key, keyID: keyID}, nil
+}
+
+// LoadSigner reads a PEM encoded PKCS #8 or SEC 1 private key.
+func LoadSigner(path string) (Signer, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return Signer{}, err
+	}
+
+	var key interface{}
+	if block.Type == "EC PRIVATE KEY" {
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	} else {
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return Signer{}, fmt.Errorf("could not parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return Signer{}, fmt.Errorf("unsupported signing key type %T", key)
+	}
+
+	return NewSigner(signer)
+}
+
+// KeyID returns the ID of a signing key, the hex encoded SHA256 of its PKIX
+// public key.
+func (s Signer) KeyID() string {
+	return s.keyID
+}
+
+// Sign returns statement signed in a DSSE envelope.
+func (s Signer) Sign(statement Statement) (Envelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("could not marshal statement: %w", err)
+	}
+
+	message := pae(PayloadType, payload)
+
+	var sig []byte
+	switch key := s.key.(type) {
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(key, message)
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(message)
+		sig, err = ecdsa.SignASN1(rand.Reader, key, digest[:])
+		if err != nil {
+			return Envelope{}, fmt.Errorf("could not sign statement: %w", err)
+		}
+	}
+
+	return Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []Signature{{
+			KeyID: s.keyID,
+			Sig:   base64.StdEncoding.EncodeToString(sig),
+		}},
+	}, nil
+}
+
+// Verifier verifies envelopes signed by the private key of an ed25519 or
+// ECDSA public key.
+type Verifier struct {
+	key   crypto.PublicKey
+	keyID string
+}
+
+// NewVerifier returns a Verifier for an ed25519 or ECDSA public key.
+func NewVerifier(key crypto.PublicKey) (Verifier, error) {
+	keyID, err := KeyID(key)
+	if err != nil {
+		return Verifier{}, err
+	}
+
+	return Verifier{key: key, keyID: keyID}, nil
+}
+
+// LoadVerifier reads a PEM encoded PKIX public key.
+func LoadVerifier(path string) (Verifier, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return Verifier{}, err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return Verifier{}, fmt.Errorf("could not parse public key: %w", err)
+	}
+
+	return NewVerifier(key)
+}
+
+// Verify checks that envelope was signed by the verifier's key and returns
+// the Statement it holds. It returns a SignatureInvalidError if it was not.
+func (v Verifier) Verify(envelope Envelope) (Statement, error) {
+	if envelope.PayloadType != PayloadType {
+		return Statement{}, fmt.Errorf("unexpected payload type %s", envelope.PayloadType)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return Statement{}, fmt.Errorf("could not decode payload: %w", depErrors.ParseError{Err: err})
+	}
+
+	message := pae(envelope.PayloadType, payload)
+
+	verified := false
+	for _, signature := range envelope.Signatures {
+		if signature.KeyID != "" && signature.KeyID != v.keyID {
+			continue
+		}
+
+		sig, err := base64.StdEncoding.DecodeString(signature.Sig)
+		if err != nil {
+			continue
+		}
+
+		if v.verify(message, sig) {
+			verified = true
+			break
+		}
+	}
+
+	if !verified {
+		return Statement{}, depErrors.SignatureInvalidError{Reason: fmt.Sprintf("envelope is not signed by key %s", v.keyID)}
+	}
+
+	var statement Statement
+	err = json.Unmarshal(payload, &statement)
+	if err != nil {
+		return Statement{}, fmt.Errorf("could not unmarshal statement: %w", depErrors.ParseError{Err: err})
+	}
+
+	if statement.Type != StatementType || statement.PredicateType != PredicateType {
+		return Statement{}, fmt.Errorf("unexpected statement type %s with predicate %s", statement.Type, statement.PredicateType)
+	}
+
+	return statement, nil
+}
+
+func (v Verifier) verify(message, sig []byte) bool {
+	switch key := v.key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, message, sig)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(message)
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	default:
+		return false
+	}
+}
+
+// KeyID returns the hex encoded SHA256 of the PKIX encoding of an ed25519 or
+// ECDSA public key.
+func KeyID(key crypto.PublicKey) (string, error) {
+	switch key.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey:
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", key)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("could not marshal public key: %w", err)
+	}
+
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// pae is the DSSE pre-authentication encoding of a payload, which is what
+// gets signed.
+func pae(payloadType string, payload []byte) []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	buffer.Write(payload)
+	return buffer.Bytes()
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key: %w", err)
+	}
+
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("could not decode PEM key")
+	}
+
+	return block, nil
+}
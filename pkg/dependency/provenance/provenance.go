@@ -0,0 +1,194 @@
+package provenance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+)
+
+const (
+	// StatementType is the in-toto Statement version emitted by NewStatement.
+	StatementType = "https://in-toto.io/Statement/v1"
+
+	// PredicateType is the SLSA provenance version emitted by NewStatement.
+	PredicateType = "https://slsa.dev/provenance/v1"
+
+	// BuildType identifies how dep-server builds a dependency from source,
+	// and so how ExternalParameters are to be interpreted.
+	BuildType = "https://github.com/paketo-buildpacks/dep-server/build-dependency@v1"
+)
+
+// BuildInputs describe a single build of a dependency version. The source is
+// what get-upstream-dependency resolved and the artifact is what
+// build-dependency compiled from it.
+type BuildInputs struct {
+	Dependency   string
+	SourceURI    string
+	SourceSHA256 string
+
+	ArtifactPath string
+	// ArtifactSHA256 is computed from ArtifactPath when empty.
+	ArtifactSHA256 string
+
+	BuilderID    string
+	InvocationID string
+	StartedOn    time.Time
+	FinishedOn   time.Time
+}
+
+type Statement struct {
+	Type          string     `json:"_type"`
+	Subject       []Resource `json:"subject"`
+	PredicateType string     `json:"predicateType"`
+	Predicate     Predicate  `json:"predicate"`
+}
+
+// Resource is an in-toto ResourceDescriptor.
+type Resource struct {
+	Name   string            `json:"name,omitempty"`
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest"`
+}
+
+type Predicate struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string             `json:"buildType"`
+	ExternalParameters   ExternalParameters `json:"externalParameters"`
+	InternalParameters   InternalParameters `json:"internalParameters"`
+	ResolvedDependencies []Resource         `json:"resolvedDependencies"`
+}
+
+type ExternalParameters struct {
+	Dependency string `json:"dependency"`
+	Version    string `json:"version"`
+	SourceURI  string `json:"sourceURI"`
+}
+
+// InternalParameters records how the source was verified before the build.
+type InternalParameters struct {
+	SourceVerification *dependency.Verification `json:"sourceVerification,omitempty"`
+}
+
+type RunDetails struct {
+	Builder  Builder  `json:"builder"`
+	Metadata Metadata `json:"metadata"`
+}
+
+type Builder struct {
+	ID string `json:"id"`
+}
+
+type Metadata struct {
+	InvocationID string     `json:"invocationId,omitempty"`
+	StartedOn    *time.Time `json:"startedOn,omitempty"`
+	FinishedOn   *time.Time `json:"finishedOn,omitempty"`
+}
+
+// NewStatement returns an in-toto Statement with a SLSA provenance predicate
+// for the artifact built from depVersion. The source in inputs defaults to
+// the URI and SHA256 of depVersion.
+func NewStatement(depVersion dependency.DepVersion, inputs BuildInputs) (Statement, error) {
+	if inputs.Dependency == "" {
+		return Statement{}, errors.New("dependency name is required")
+	}
+
+	if inputs.BuilderID == "" {
+		return Statement{}, errors.New("builder id is required")
+	}
+
+	if inputs.ArtifactPath == "" {
+		return Statement{}, errors.New("artifact path is required")
+	}
+
+	sourceURI := inputs.SourceURI
+	if sourceURI == "" {
+		sourceURI = depVersion.URI
+	}
+
+	sourceSHA256 := inputs.SourceSHA256
+	if sourceSHA256 == "" {
+		sourceSHA256 = depVersion.SHA256
+	}
+
+	if sourceURI == "" || sourceSHA256 == "" {
+		return Statement{}, errors.New("source uri and sha256 are required")
+	}
+
+	artifactSHA256 := inputs.ArtifactSHA256
+	if artifactSHA256 == "" {
+		var err error
+		artifactSHA256, err = fileSHA256(inputs.ArtifactPath)
+		if err != nil {
+			return Statement{}, fmt.Errorf("could not get artifact sha256: %w", err)
+		}
+	}
+
+	return Statement{
+		Type: StatementType,
+		Subject: []Resource{{
+			Name:   filepath.Base(inputs.ArtifactPath),
+			Digest: map[string]string{"sha256": artifactSHA256},
+		}},
+		PredicateType: PredicateType,
+		Predicate: Predicate{
+			BuildDefinition: BuildDefinition{
+				BuildType: BuildType,
+				ExternalParameters: ExternalParameters{
+					Dependency: inputs.Dependency,
+					Version:    depVersion.Version,
+					SourceURI:  sourceURI,
+				},
+				InternalParameters: InternalParameters{
+					SourceVerification: depVersion.Verification,
+				},
+				ResolvedDependencies: []Resource{{
+					URI:    sourceURI,
+					Digest: map[string]string{"sha256": sourceSHA256},
+				}},
+			},
+			RunDetails: RunDetails{
+				Builder: Builder{ID: inputs.BuilderID},
+				Metadata: Metadata{
+					InvocationID: inputs.InvocationID,
+					StartedOn:    optionalTime(inputs.StartedOn),
+					FinishedOn:   optionalTime(inputs.FinishedOn),
+				},
+			},
+		},
+	}, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,312 @@
+package provenance_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/provenance"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvenance(t *testing.T) {
+	spec.Run(t, "Provenance", testProvenance, spec.Report(report.Terminal{}))
+}
+
+func testProvenance(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert  = assert.New(t)
+		require = require.New(t)
+
+		depVersion   dependency.DepVersion
+		artifactPath string
+	)
+
+	it.Before(func() {
+		depVersion = dependency.DepVersion{
+			Version: "1.2.3",
+			URI:     "https://example.org/some-dep-1.2.3.tgz",
+			SHA256:  "some-source-sha",
+			Verification: &dependency.Verification{
+				Method:      dependency.VerificationPGP,
+				SourceURL:   "https://example.org/some-dep-1.2.3.tgz.asc",
+				Fingerprint: "some-fingerprint",
+			},
+		}
+
+		artifactPath = filepath.Join(t.TempDir(), "some-dep-1.2.3-linux-x64.tgz")
+		require.NoError(os.WriteFile(artifactPath, []byte("some-artifact"), 0600))
+	})
+
+	when("NewStatement", func() {
+		it("describes the artifact built from the dependency version", func() {
+			started := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+			finished := started.Add(time.Minute)
+
+			statement, err := provenance.NewStatement(depVersion, provenance.BuildInputs{
+				Dependency:     "some-dep",
+				ArtifactPath:   artifactPath,
+				ArtifactSHA256: "some-artifact-sha",
+				BuilderID:      "some-builder",
+				InvocationID:   "some-invocation",
+				StartedOn:      started,
+				FinishedOn:     finished,
+			})
+			require.NoError(err)
+
+			assert.Equal(provenance.StatementType, statement.Type)
+			assert.Equal(provenance.PredicateType, statement.PredicateType)
+			assert.Equal([]provenance.Resource{{
+				Name:   "some-dep-1.2.3-linux-x64.tgz",
+				Digest: map[string]string{"sha256": "some-artifact-sha"},
+			}}, statement.Subject)
+
+			buildDefinition := statement.Predicate.BuildDefinition
+			assert.Equal(provenance.BuildType, buildDefinition.BuildType)
+			assert.Equal(provenance.ExternalParameters{
+				Dependency: "some-dep",
+				Version:    "1.2.3",
+				SourceURI:  "https://example.org/some-dep-1.2.3.tgz",
+			}, buildDefinition.ExternalParameters)
+			assert.Equal(depVersion.Verification, buildDefinition.InternalParameters.SourceVerification)
+			assert.Equal([]provenance.Resource{{
+				URI:    "https://example.org/some-dep-1.2.3.tgz",
+				Digest: map[string]string{"sha256": "some-source-sha"},
+			}}, buildDefinition.ResolvedDependencies)
+
+			runDetails := statement.Predicate.RunDetails
+			assert.Equal("some-builder", runDetails.Builder.ID)
+			assert.Equal("some-invocation", runDetails.Metadata.InvocationID)
+			assert.Equal(&started, runDetails.Metadata.StartedOn)
+			assert.Equal(&finished, runDetails.Metadata.FinishedOn)
+		})
+
+		it("prefers the source given in the build inputs", func() {
+			statement, err := provenance.NewStatement(depVersion, provenance.BuildInputs{
+				Dependency:     "some-dep",
+				SourceURI:      "https://mirror.example.org/some-dep-1.2.3.tgz",
+				SourceSHA256:   "some-other-source-sha",
+				ArtifactPath:   artifactPath,
+				ArtifactSHA256: "some-artifact-sha",
+				BuilderID:      "some-builder",
+			})
+			require.NoError(err)
+
+			assert.Equal([]provenance.Resource{{
+				URI:    "https://mirror.example.org/some-dep-1.2.3.tgz",
+				Digest: map[string]string{"sha256": "some-other-source-sha"},
+			}}, statement.Predicate.BuildDefinition.ResolvedDependencies)
+			assert.Nil(statement.Predicate.RunDetails.Metadata.StartedOn)
+		})
+
+		it("computes the artifact sha256 when it is not given", func() {
+			statement, err := provenance.NewStatement(depVersion, provenance.BuildInputs{
+				Dependency:   "some-dep",
+				ArtifactPath: artifactPath,
+				BuilderID:    "some-builder",
+			})
+			require.NoError(err)
+
+			assert.Equal("c704db7cd00fee1032391ccef39a80d2236db81a5361e80a5ecdf0c58633dbc4", statement.Subject[0].Digest["sha256"])
+		})
+
+		it("returns an error when required inputs are missing", func() {
+			for name, inputs := range map[string]provenance.BuildInputs{
+				"dependency": {ArtifactPath: artifactPath, BuilderID: "some-builder"},
+				"builder":    {Dependency: "some-dep", ArtifactPath: artifactPath},
+				"artifact":   {Dependency: "some-dep", BuilderID: "some-builder"},
+				"missing":    {Dependency: "some-dep", BuilderID: "some-builder", ArtifactPath: filepath.Join(t.TempDir(), "missing")},
+			} {
+				_, err := provenance.NewStatement(depVersion, inputs)
+				assert.Error(err, name)
+			}
+
+			_, err := provenance.NewStatement(dependency.DepVersion{Version: "1.2.3"}, provenance.BuildInputs{
+				Dependency:   "some-dep",
+				ArtifactPath: artifactPath,
+				BuilderID:    "some-builder",
+			})
+			assert.EqualError(err, "source uri and sha256 are required")
+		})
+	})
+
+	when("signing and verifying", func() {
+		var statement provenance.Statement
+
+		it.Before(func() {
+			var err error
+			statement, err = provenance.NewStatement(depVersion, provenance.BuildInputs{
+				Dependency:   "some-dep",
+				ArtifactPath: artifactPath,
+				BuilderID:    "some-builder",
+			})
+			require.NoError(err)
+		})
+
+		for name, generate := range map[string]func() (crypto.Signer, error){
+			"ed25519": func() (crypto.Signer, error) {
+				_, key, err := ed25519.GenerateKey(rand.Reader)
+				return key, err
+			},
+			"ecdsa": func() (crypto.Signer, error) {
+				return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			},
+		} {
+			generate := generate
+
+			when(name, func() {
+				var (
+					key    crypto.Signer
+					signer provenance.Signer
+				)
+
+				it.Before(func() {
+					var err error
+					key, err = generate()
+					require.NoError(err)
+
+					signer, err = provenance.NewSigner(key)
+					require.NoError(err)
+				})
+
+				it("round trips the statement through a DSSE envelope", func() {
+					envelope, err := signer.Sign(statement)
+					require.NoError(err)
+
+					assert.Equal(provenance.PayloadType, envelope.PayloadType)
+					require.Len(envelope.Signatures, 1)
+					assert.Equal(signer.KeyID(), envelope.Signatures[0].KeyID)
+
+					verifier, err := provenance.NewVerifier(key.Public())
+					require.NoError(err)
+
+					verified, err := verifier.Verify(envelope)
+					require.NoError(err)
+					assert.Equal(statement, verified)
+				})
+
+				it("rejects a tampered payload", func() {
+					envelope, err := signer.Sign(statement)
+					require.NoError(err)
+
+					statement.Subject[0].Digest["sha256"] = "some-other-sha"
+					tampered, err := signer.Sign(statement)
+					require.NoError(err)
+					envelope.Payload = tampered.Payload
+
+					verifier, err := provenance.NewVerifier(key.Public())
+					require.NoError(err)
+
+					_, err = verifier.Verify(envelope)
+					assert.True(errors.Is(err, depErrors.SignatureInvalidError{Reason: "envelope is not signed by key " + signer.KeyID()}))
+				})
+
+				it("rejects an envelope signed by another key", func() {
+					envelope, err := signer.Sign(statement)
+					require.NoError(err)
+
+					otherKey, err := generate()
+					require.NoError(err)
+
+					verifier, err := provenance.NewVerifier(otherKey.Public())
+					require.NoError(err)
+
+					_, err = verifier.Verify(envelope)
+					assert.Error(err)
+
+					envelope.Signatures[0].KeyID = ""
+					_, err = verifier.Verify(envelope)
+					assert.Error(err)
+				})
+			})
+		}
+
+		it("rejects an envelope with another payload type", func() {
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			require.NoError(err)
+
+			signer, err := provenance.NewSigner(key)
+			require.NoError(err)
+
+			envelope, err := signer.Sign(statement)
+			require.NoError(err)
+			envelope.PayloadType = "application/json"
+
+			verifier, err := provenance.NewVerifier(key.Public())
+			require.NoError(err)
+
+			_, err = verifier.Verify(envelope)
+			assert.EqualError(err, "unexpected payload type application/json")
+		})
+
+		it("rejects a payload that is not base64", func() {
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			require.NoError(err)
+
+			verifier, err := provenance.NewVerifier(key.Public())
+			require.NoError(err)
+
+			_, err = verifier.Verify(provenance.Envelope{PayloadType: provenance.PayloadType, Payload: "!"})
+			assert.True(errors.As(err, &depErrors.ParseError{}))
+		})
+
+		it("loads keys from PEM files", func() {
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			require.NoError(err)
+
+			dir := t.TempDir()
+
+			sec1, err := x509.MarshalECPrivateKey(key)
+			require.NoError(err)
+			privatePath := filepath.Join(dir, "key.pem")
+			require.NoError(os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}), 0600))
+
+			pkix, err := x509.MarshalPKIXPublicKey(key.Public())
+			require.NoError(err)
+			publicPath := filepath.Join(dir, "key.pub")
+			require.NoError(os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}), 0600))
+
+			signer, err := provenance.LoadSigner(privatePath)
+			require.NoError(err)
+
+			verifier, err := provenance.LoadVerifier(publicPath)
+			require.NoError(err)
+
+			envelope, err := signer.Sign(statement)
+			require.NoError(err)
+
+			_, err = verifier.Verify(envelope)
+			assert.NoError(err)
+
+			payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+			require.NoError(err)
+			assert.Contains(string(payload), provenance.PredicateType)
+		})
+
+		it("returns an error for a file that is not a PEM key", func() {
+			path := filepath.Join(t.TempDir(), "key.pem")
+			require.NoError(os.WriteFile(path, []byte("not a key"), 0600))
+
+			_, err := provenance.LoadSigner(path)
+			assert.EqualError(err, "could not decode PEM key")
+
+			_, err = provenance.LoadVerifier(path)
+			assert.EqualError(err, "could not decode PEM key")
+		})
+	})
+}
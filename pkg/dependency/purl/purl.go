@@ -6,22 +6,96 @@ import (
 	"github.com/package-url/packageurl-go"
 )
 
+// Package identifies a dependency version in the ecosystem that publishes it,
+// so that scanners can match it against the advisories of that ecosystem.
+type Package struct {
+	// Type is the purl type, such as pypi or gem. Packages without a Type are
+	// generic.
+	Type      string
+	Namespace string
+	Name      string
+	Version   string
+	// Checksum is the sha256 of the source the dependency was built from.
+	Checksum string
+	// DownloadURL is the URL of the source the dependency was built from.
+	DownloadURL string
+}
+
+// Generic returns a package that does not belong to any ecosystem.
+func Generic(name, version string) Package {
+	return Package{Type: packageurl.TypeGeneric, Name: name, Version: version}
+}
+
+// PyPI returns a package published to the Python Package Index.
+func PyPI(name, version string) Package {
+	return Package{Type: packageurl.TypePyPi, Name: name, Version: version}
+}
+
+// Gem returns a package published to RubyGems.
+func Gem(name, version string) Package {
+	return Package{Type: packageurl.TypeGem, Name: name, Version: version}
+}
+
+// NPM returns a package published to the npm registry.
+func NPM(name, version string) Package {
+	return Package{Type: packageurl.TypeNPM, Name: name, Version: version}
+}
+
+// Composer returns a package published to Packagist, whose names are
+// vendor/package.
+func Composer(vendor, name, version string) Package {
+	return Package{Type: packageurl.TypeComposer, Namespace: vendor, Name: name, Version: version}
+}
+
+// GitHub returns a package released from a GitHub repository.
+func GitHub(owner, repo, version string) Package {
+	return Package{Type: packageurl.TypeGithub, Namespace: owner, Name: repo, Version: version}
+}
+
+// Golang returns a Go module. The Go toolchain itself is the stdlib module.
+func Golang(module, version string) Package {
+	return Package{Type: packageurl.TypeGolang, Name: module, Version: version}
+}
+
+// NuGet returns a package published to NuGet.
+func NuGet(name, version string) Package {
+	return Package{Type: packageurl.TypeNuget, Name: name, Version: version}
+}
+
+// WithSource returns the package with the checksum and download URL of the
+// source it was built from.
+func (p Package) WithSource(checksum, downloadURL string) Package {
+	p.Checksum = checksum
+	p.DownloadURL = downloadURL
+	return p
+}
+
 type PURLGenerator struct{}
 
 func NewPURLGenerator() PURLGenerator {
 	return PURLGenerator{}
 }
 
-func (PURLGenerator) Generate(name, version, sourceSHA, source string) string {
+func (PURLGenerator) Generate(pkg Package) string {
+	purlType := pkg.Type
+	if purlType == "" {
+		purlType = packageurl.TypeGeneric
+	}
+
+	qualifiers := map[string]string{}
+	if pkg.Checksum != "" {
+		qualifiers["checksum"] = pkg.Checksum
+	}
+	if pkg.DownloadURL != "" {
+		qualifiers["download_url"] = pkg.DownloadURL
+	}
+
 	purl := packageurl.NewPackageURL(
-		packageurl.TypeGeneric,
-		"",
-		name,
-		version,
-		packageurl.QualifiersFromMap(map[string]string{
-			"checksum":     sourceSHA,
-			"download_url": source,
-		}),
+		purlType,
+		pkg.Namespace,
+		pkg.Name,
+		pkg.Version,
+		packageurl.QualifiersFromMap(qualifiers),
 		"",
 	)
 
@@ -26,8 +26,28 @@ func testPURLGenerator(t *testing.T, context spec.G, it spec.S) {
 		})
 
 		it("returns a PURL", func() {
-			purl := purlGenerator.Generate("dependencyName", "dependencyVersion", "dependencySourceSHA", "http://dependencySource")
-			Expect(purl).To(Equal("pkg:generic/dependencyName@dependencyVersion?checksum=dependencySourceSHA&download_url=http://dependencySource"))
+			actual := purlGenerator.Generate(purl.Generic("dependencyName", "dependencyVersion").WithSource("dependencySourceSHA", "http://dependencySource"))
+			Expect(actual).To(Equal("pkg:generic/dependencyName@dependencyVersion?checksum=dependencySourceSHA&download_url=http://dependencySource"))
+		})
+
+		it("falls back to a generic PURL for packages without a type", func() {
+			actual := purlGenerator.Generate(purl.Package{Name: "dependencyName", Version: "dependencyVersion"})
+			Expect(actual).To(Equal("pkg:generic/dependencyName@dependencyVersion"))
+		})
+
+		it("returns the PURL of the ecosystem that publishes the package", func() {
+			Expect(purlGenerator.Generate(purl.PyPI("pip", "22.0.4"))).To(Equal("pkg:pypi/pip@22.0.4"))
+			Expect(purlGenerator.Generate(purl.Gem("bundler", "2.3.10"))).To(Equal("pkg:gem/bundler@2.3.10"))
+			Expect(purlGenerator.Generate(purl.NPM("yarn", "1.22.18"))).To(Equal("pkg:npm/yarn@1.22.18"))
+			Expect(purlGenerator.Generate(purl.Composer("composer", "composer", "2.3.5"))).To(Equal("pkg:composer/composer/composer@2.3.5"))
+			Expect(purlGenerator.Generate(purl.GitHub("krallin", "tini", "v0.19.0"))).To(Equal("pkg:github/krallin/tini@v0.19.0"))
+			Expect(purlGenerator.Generate(purl.Golang("stdlib", "1.18.1"))).To(Equal("pkg:golang/stdlib@1.18.1"))
+			Expect(purlGenerator.Generate(purl.NuGet("Microsoft.NETCore.App.Runtime.linux-x64", "6.0.4"))).To(Equal("pkg:nuget/Microsoft.NETCore.App.Runtime.linux-x64@6.0.4"))
+		})
+
+		it("keeps the source qualifiers of ecosystem PURLs", func() {
+			actual := purlGenerator.Generate(purl.Gem("bundler", "2.3.10").WithSource("some-sha", "https://rubygems.org/gems/bundler-2.3.10.gem"))
+			Expect(actual).To(Equal("pkg:gem/bundler@2.3.10?checksum=some-sha&download_url=https://rubygems.org/gems/bundler-2.3.10.gem"))
 		})
 
 	})
@@ -8,6 +8,10 @@ import (
 	"time"
 
 	"github.com/Masterminds/semver"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type PyPi struct {
@@ -18,6 +22,8 @@ type PyPi struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
 }
 
 type PyPiRelease struct {
@@ -54,11 +60,32 @@ func (p PyPi) GetDependencyVersion(version string) (DepVersion, error) {
 
 	for _, release := range releases {
 		if release.Version == version {
+			artifactPath, err := p.artifactStore.Fetch(release.URI, verifySHA256(release.SHA256)...)
+			if err != nil {
+				return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+			}
+			defer p.artifactStore.Release(artifactPath)
+
+			checksums, err := p.checksummer.GetChecksums(artifactPath, checksumAlgorithms...)
+			if err != nil {
+				return DepVersion{}, fmt.Errorf("could not get checksums: %w", err)
+			}
+			release.SHA256 = checksums["sha256"]
+			release.Checksum = prefixedChecksum(checksums)
+			release.Checksums = checksums
+
+			release.Licenses, release.LicenseExpression, release.LicenseSources, err = resolveLicenses(p.licenseRetriever, "pypi", artifactPath,
+				pypiLicenses(p.cache, p.webClient, p.productName, version),
+			)
+			if err != nil {
+				return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
+			}
+
 			return release, nil
 		}
 	}
 
-	return DepVersion{}, fmt.Errorf("could not find release with version %s: %w", version, err)
+	return DepVersion{}, depErrors.VersionNotFoundError{Version: version}
 }
 
 func (p PyPi) GetReleaseDate(version string) (*time.Time, error) {
@@ -73,11 +100,12 @@ func (p PyPi) GetReleaseDate(version string) (*time.Time, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("could not find release date for version %s", version)
+	return nil, fmt.Errorf("could not find release date: %w", depErrors.VersionNotFoundError{Version: version})
 }
 
 func (p PyPi) getReleases() ([]DepVersion, error) {
-	body, err := p.webClient.Get(fmt.Sprintf("https://pypi.org/pypi/%s/json", p.productName))
+	releasesURL := fmt.Sprintf("https://pypi.org/pypi/%s/json", p.productName)
+	body, err := cachedGet(p.cache, p.webClient, releasesURL)
 	if err != nil {
 		return nil, fmt.Errorf("could not get project metadata: %w", err)
 	}
@@ -92,7 +120,7 @@ func (p PyPi) getReleases() ([]DepVersion, error) {
 	}
 	err = json.Unmarshal(body, &productMetadata)
 	if err != nil {
-		return nil, fmt.Errorf("could not unmarshal project metadata: %w", err)
+		return nil, fmt.Errorf("could not unmarshal project metadata: %w", depErrors.ParseError{Err: err})
 	}
 
 	var releases []DepVersion
@@ -107,38 +135,36 @@ func (p PyPi) getReleases() ([]DepVersion, error) {
 
 			uploadTime, err := time.Parse(time.RFC3339, release.UploadTime)
 			if err != nil {
-				return nil, fmt.Errorf("could not parse upload time '%s' as date for version %s: %w", release.UploadTime, version, err)
+				return nil, fmt.Errorf("could not parse upload time '%s' as date for version %s: %w", release.UploadTime, version, depErrors.ParseError{Err: err})
 			}
 
 			if release.Digests["sha256"] == "" {
 				return nil, fmt.Errorf("could not find sha256 for version %s", version)
 			}
 
-			var cpe string
+			var cpeName string
 			switch p.productName {
 			case "pip":
-				cpe = fmt.Sprintf("cpe:2.3:a:pypa:pip:%s:*:*:*:*:python:*:*", version)
+				cpeName = cpe.Application("pypa", "pip", version).WithTargetSoftware("python").String()
 			case "pipenv":
-				cpe = fmt.Sprintf("cpe:2.3:a:pypa:pipenv:%s:*:*:*:*:python:*:*", version)
+				cpeName = cpe.Application("pypa", "pipenv", version).WithTargetSoftware("python").String()
 			case "poetry":
-				cpe = fmt.Sprintf("cpe:2.3:a:python-poetry:poetry:%s:*:*:*:*:python:*:*", version)
+				cpeName = cpe.Application("python-poetry", "poetry", version).WithTargetSoftware("python").String()
 			default:
 				// do nothing
 			}
 
-			licenses, err := p.licenseRetriever.LookupLicenses("pypi", release.URL)
-			if err != nil {
-				return nil, fmt.Errorf("could not get retrieve licenses: %w", err)
-			}
-
+			checksums := map[string]string{"sha256": release.Digests["sha256"]}
 			releases = append(releases, DepVersion{
-				Version:     version,
-				URI:         release.URL,
-				SHA256:      release.Digests["sha256"],
-				ReleaseDate: &uploadTime,
-				CPE:         cpe,
-				PURL:        p.purlGenerator.Generate(p.productName, version, release.Digests["sha256"], release.URL),
-				Licenses:    licenses,
+				Version:      version,
+				URI:          release.URL,
+				SHA256:       release.Digests["sha256"],
+				Checksum:     prefixedChecksum(checksums),
+				Checksums:    checksums,
+				ReleaseDate:  &uploadTime,
+				CPE:          cpeName,
+				PURL:         p.purlGenerator.Generate(purl.PyPI(p.productName, version).WithSource(release.Digests["sha256"], release.URL)),
+				Verification: checksumVerification(VerificationSHA256, releasesURL),
 			})
 		}
 	}
@@ -155,12 +181,12 @@ func (p PyPi) sortReleases(releases []DepVersion) error {
 
 		semver1, err := semver.NewVersion(releases[i].Version)
 		if err != nil {
-			sortErr = fmt.Errorf("could not parse '%s' as semver", releases[i].Version)
+			sortErr = depErrors.ParseError{Err: fmt.Errorf("could not parse '%s' as semver", releases[i].Version)}
 			return false
 		}
 		semver2, err := semver.NewVersion(releases[j].Version)
 		if err != nil {
-			sortErr = fmt.Errorf("could not parse '%s' as semver", releases[j].Version)
+			sortErr = depErrors.ParseError{Err: fmt.Errorf("could not parse '%s' as semver", releases[j].Version)}
 			return false
 		}
 		return semver1.GreaterThan(semver2)
@@ -25,6 +25,7 @@ func testPyPi(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeGithubClient     *dependencyfakes.FakeGithubClient
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		pypi                 dependency.Dependency
@@ -35,11 +36,13 @@ func testPyPi(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		pypi, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("pip")
+		pypi, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("pip")
 		require.NoError(err)
 	})
 
@@ -133,32 +136,91 @@ func testPyPi(t *testing.T, when spec.G, it spec.S) {
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/pip@2.0.0?checksum=some-sha-256gz&download_url=some-url")
 
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
+
 			actualDep, err := pypi.GetDependencyVersion("2.0.0")
 			require.NoError(err)
 
-			assert.Equal(3, fakeLicenseRetriever.LookupLicensesCallCount())
+			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 			assert.Equal(3, fakePURLGenerator.GenerateCallCount())
+
+			urlArg, _ := fakeArtifactStore.FetchArgsForCall(0)
+			assert.Equal("some-url", urlArg)
+
+			_, artifactPathArg := fakeLicenseRetriever.LookupLicensesArgsForCall(0)
+			assert.Equal("some-artifact-path", artifactPathArg)
 			expectedReleaseDate := time.Date(2010, 5, 1, 0, 0, 0, 0, time.UTC)
 			expectedDep := dependency.DepVersion{
-				Version:         "2.0.0",
-				URI:             "some-url",
-				SHA256:          "some-sha256",
+				Version:  "2.0.0",
+				URI:      "some-url",
+				SHA256:   "some-sha256",
+				Checksum: "sha256:some-sha256",
+				Checksums: map[string]string{
+					"sha256": "some-sha256",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: nil,
 				CPE:             "cpe:2.3:a:pypa:pip:2.0.0:*:*:*:*:python:*:*",
 				PURL:            "pkg:generic/pip@2.0.0?checksum=some-sha-256gz&download_url=some-url",
 				Licenses:        []string{"MIT", "MIT-2"},
+				LicenseSources:  map[string]dependency.LicenseSource{"MIT": dependency.LicenseSourceFileScan, "MIT-2": dependency.LicenseSourceFileScan},
+				Verification:    &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://pypi.org/pypi/pip/json"},
 			}
 			assert.Equal(expectedDep, actualDep)
 
-			urlArg, _ := fakeWebClient.GetArgsForCall(0)
+			urlArg, _ = fakeWebClient.GetArgsForCall(0)
 			assert.Equal("https://pypi.org/pypi/pip/json", urlArg)
 		})
 
+		when("PyPI declares the license of the version", func() {
+			it("uses the license classifiers instead of scanning the sdist", func() {
+				fakeWebClient.GetReturnsOnCall(0, []byte(`{"releases": {"2.0.0": [
+  {"packagetype": "sdist", "upload_time_iso_8601": "2010-05-01T00:00:00.000000Z", "digests": {"sha256": "some-sha256"}, "url": "some-url"}
+]}}`), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte(`{"info": {
+  "license": "Copyright (c) some-authors\n\nPermission is hereby granted, free of charge, ...",
+  "classifiers": [
+    "Development Status :: 5 - Production/Stable",
+    "License :: OSI Approved",
+    "License :: OSI Approved :: MIT License",
+    "Programming Language :: Python"
+  ]
+}}`), nil)
+
+				actualDep, err := pypi.GetDependencyVersion("2.0.0")
+				require.NoError(err)
+
+				assert.Equal(0, fakeLicenseRetriever.LookupLicensesCallCount())
+				assert.Equal([]string{"MIT"}, actualDep.Licenses)
+				assert.Equal(map[string]dependency.LicenseSource{"MIT": dependency.LicenseSourcePyPI}, actualDep.LicenseSources)
+
+				urlArg, _ := fakeWebClient.GetArgsForCall(1)
+				assert.Equal("https://pypi.org/pypi/pip/2.0.0/json", urlArg)
+			})
+
+			it("prefers the license expression", func() {
+				fakeWebClient.GetReturnsOnCall(0, []byte(`{"releases": {"2.0.0": [
+  {"packagetype": "sdist", "upload_time_iso_8601": "2010-05-01T00:00:00.000000Z", "digests": {"sha256": "some-sha256"}, "url": "some-url"}
+]}}`), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte(`{"info": {
+  "license_expression": "MIT OR Apache-2.0",
+  "classifiers": ["License :: OSI Approved :: MIT License"]
+}}`), nil)
+
+				actualDep, err := pypi.GetDependencyVersion("2.0.0")
+				require.NoError(err)
+
+				assert.Equal([]string{"Apache-2.0", "MIT"}, actualDep.Licenses)
+				assert.Equal("MIT OR Apache-2.0", actualDep.LicenseExpression)
+				assert.Equal(map[string]dependency.LicenseSource{"Apache-2.0": dependency.LicenseSourcePyPI, "MIT": dependency.LicenseSourcePyPI}, actualDep.LicenseSources)
+			})
+		})
+
 		when("the product is pipenv", func() {
 			it.Before(func() {
 				var err error
-				pypi, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("pipenv")
+				pypi, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("pipenv")
 				require.NoError(err)
 			})
 
@@ -195,7 +257,7 @@ func testPyPi(t *testing.T, when spec.G, it spec.S) {
 		when("the product is poetry", func() {
 			it.Before(func() {
 				var err error
-				pypi, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("poetry")
+				pypi, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("poetry")
 				require.NoError(err)
 			})
 
@@ -3,23 +3,28 @@ package dependency
 import (
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Python struct {
-	checksummer      Checksummer
-	fileSystem       FileSystem
-	webClient        WebClient
-	licenseRetriever LicenseRetriever
-	purlGenerator    PURLGenerator
+	checksummer        Checksummer
+	fileSystem         FileSystem
+	webClient          WebClient
+	licenseRetriever   LicenseRetriever
+	purlGenerator      PURLGenerator
+	cache              Cache
+	artifactStore      ArtifactStore
+	checksumExceptions ChecksumExceptions
 }
 
 func (p Python) GetAllVersionRefs() ([]string, error) {
-	body, err := p.webClient.Get("https://www.python.org/downloads/")
+	body, err := cachedGet(p.cache, p.webClient, "https://www.python.org/downloads/")
 	if err != nil {
 		return nil, fmt.Errorf("could not get python downloads: %w", err)
 	}
@@ -43,17 +48,24 @@ func (p Python) GetDependencyVersion(version string) (DepVersion, error) {
 		return DepVersion{}, fmt.Errorf("could not get release metadata: %w", err)
 	}
 
-	sha256, err := p.getDependencySHA256(sourceURI, potentialMD5s, version)
+	artifactPath, err := p.artifactStore.Fetch(sourceURI)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer p.artifactStore.Release(artifactPath)
+
+	checksums, verification, err := p.getDependencyChecksums(artifactPath, potentialMD5s, version)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get dependency SHA256: %w", err)
 	}
+	sha256 := checksums["sha256"]
 
 	deprecationDate, err := p.getReleaseDeprecationDate(version)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get release deprecation date: %w", err)
 	}
 
-	licenses, err := p.licenseRetriever.LookupLicenses("python", sourceURI)
+	licenses, err := p.licenseRetriever.LookupLicenses("python", artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
@@ -62,11 +74,14 @@ func (p Python) GetDependencyVersion(version string) (DepVersion, error) {
 		Version:         version,
 		URI:             sourceURI,
 		SHA256:          sha256,
+		Checksum:        prefixedChecksum(checksums),
+		Checksums:       checksums,
 		ReleaseDate:     releaseDate,
 		DeprecationDate: deprecationDate,
-		CPE:             fmt.Sprintf("cpe:2.3:a:python:python:%s:*:*:*:*:*:*:*", version),
-		PURL:            p.purlGenerator.Generate("python", version, sha256, sourceURI),
+		CPE:             cpe.Application("python", "python", version).String(),
+		PURL:            p.purlGenerator.Generate(purl.Generic("python", version).WithSource(sha256, sourceURI)),
 		Licenses:        licenses,
+		Verification:    verification,
 	}, nil
 }
 
@@ -79,8 +94,7 @@ func (p Python) GetReleaseDate(version string) (*time.Time, error) {
 }
 
 func (p Python) getReleaseMetadata(version string) (string, *time.Time, []string, error) {
-	versionWithoutPeriods := strings.ReplaceAll(version, ".", "")
-	body, err := p.webClient.Get(fmt.Sprintf("https://www.python.org/downloads/release/python-%s/", versionWithoutPeriods))
+	body, err := p.webClient.Get(p.releasePageURL(version))
 	if err != nil {
 		return "", nil, nil, fmt.Errorf("could not get python downloads: %w", err)
 	}
@@ -132,61 +146,62 @@ func (p Python) getReleaseMetadata(version string) (string, *time.Time, []string
 	}
 
 	if sourceURI == "" {
-		return "", nil, nil, errors.New("could not find source URI on download page")
+		return "", nil, nil, depErrors.ParseError{Err: errors.New("could not find source URI on download page")}
 	}
 	if len(potentialMD5s) == 0 {
-		return "", nil, nil, errors.New("could not find MD5 on download page")
+		return "", nil, nil, depErrors.ParseError{Err: errors.New("could not find MD5 on download page")}
 	}
 	if releaseDateDayAndYear == "" || releaseDateMonth == "" {
-		return "", nil, nil, errors.New("could not find release date on download page")
+		return "", nil, nil, depErrors.ParseError{Err: errors.New("could not find release date on download page")}
 	}
 
 	releaseDate, err := time.Parse("Jan 2, 2006", fmt.Sprintf("%s %s", releaseDateMonth, releaseDateDayAndYear))
 	if err != nil {
-		return "", nil, nil, fmt.Errorf("could not parse release date: %w", err)
+		return "", nil, nil, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 	}
 
 	return sourceURI, &releaseDate, potentialMD5s, nil
 }
 
-func (p Python) getDependencySHA256(sourceURI string, potentialMD5s []string, version string) (string, error) {
-	tempDir, err := os.MkdirTemp("", "python")
-	if err != nil {
-		return "", fmt.Errorf("could not make temp dir: %w", err)
-	}
-	defer os.RemoveAll(tempDir)
-
-	dependencyPath := filepath.Join(tempDir, filepath.Base(sourceURI))
-
-	err = p.webClient.Download(sourceURI, dependencyPath)
-	if err != nil {
-		return "", fmt.Errorf("could not download dependency: %w", err)
+func (p Python) getDependencyChecksums(dependencyPath string, potentialMD5s []string, version string) (map[string]string, *Verification, error) {
+	if exception, ok := p.checksumExceptions.Lookup("python", version); ok {
+		return exceptionChecksums(p.checksummer, exception, dependencyPath)
 	}
 
-	if !p.dependencyVersionHasWrongChecksum(version) {
-		verifiedMD5 := false
-		for _, md5 := range potentialMD5s {
-			if err = p.checksummer.VerifyMD5(dependencyPath, md5); err == nil {
-				verifiedMD5 = true
-				break
-			}
+	verifyErr := depErrors.ChecksumMismatchError{Algorithm: "MD5", Expected: strings.Join(potentialMD5s, ",")}
+	verifiedMD5 := false
+	for _, md5 := range potentialMD5s {
+		err := p.checksummer.VerifyMD5(dependencyPath, md5)
+		if err == nil {
+			verifiedMD5 = true
+			break
 		}
 
-		if !verifiedMD5 {
-			return "", fmt.Errorf("md5 did not match any of [%s]", strings.Join(potentialMD5s, ","))
+		var mismatch depErrors.ChecksumMismatchError
+		if !errors.As(err, &mismatch) {
+			return nil, nil, fmt.Errorf("could not verify md5: %w", err)
 		}
+		verifyErr.Actual = mismatch.Actual
+	}
+
+	if !verifiedMD5 {
+		return nil, nil, fmt.Errorf("md5 did not match any of [%s]: %w", strings.Join(potentialMD5s, ","), verifyErr)
 	}
 
-	sha256, err := p.checksummer.GetSHA256(dependencyPath)
+	checksums, err := p.checksummer.GetChecksums(dependencyPath, checksumAlgorithms...)
 	if err != nil {
-		return "", fmt.Errorf("could not get sha256: %w", err)
+		return nil, nil, fmt.Errorf("could not get sha256: %w", err)
 	}
 
-	return sha256, nil
+	return checksums, checksumVerification(VerificationMD5, p.releasePageURL(version)), nil
+}
+
+func (p Python) releasePageURL(version string) string {
+	return fmt.Sprintf("https://www.python.org/downloads/release/python-%s/", strings.ReplaceAll(version, ".", ""))
 }
 
 func (p Python) getReleaseDeprecationDate(version string) (*time.Time, error) {
-	body, err := p.webClient.Get("https://www.python.org/downloads/")
+	body, err := cachedGet(p.cache, p.webClient, "https://www.python.org/downloads/")
 	if err != nil {
 		return nil, fmt.Errorf("could not get python downloads: %w", err)
 	}
@@ -202,7 +217,7 @@ func (p Python) getReleaseDeprecationDate(version string) (*time.Time, error) {
 			if len(matches) == 2 {
 				deprecationDate, err := time.Parse("2006-01-02", matches[1])
 				if err != nil {
-					return nil, fmt.Errorf("could not parse deprecation date: %w", err)
+					return nil, fmt.Errorf("could not parse deprecation date: %w", depErrors.ParseError{Err: err})
 				}
 
 				return &deprecationDate, nil
@@ -213,7 +228,7 @@ func (p Python) getReleaseDeprecationDate(version string) (*time.Time, error) {
 			if len(matches) == 2 {
 				deprecationDate, err := time.Parse("2006-01", matches[1])
 				if err != nil {
-					return nil, fmt.Errorf("could not parse deprecation date: %w", err)
+					return nil, fmt.Errorf("could not parse deprecation date: %w", depErrors.ParseError{Err: err})
 				}
 
 				return &deprecationDate, nil
@@ -223,12 +238,3 @@ func (p Python) getReleaseDeprecationDate(version string) (*time.Time, error) {
 
 	return nil, nil
 }
-
-func (p Python) dependencyVersionHasWrongChecksum(version string) bool {
-	versionsWithMissingChecksum := map[string]bool{
-		"3.1.0": true,
-	}
-
-	_, shouldBeIgnored := versionsWithMissingChecksum[version]
-	return shouldBeIgnored
-}
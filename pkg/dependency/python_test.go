@@ -13,6 +13,7 @@ import (
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/dependencyfakes"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 )
 
 func TestPython(t *testing.T) {
@@ -26,6 +27,7 @@ func testPython(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer      *dependencyfakes.FakeChecksummer
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		python               dependency.Dependency
@@ -35,11 +37,13 @@ func testPython(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		python, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("python")
+		python, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("python")
 		require.NoError(err)
 	})
 
@@ -76,7 +80,7 @@ func testPython(t *testing.T, when spec.G, it spec.S) {
 			fakeWebClient.GetReturnsOnCall(0, []byte(python378DownloadPage), nil)
 			fakeWebClient.GetReturnsOnCall(1, []byte(fullPythonIndex), nil)
 
-			fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/python@3.7.8?checksum=some-sha-256&download_url=https://www.python.org")
 
@@ -88,14 +92,20 @@ func testPython(t *testing.T, when spec.G, it spec.S) {
 			expectedReleaseDate := time.Date(2020, 6, 27, 0, 0, 0, 0, time.UTC)
 			expectedDeprecationDate := time.Date(2023, 6, 27, 0, 0, 0, 0, time.UTC)
 			expectedDepVersion := dependency.DepVersion{
-				Version:         "3.7.8",
-				URI:             "https://www.python.org/ftp/python/3.7.8/Python-3.7.8.tgz",
-				SHA256:          "some-sha256",
+				Version:  "3.7.8",
+				URI:      "https://www.python.org/ftp/python/3.7.8/Python-3.7.8.tgz",
+				SHA256:   "some-sha256",
+				Checksum: "sha256:some-sha256",
+				Checksums: map[string]string{
+					"sha256": "some-sha256",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: &expectedDeprecationDate,
 				CPE:             "cpe:2.3:a:python:python:3.7.8:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/python@3.7.8?checksum=some-sha-256&download_url=https://www.python.org",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Verification:    &dependency.Verification{Method: dependency.VerificationMD5, SourceURL: "https://www.python.org/downloads/release/python-378/"},
 			}
 
 			assert.Equal(expectedDepVersion, actualDepVersion)
@@ -163,8 +173,8 @@ func testPython(t *testing.T, when spec.G, it spec.S) {
 			it("uses the one from the pre block", func() {
 				fakeWebClient.GetReturnsOnCall(0, []byte(python333DownloadPage), nil)
 				fakeWebClient.GetReturnsOnCall(1, []byte(fullPythonIndex), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
-				fakeChecksummer.VerifyMD5ReturnsOnCall(0, errors.New("some-error"))
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
+				fakeChecksummer.VerifyMD5ReturnsOnCall(0, depErrors.ChecksumMismatchError{Algorithm: "MD5", Expected: "some-md5", Actual: "some-other-md5"})
 
 				depVersion, err := python.GetDependencyVersion("3.3.3")
 				require.NoError(err)
@@ -185,8 +195,8 @@ func testPython(t *testing.T, when spec.G, it spec.S) {
 			it("uses the one from the pre block", func() {
 				fakeWebClient.GetReturnsOnCall(0, []byte(python255DownloadPage), nil)
 				fakeWebClient.GetReturnsOnCall(1, []byte(fullPythonIndex), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
-				fakeChecksummer.VerifyMD5ReturnsOnCall(0, errors.New("some-error"))
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
+				fakeChecksummer.VerifyMD5ReturnsOnCall(0, depErrors.ChecksumMismatchError{Algorithm: "MD5", Expected: "some-md5", Actual: "some-other-md5"})
 
 				depVersion, err := python.GetDependencyVersion("2.5.5")
 				require.NoError(err)
@@ -203,11 +213,37 @@ func testPython(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("none of the md5s match", func() {
+			it("returns a ChecksumMismatchError", func() {
+				fakeWebClient.GetReturnsOnCall(0, []byte(python378DownloadPage), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte(fullPythonIndex), nil)
+				fakeChecksummer.VerifyMD5Returns(depErrors.ChecksumMismatchError{Algorithm: "MD5", Expected: "some-md5", Actual: "some-other-md5"})
+
+				_, err := python.GetDependencyVersion("3.7.8")
+
+				var mismatch depErrors.ChecksumMismatchError
+				require.True(errors.As(err, &mismatch), "expected a ChecksumMismatchError, got %v", err)
+				assert.Equal("some-other-md5", mismatch.Actual)
+			})
+		})
+
+		when("the md5 cannot be computed", func() {
+			it("returns the error without reporting a mismatch", func() {
+				fakeWebClient.GetReturnsOnCall(0, []byte(python378DownloadPage), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte(fullPythonIndex), nil)
+				fakeChecksummer.VerifyMD5Returns(errors.New("some-read-error"))
+
+				_, err := python.GetDependencyVersion("3.7.8")
+				assert.ErrorContains(err, "could not verify md5: some-read-error")
+				assert.False(errors.As(err, &depErrors.ChecksumMismatchError{}))
+			})
+		})
+
 		when("the MD5s are known to be wrong", func() {
 			it("does not try to verify the MD5", func() {
 				fakeWebClient.GetReturnsOnCall(0, []byte(python255DownloadPage), nil)
 				fakeWebClient.GetReturnsOnCall(1, []byte(fullPythonIndex), nil)
-				fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 				depVersion, err := python.GetDependencyVersion("3.1.0")
 				require.NoError(err)
@@ -217,13 +253,59 @@ func testPython(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(0, fakeChecksummer.VerifyMD5CallCount())
 			})
 		})
+
+		when("a checksum exception pins a sha256", func() {
+			it.Before(func() {
+				exceptions := dependency.ChecksumExceptions{
+					Version: 1,
+					Exceptions: []dependency.ChecksumException{{
+						Dependency: "python",
+						Version:    "3.7.8",
+						Reason:     "some-reason",
+						SHA256:     "some-pinned-sha256",
+					}},
+				}
+
+				var err error
+				python, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator,
+					dependency.WithArtifactStore(fakeArtifactStore),
+					dependency.WithChecksumExceptions(exceptions),
+				).NewDependency("python")
+				require.NoError(err)
+			})
+
+			it("verifies the artifact against the pinned sha256 instead of the MD5", func() {
+				fakeWebClient.GetReturnsOnCall(0, []byte(python378DownloadPage), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte(fullPythonIndex), nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
+
+				depVersion, err := python.GetDependencyVersion("3.7.8")
+				require.NoError(err)
+
+				assert.Equal(&dependency.Verification{Method: dependency.VerificationPinnedSHA256, SkipReason: "some-reason"}, depVersion.Verification)
+				assert.Equal(0, fakeChecksummer.VerifyMD5CallCount())
+
+				pathArg, shaArg := fakeChecksummer.VerifySHA256ArgsForCall(0)
+				assert.Equal("some-artifact-path", pathArg)
+				assert.Equal("some-pinned-sha256", shaArg)
+			})
+
+			it("fails when the artifact does not match the pinned sha256", func() {
+				fakeWebClient.GetReturnsOnCall(0, []byte(python378DownloadPage), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte(fullPythonIndex), nil)
+				fakeChecksummer.VerifySHA256Returns(depErrors.ChecksumMismatchError{Algorithm: "SHA256"})
+
+				_, err := python.GetDependencyVersion("3.7.8")
+				assert.True(errors.Is(err, depErrors.ChecksumMismatchError{Algorithm: "SHA256"}))
+			})
+		})
 	})
 
 	when("GetReleaseDate", func() {
 		it("returns the correct python release date", func() {
 			fakeWebClient.GetReturnsOnCall(0, []byte(python378DownloadPage), nil)
 			fakeWebClient.GetReturnsOnCall(1, []byte(fullPythonIndex), nil)
-			fakeChecksummer.GetSHA256Returns("some-sha256", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha256", "sha512": "some-sha512"}, nil)
 
 			releaseDate, err := python.GetReleaseDate("3.7.8")
 			require.NoError(err)
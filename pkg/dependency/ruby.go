@@ -9,7 +9,14 @@ import (
 
 	"gopkg.in/yaml.v2"
 
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
 	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
+)
+
+const (
+	rubyReleasesURL    = "https://raw.githubusercontent.com/ruby/www.ruby-lang.org/master/_data/releases.yml"
+	rubyMirrorIndexURL = "https://cache.ruby-lang.org/pub/ruby/index.txt"
 )
 
 type Ruby struct {
@@ -18,6 +25,8 @@ type Ruby struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
 }
 
 type RubyRelease struct {
@@ -45,12 +54,18 @@ func (r Ruby) GetDependencyVersion(version string) (DepVersion, error) {
 		return DepVersion{}, fmt.Errorf("could not get releases: %w", err)
 	}
 
-	depURL, depSHA, err := r.getDependencyURLAndSHA(version)
+	depURL, depSHA, shaSourceURL, err := r.getDependencyURLAndSHA(version)
 	if err != nil {
 		return DepVersion{}, err
 	}
 
-	licenses, err := r.licenseRetriever.LookupLicenses("ruby", depURL)
+	artifactPath, err := r.artifactStore.Fetch(depURL, verifySHA256(depSHA)...)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer r.artifactStore.Release(artifactPath)
+
+	licenses, err := r.licenseRetriever.LookupLicenses("ruby", artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
@@ -59,23 +74,31 @@ func (r Ruby) GetDependencyVersion(version string) (DepVersion, error) {
 		if release.Version == version {
 			releaseDate, err := time.Parse("2006-01-02", release.Date)
 			if err != nil {
-				return DepVersion{}, fmt.Errorf("could not parse release date: %w", err)
+				return DepVersion{}, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
+			}
+
+			checksums, err := r.checksummer.GetChecksums(artifactPath, checksumAlgorithms...)
+			if err != nil {
+				return DepVersion{}, fmt.Errorf("could not get checksums: %w", err)
 			}
 
 			return DepVersion{
 				Version:         version,
 				URI:             depURL,
-				SHA256:          depSHA,
+				SHA256:          checksums["sha256"],
+				Checksum:        prefixedChecksum(checksums),
+				Checksums:       checksums,
 				ReleaseDate:     &releaseDate,
 				DeprecationDate: nil,
-				CPE:             fmt.Sprintf("cpe:2.3:a:ruby-lang:ruby:%s:*:*:*:*:*:*:*", version),
-				PURL:            r.purlGenerator.Generate("ruby", version, depSHA, depURL),
+				CPE:             cpe.Application("ruby-lang", "ruby", version).String(),
+				PURL:            r.purlGenerator.Generate(purl.Generic("ruby", version).WithSource(checksums["sha256"], depURL)),
 				Licenses:        licenses,
+				Verification:    checksumVerification(VerificationSHA256, shaSourceURL),
 			}, nil
 		}
 	}
 
-	return DepVersion{}, fmt.Errorf("could not find version %s", version)
+	return DepVersion{}, depErrors.VersionNotFoundError{Version: version}
 }
 
 func (r Ruby) GetReleaseDate(version string) (*time.Time, error) {
@@ -88,17 +111,17 @@ func (r Ruby) GetReleaseDate(version string) (*time.Time, error) {
 		if release.Version == version {
 			releaseDate, err := time.Parse("2006-01-02", release.Date)
 			if err != nil {
-				return nil, fmt.Errorf("could not parse release date: %w", err)
+				return nil, fmt.Errorf("could not parse release date: %w", depErrors.ParseError{Err: err})
 			}
 			return &releaseDate, nil
 		}
 	}
 
-	return nil, fmt.Errorf("could not find release date for version %s", version)
+	return nil, fmt.Errorf("could not find release date: %w", depErrors.VersionNotFoundError{Version: version})
 }
 
 func (r Ruby) getAllReleases() ([]RubyRelease, error) {
-	body, err := r.webClient.Get("https://www.ruby-lang.org/en/downloads/releases/")
+	body, err := cachedGet(r.cache, r.webClient, "https://www.ruby-lang.org/en/downloads/releases/")
 	if err != nil {
 		return nil, fmt.Errorf("could not get release index: %w", err)
 	}
@@ -117,20 +140,26 @@ func (r Ruby) getAllReleases() ([]RubyRelease, error) {
 	return rubyReleases, nil
 }
 
-func (r Ruby) getDependencyURLAndSHA(version string) (string, string, error) {
+// getDependencyURLAndSHA returns the download URL and SHA256 of version,
+// along with the URL the SHA256 was published at.
+func (r Ruby) getDependencyURLAndSHA(version string) (string, string, string, error) {
 	URL, SHA, err := r.getDependencyURLAndSHAFromGithub(version)
 	if err != nil {
 		if errors.Is(err, depErrors.NoSourceCodeError{Version: version}) {
-			return r.getDependencyURLAndSHAFromMirror(version)
+			URL, SHA, err = r.getDependencyURLAndSHAFromMirror(version)
+			if err != nil {
+				return "", "", "", err
+			}
+			return URL, SHA, rubyMirrorIndexURL, nil
 		} else {
-			return "", "", err
+			return "", "", "", err
 		}
 	}
-	return URL, SHA, nil
+	return URL, SHA, rubyReleasesURL, nil
 }
 
 func (r Ruby) getDependencyURLAndSHAFromGithub(version string) (string, string, error) {
-	body, err := r.webClient.Get("https://raw.githubusercontent.com/ruby/www.ruby-lang.org/master/_data/releases.yml")
+	body, err := cachedGet(r.cache, r.webClient, rubyReleasesURL)
 	if err != nil {
 		return "", "", fmt.Errorf("could not get release yaml: %w", err)
 	}
@@ -148,7 +177,7 @@ func (r Ruby) getDependencyURLAndSHAFromGithub(version string) (string, string,
 	var releases []YAMLRelease
 	err = yaml.Unmarshal(body, &releases)
 	if err != nil {
-		return "", "", fmt.Errorf("could not unmarshal yaml releases file: %w", err)
+		return "", "", fmt.Errorf("could not unmarshal yaml releases file: %w", depErrors.ParseError{Err: err})
 	}
 
 	for _, release := range releases {
@@ -165,7 +194,7 @@ func (r Ruby) getDependencyURLAndSHAFromGithub(version string) (string, string,
 }
 
 func (r Ruby) getDependencyURLAndSHAFromMirror(version string) (string, string, error) {
-	body, err := r.webClient.Get("https://cache.ruby-lang.org/pub/ruby/index.txt")
+	body, err := cachedGet(r.cache, r.webClient, rubyMirrorIndexURL)
 	if err != nil {
 		return "", "", fmt.Errorf("could not get release index: %w", err)
 	}
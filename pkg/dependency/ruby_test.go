@@ -24,6 +24,7 @@ func testRuby(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer      *dependencyfakes.FakeChecksummer
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		ruby                 dependency.Dependency
@@ -33,11 +34,13 @@ func testRuby(t *testing.T, when spec.G, it spec.S) {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		ruby, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("ruby")
+		ruby, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, nil, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("ruby")
 		require.NoError(err)
 	})
 
@@ -145,6 +148,8 @@ func testRuby(t *testing.T, when spec.G, it spec.S) {
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 				fakePURLGenerator.GenerateReturns("pkg:generic/ruby@3.0.0?checksum=some-sha-256-gz&download_url=https://cache.ruby-lang.org")
 
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha-256-gz", "sha512": "some-sha512"}, nil)
+
 				actualDepVersion, err := ruby.GetDependencyVersion("3.0.0")
 				require.NoError(err)
 
@@ -152,14 +157,20 @@ func testRuby(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 				expectedReleaseDate := time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)
 				expectedDepVersion := dependency.DepVersion{
-					Version:         "3.0.0",
-					URI:             "https://cache.ruby-lang.org/pub/ruby/3.0/ruby-3.0.0.tar.gz",
-					SHA256:          "some-sha-256-gz",
+					Version:  "3.0.0",
+					URI:      "https://cache.ruby-lang.org/pub/ruby/3.0/ruby-3.0.0.tar.gz",
+					SHA256:   "some-sha-256-gz",
+					Checksum: "sha256:some-sha-256-gz",
+					Checksums: map[string]string{
+						"sha256": "some-sha-256-gz",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: nil,
 					CPE:             "cpe:2.3:a:ruby-lang:ruby:3.0.0:*:*:*:*:*:*:*",
 					PURL:            "pkg:generic/ruby@3.0.0?checksum=some-sha-256-gz&download_url=https://cache.ruby-lang.org",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://raw.githubusercontent.com/ruby/www.ruby-lang.org/master/_data/releases.yml"},
 				}
 				assert.Equal(expectedDepVersion, actualDepVersion)
 
@@ -192,18 +203,26 @@ ruby-1.6.7	https://cache.ruby-lang.org/pub/ruby/1.6/ruby-1.6.7.tar.gz	some-sha-1
 
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha-256", "sha512": "some-sha512"}, nil)
+
 				actualDepVersion, err := ruby.GetDependencyVersion("1.6.7")
 				require.NoError(err)
 
 				expectedReleaseDate := time.Date(2002, 3, 1, 0, 0, 0, 0, time.UTC)
 				expectedDepVersion := dependency.DepVersion{
-					Version:         "1.6.7",
-					URI:             "https://cache.ruby-lang.org/pub/ruby/1.6/ruby-1.6.7.tar.gz",
-					SHA256:          "some-sha-256",
+					Version:  "1.6.7",
+					URI:      "https://cache.ruby-lang.org/pub/ruby/1.6/ruby-1.6.7.tar.gz",
+					SHA256:   "some-sha-256",
+					Checksum: "sha256:some-sha-256",
+					Checksums: map[string]string{
+						"sha256": "some-sha-256",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: nil,
 					CPE:             "cpe:2.3:a:ruby-lang:ruby:1.6.7:*:*:*:*:*:*:*",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://cache.ruby-lang.org/pub/ruby/index.txt"},
 				}
 				assert.Equal(expectedDepVersion, actualDepVersion)
 
@@ -258,18 +277,26 @@ ruby-2.7.1	https://cache.ruby-lang.org/pub/ruby/2.7/ruby-2.7.1.tar.gz	some-sha-1
 `), nil)
 				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha-256-gz", "sha512": "some-sha512"}, nil)
+
 				actualDepVersion, err := ruby.GetDependencyVersion("2.6.6")
 				require.NoError(err)
 
 				expectedReleaseDate := time.Date(2020, 4, 20, 0, 0, 0, 0, time.UTC)
 				expectedDepVersion := dependency.DepVersion{
-					Version:         "2.6.6",
-					URI:             "https://cache.ruby-lang.org/pub/ruby/2.6/ruby-2.6.6.tar.gz",
-					SHA256:          "some-sha-256-gz",
+					Version:  "2.6.6",
+					URI:      "https://cache.ruby-lang.org/pub/ruby/2.6/ruby-2.6.6.tar.gz",
+					SHA256:   "some-sha-256-gz",
+					Checksum: "sha256:some-sha-256-gz",
+					Checksums: map[string]string{
+						"sha256": "some-sha-256-gz",
+						"sha512": "some-sha512",
+					},
 					ReleaseDate:     &expectedReleaseDate,
 					DeprecationDate: nil,
 					CPE:             "cpe:2.3:a:ruby-lang:ruby:2.6.6:*:*:*:*:*:*:*",
 					Licenses:        []string{"MIT", "MIT-2"},
+					Verification:    &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://cache.ruby-lang.org/pub/ruby/index.txt"},
 				}
 				assert.Equal(expectedDepVersion, actualDepVersion)
 
@@ -333,18 +360,26 @@ ruby-1.9.0-0	https://cache.ruby-lang.org/pub/ruby/1.9/ruby-1.9.0-0.tar.gz	some-s
 `), nil)
 					fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 
+					fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha-256", "sha512": "some-sha512"}, nil)
+
 					actualDepVersion, err := ruby.GetDependencyVersion("1.9.0")
 					require.NoError(err)
 
 					expectedReleaseDate := time.Date(2007, 12, 25, 0, 0, 0, 0, time.UTC)
 					expectedDepVersion := dependency.DepVersion{
-						Version:         "1.9.0",
-						URI:             "https://cache.ruby-lang.org/pub/ruby/1.9/ruby-1.9.0-0.tar.gz",
-						SHA256:          "some-sha-256",
+						Version:  "1.9.0",
+						URI:      "https://cache.ruby-lang.org/pub/ruby/1.9/ruby-1.9.0-0.tar.gz",
+						SHA256:   "some-sha-256",
+						Checksum: "sha256:some-sha-256",
+						Checksums: map[string]string{
+							"sha256": "some-sha-256",
+							"sha512": "some-sha512",
+						},
 						ReleaseDate:     &expectedReleaseDate,
 						DeprecationDate: nil,
 						CPE:             "cpe:2.3:a:ruby-lang:ruby:1.9.0:*:*:*:*:*:*:*",
 						Licenses:        []string{"MIT", "MIT-2"},
+						Verification:    &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://cache.ruby-lang.org/pub/ruby/index.txt"},
 					}
 					assert.Equal(expectedDepVersion, actualDepVersion)
 
@@ -409,18 +444,26 @@ ruby-1.9.1-p0	https://cache.ruby-lang.org/pub/ruby/1.9/ruby-1.9.1-p0.tar.gz	some
 `), nil)
 					fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 
+					fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-sha-256", "sha512": "some-sha512"}, nil)
+
 					actualDepVersion, err := ruby.GetDependencyVersion("1.9.1")
 					require.NoError(err)
 
 					expectedReleaseDate := time.Date(2009, 1, 30, 0, 0, 0, 0, time.UTC)
 					expectedDepVersion := dependency.DepVersion{
-						Version:         "1.9.1",
-						URI:             "https://cache.ruby-lang.org/pub/ruby/1.9/ruby-1.9.1-p0.tar.gz",
-						SHA256:          "some-sha-256",
+						Version:  "1.9.1",
+						URI:      "https://cache.ruby-lang.org/pub/ruby/1.9/ruby-1.9.1-p0.tar.gz",
+						SHA256:   "some-sha-256",
+						Checksum: "sha256:some-sha-256",
+						Checksums: map[string]string{
+							"sha256": "some-sha-256",
+							"sha512": "some-sha512",
+						},
 						ReleaseDate:     &expectedReleaseDate,
 						DeprecationDate: nil,
 						CPE:             "cpe:2.3:a:ruby-lang:ruby:1.9.1:*:*:*:*:*:*:*",
 						Licenses:        []string{"MIT", "MIT-2"},
+						Verification:    &dependency.Verification{Method: dependency.VerificationSHA256, SourceURL: "https://cache.ruby-lang.org/pub/ruby/index.txt"},
 					}
 					assert.Equal(expectedDepVersion, actualDepVersion)
 
@@ -2,12 +2,14 @@ package dependency
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Rust struct {
@@ -17,10 +19,13 @@ type Rust struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
+	keyring          Keyring
 }
 
 func (r Rust) GetAllVersionRefs() ([]string, error) {
-	tags, err := r.githubClient.GetTags("rust-lang", "rust")
+	tags, err := cachedTags(r.cache, r.githubClient, "rust-lang", "rust")
 	if err != nil {
 		return nil, fmt.Errorf("could not get tags: %w", err)
 	}
@@ -32,7 +37,7 @@ func (r Rust) GetAllVersionRefs() ([]string, error) {
 		}
 		version, err := semver.NewVersion(tag)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse version %s: %w", tag, err)
+			return nil, fmt.Errorf("failed to parse version %s: %w", tag, depErrors.ParseError{Err: err})
 		}
 		if version.Prerelease() != "" || version.Major() == 0 {
 			continue
@@ -49,12 +54,19 @@ func (r Rust) GetDependencyVersion(version string) (DepVersion, error) {
 	}
 
 	dependencyURL := r.dependencyURL(version)
-	sha, err := r.getDependencySHA(dependencyURL, version)
+	artifactPath, err := r.artifactStore.Fetch(dependencyURL)
+	if err != nil {
+		return DepVersion{}, fmt.Errorf("could not fetch dependency: %w", err)
+	}
+	defer r.artifactStore.Release(artifactPath)
+
+	checksums, verification, err := r.getDependencyChecksums(artifactPath, version)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get rust sha: %w", err)
 	}
+	sha := checksums["sha256"]
 
-	licenses, err := r.licenseRetriever.LookupLicenses("rust", dependencyURL)
+	licenses, err := r.licenseRetriever.LookupLicenses("rust", artifactPath)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
@@ -63,11 +75,14 @@ func (r Rust) GetDependencyVersion(version string) (DepVersion, error) {
 		Version:         version,
 		URI:             dependencyURL,
 		SHA256:          sha,
+		Checksum:        prefixedChecksum(checksums),
+		Checksums:       checksums,
 		ReleaseDate:     releaseDate,
 		DeprecationDate: nil,
-		CPE:             fmt.Sprintf("cpe:2.3:a:rust-lang:rust:%s:*:*:*:*:*:*:*", version),
-		PURL:            r.purlGenerator.Generate("rust", version, sha, dependencyURL),
+		CPE:             cpe.Application("rust-lang", "rust", version).String(),
+		PURL:            r.purlGenerator.Generate(purl.Generic("rust", version).WithSource(sha, dependencyURL)),
 		Licenses:        licenses,
+		Verification:    verification,
 	}, nil
 }
 
@@ -80,39 +95,29 @@ func (r Rust) GetReleaseDate(version string) (*time.Time, error) {
 	return &tagCommit.Date, nil
 }
 
-func (r Rust) getDependencySHA(dependencyURL, version string) (string, error) {
-	rustGPGKey, err := r.webClient.Get("https://static.rust-lang.org/rust-key.gpg.ascii")
-	if err != nil {
-		return "", fmt.Errorf("could not get rust GPG key: %w", err)
-	}
-
-	dependencySignature, err := r.webClient.Get(r.dependencySignatureURL(version))
-	if err != nil {
-		return "", fmt.Errorf("could not get dependency signature: %w", err)
-	}
-
-	dependencyOutputDir, err := os.MkdirTemp("", "rust")
+func (r Rust) getDependencyChecksums(dependencyOutputPath, version string) (map[string]string, *Verification, error) {
+	keyring, err := fetchKeys(r.cache, r.webClient, r.keyring)
 	if err != nil {
-		return "", fmt.Errorf("failed to create temp directory: %w", err)
+		return nil, nil, fmt.Errorf("could not get rust GPG key: %w", err)
 	}
-	dependencyOutputPath := filepath.Join(dependencyOutputDir, filepath.Base(dependencyURL))
 
-	err = r.webClient.Download(dependencyURL, dependencyOutputPath)
+	signatureURL := r.dependencySignatureURL(version)
+	dependencySignature, err := r.webClient.Get(signatureURL)
 	if err != nil {
-		return "", fmt.Errorf("could not download dependency: %w", err)
+		return nil, nil, fmt.Errorf("could not get dependency signature: %w", err)
 	}
 
-	err = r.checksummer.VerifyASC(string(dependencySignature), dependencyOutputPath, string(rustGPGKey))
+	fingerprint, err := r.checksummer.VerifyASC(string(dependencySignature), dependencyOutputPath, keyring)
 	if err != nil {
-		return "", fmt.Errorf("dependency signature verification failed: %w", err)
+		return nil, nil, fmt.Errorf("dependency signature verification failed: %w", err)
 	}
 
-	dependencySHA, err := r.checksummer.GetSHA256(dependencyOutputPath)
+	checksums, err := r.checksummer.GetChecksums(dependencyOutputPath, checksumAlgorithms...)
 	if err != nil {
-		return "", fmt.Errorf("could not get SHA256: %w", err)
+		return nil, nil, fmt.Errorf("could not get SHA256: %w", err)
 	}
 
-	return dependencySHA, nil
+	return checksums, signatureVerification(signatureURL, fingerprint), nil
 }
 
 func (r Rust) dependencySignatureURL(version string) string {
@@ -26,6 +26,7 @@ func testRust(t *testing.T, when spec.G, it spec.S) {
 		fakeFileSystem       *dependencyfakes.FakeFileSystem
 		fakeGithubClient     *dependencyfakes.FakeGithubClient
 		fakeWebClient        *dependencyfakes.FakeWebClient
+		fakeArtifactStore    *dependencyfakes.FakeArtifactStore
 		fakeLicenseRetriever *dependencyfakes.FakeLicenseRetriever
 		fakePURLGenerator    *dependencyfakes.FakePURLGenerator
 		rust                 dependency.Dependency
@@ -33,14 +34,17 @@ func testRust(t *testing.T, when spec.G, it spec.S) {
 
 	it.Before(func() {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
+		fakeChecksummer.VerifyASCReturns("some-fingerprint", nil)
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
+		fakeArtifactStore = &dependencyfakes.FakeArtifactStore{}
+		fakeArtifactStore.FetchReturns("some-artifact-path", nil)
 		fakeLicenseRetriever = &dependencyfakes.FakeLicenseRetriever{}
 		fakePURLGenerator = &dependencyfakes.FakePURLGenerator{}
 
 		var err error
-		rust, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator).NewDependency("rust")
+		rust, err = dependency.NewCustomDependencyFactory(fakeChecksummer, fakeFileSystem, fakeGithubClient, fakeWebClient, fakeLicenseRetriever, fakePURLGenerator, dependency.WithArtifactStore(fakeArtifactStore)).NewDependency("rust")
 		require.NoError(err)
 	})
 
@@ -79,7 +83,7 @@ func testRust(t *testing.T, when spec.G, it spec.S) {
 			fakeWebClient.GetReturnsOnCall(0, []byte("some-gpg-key"), nil)
 			fakeWebClient.GetReturnsOnCall(1, []byte("some-signature"), nil)
 
-			fakeChecksummer.GetSHA256Returns("some-source-sha", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha", "sha512": "some-sha512"}, nil)
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/rust@1.49.0?checksum=some-source-sha&download_url=https://static.rust-lang.org")
 
@@ -89,14 +93,20 @@ func testRust(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 			expectedDepVersion := dependency.DepVersion{
-				Version:         "1.49.0",
-				URI:             "https://static.rust-lang.org/dist/rustc-1.49.0-src.tar.gz",
-				SHA256:          "some-source-sha",
+				Version:  "1.49.0",
+				URI:      "https://static.rust-lang.org/dist/rustc-1.49.0-src.tar.gz",
+				SHA256:   "some-source-sha",
+				Checksum: "sha256:some-source-sha",
+				Checksums: map[string]string{
+					"sha256": "some-source-sha",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &tagCommit.Date,
 				DeprecationDate: nil,
 				CPE:             "cpe:2.3:a:rust-lang:rust:1.49.0:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/rust@1.49.0?checksum=some-source-sha&download_url=https://static.rust-lang.org",
 				Licenses:        []string{"MIT", "MIT-2"},
+				Verification:    &dependency.Verification{Method: dependency.VerificationPGP, SourceURL: "https://static.rust-lang.org/dist/rustc-1.49.0-src.tar.gz.asc", Fingerprint: "some-fingerprint"},
 			}
 			assert.Equal(expectedDepVersion, actualDepVersion)
 
@@ -106,12 +116,12 @@ func testRust(t *testing.T, when spec.G, it spec.S) {
 			url, _ = fakeWebClient.GetArgsForCall(1)
 			assert.Equal("https://static.rust-lang.org/dist/rustc-1.49.0-src.tar.gz.asc", url)
 
-			urlArg, _, _ := fakeWebClient.DownloadArgsForCall(0)
+			urlArg, _ := fakeArtifactStore.FetchArgsForCall(0)
 			assert.Equal("https://static.rust-lang.org/dist/rustc-1.49.0-src.tar.gz", urlArg)
 
 			releaseAssetSignatureArg, _, rustGPGKeyArg := fakeChecksummer.VerifyASCArgsForCall(0)
 			assert.Equal("some-signature", releaseAssetSignatureArg)
-			assert.Equal([]string{"some-gpg-key"}, rustGPGKeyArg)
+			assert.Equal([]string{"some-gpg-key"}, rustGPGKeyArg.Keys)
 		})
 	})
 
@@ -2,12 +2,15 @@ package dependency
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
+	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Tini struct {
@@ -15,10 +18,13 @@ type Tini struct {
 	githubClient     GithubClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
+	tempDirs         internal.TempDirs
 }
 
 func (t Tini) GetAllVersionRefs() ([]string, error) {
-	releases, err := t.githubClient.GetReleaseTags("krallin", "tini")
+	releases, err := cachedReleaseTags(t.cache, t.githubClient, "krallin", "tini")
 	if err != nil {
 		return nil, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -31,7 +37,7 @@ func (t Tini) GetAllVersionRefs() ([]string, error) {
 }
 
 func (t Tini) GetDependencyVersion(version string) (DepVersion, error) {
-	releases, err := t.githubClient.GetReleaseTags("krallin", "tini")
+	releases, err := cachedReleaseTags(t.cache, t.githubClient, "krallin", "tini")
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -45,11 +51,11 @@ func (t Tini) GetDependencyVersion(version string) (DepVersion, error) {
 			return depVersion, nil
 		}
 	}
-	return DepVersion{}, fmt.Errorf("could not find tini version %s", version)
+	return DepVersion{}, depErrors.VersionNotFoundError{Dependency: "tini", Version: version}
 }
 
 func (t Tini) GetReleaseDate(version string) (*time.Time, error) {
-	releases, err := t.githubClient.GetReleaseTags("krallin", "tini")
+	releases, err := cachedReleaseTags(t.cache, t.githubClient, "krallin", "tini")
 	if err != nil {
 		return nil, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -60,15 +66,15 @@ func (t Tini) GetReleaseDate(version string) (*time.Time, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("could not find release date for version %s", version)
+	return nil, fmt.Errorf("could not find release date: %w", depErrors.VersionNotFoundError{Version: version})
 }
 
 func (t Tini) createDependencyVersion(version string, release internal.GithubRelease) (DepVersion, error) {
-	tarballDir, err := os.MkdirTemp("", "tini")
+	tarballDir, err := t.tempDirs.MkdirTemp("tini")
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not create temp directory: %w", err)
 	}
-	defer os.RemoveAll(tarballDir)
+	defer t.tempDirs.Remove(tarballDir)
 
 	tarballPath := filepath.Join(tarballDir, fmt.Sprintf("tini-%s.tar.gz", version))
 
@@ -77,24 +83,32 @@ func (t Tini) createDependencyVersion(version string, release internal.GithubRel
 		return DepVersion{}, fmt.Errorf("could not download source tarball: %w", err)
 	}
 
-	dependencySHA, err := t.checksummer.GetSHA256(tarballPath)
+	checksums, err := t.checksummer.GetChecksums(tarballPath, checksumAlgorithms...)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get SHA256: %w", err)
 	}
+	dependencySHA := checksums["sha256"]
 
-	licenses, err := t.licenseRetriever.LookupLicenses("tini", tarballURL)
+	licenses, licenseExpression, licenseSources, err := resolveLicenses(t.licenseRetriever, "tini", tarballPath,
+		githubLicenses(t.githubClient, "krallin", "tini", version),
+	)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
 
 	return DepVersion{
-		Version:         version,
-		URI:             tarballURL,
-		SHA256:          dependencySHA,
-		ReleaseDate:     &release.PublishedDate,
-		DeprecationDate: nil,
-		CPE:             fmt.Sprintf("cpe:2.3:a:tini_project:tini:%s:*:*:*:*:*:*:*", strings.TrimPrefix(version, "v")),
-		PURL:            t.purlGenerator.Generate("tini", version, dependencySHA, tarballURL),
-		Licenses:        licenses,
+		Version:           version,
+		URI:               tarballURL,
+		SHA256:            dependencySHA,
+		Checksum:          prefixedChecksum(checksums),
+		Checksums:         checksums,
+		ReleaseDate:       &release.PublishedDate,
+		DeprecationDate:   nil,
+		CPE:               cpe.Application("tini_project", "tini", strings.TrimPrefix(version, "v")).String(),
+		PURL:              t.purlGenerator.Generate(purl.GitHub("krallin", "tini", version).WithSource(dependencySHA, tarballURL)),
+		Licenses:          licenses,
+		LicenseExpression: licenseExpression,
+		LicenseSources:    licenseSources,
+		Verification:      skippedVerification("tini does not publish checksums for its source tarballs"),
 	}, nil
 }
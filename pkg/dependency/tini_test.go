@@ -1,6 +1,7 @@
 package dependency_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -87,7 +88,7 @@ func testTini(t *testing.T, when spec.G, it spec.S) {
 			}, nil)
 			fakeGithubClient.DownloadSourceTarballReturns("some-tarball-url", nil)
 
-			fakeChecksummer.GetSHA256Returns("some-source-sha", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha", "sha512": "some-sha512"}, nil)
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/tini@v1.0.0?checksum=some-source-sha&download_url=some-tarball-url")
 
@@ -98,14 +99,21 @@ func testTini(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
 			expectedReleaseDate := time.Date(2020, 6, 27, 0, 0, 0, 0, time.UTC)
 			expectedDep := dependency.DepVersion{
-				Version:         "v1.0.0",
-				URI:             "some-tarball-url",
-				SHA256:          "some-source-sha",
+				Version:  "v1.0.0",
+				URI:      "some-tarball-url",
+				SHA256:   "some-source-sha",
+				Checksum: "sha256:some-source-sha",
+				Checksums: map[string]string{
+					"sha256": "some-source-sha",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: nil,
 				CPE:             "cpe:2.3:a:tini_project:tini:1.0.0:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/tini@v1.0.0?checksum=some-source-sha&download_url=some-tarball-url",
 				Licenses:        []string{"MIT", "MIT-2"},
+				LicenseSources:  map[string]dependency.LicenseSource{"MIT": dependency.LicenseSourceFileScan, "MIT-2": dependency.LicenseSourceFileScan},
+				Verification:    &dependency.Verification{Method: dependency.VerificationNone, SkipReason: "tini does not publish checksums for its source tarballs"},
 			}
 
 			assert.Equal(expectedDep, actualDep)
@@ -115,6 +123,40 @@ func testTini(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal("tini", repoArg)
 			assert.Equal("v1.0.0", versionArg)
 		})
+
+		when("GitHub detects the license of the tag", func() {
+			it("uses it instead of scanning the tarball", func() {
+				fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{{TagName: "v1.0.0"}}, nil)
+				fakeGithubClient.GetLicenseReturns("MIT", nil)
+
+				actualDep, err := tini.GetDependencyVersion("v1.0.0")
+				require.NoError(err)
+
+				assert.Equal(0, fakeLicenseRetriever.LookupLicensesCallCount())
+				assert.Equal([]string{"MIT"}, actualDep.Licenses)
+				assert.Equal(map[string]dependency.LicenseSource{"MIT": dependency.LicenseSourceGitHub}, actualDep.LicenseSources)
+
+				orgArg, repoArg, refArg := fakeGithubClient.GetLicenseArgsForCall(0)
+				assert.Equal("krallin", orgArg)
+				assert.Equal("tini", repoArg)
+				assert.Equal("v1.0.0", refArg)
+			})
+		})
+
+		when("the GitHub license lookup fails", func() {
+			it("scans the tarball", func() {
+				fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{{TagName: "v1.0.0"}}, nil)
+				fakeGithubClient.GetLicenseReturns("", errors.New("some-error"))
+				fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT"}, nil)
+
+				actualDep, err := tini.GetDependencyVersion("v1.0.0")
+				require.NoError(err)
+
+				assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
+				assert.Equal(map[string]dependency.LicenseSource{"MIT": dependency.LicenseSourceFileScan}, actualDep.LicenseSources)
+				assert.Empty(actualDep.LicenseExpression)
+			})
+		})
 	})
 
 	when("GetReleaseDate", func() {
@@ -136,4 +178,41 @@ func testTini(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal("2020-06-27T00:00:00Z", releaseDate.Format(time.RFC3339))
 		})
 	})
+
+	when("the factory is given a cache", func() {
+		it.Before(func() {
+			var err error
+			tini, err = dependency.NewCustomDependencyFactory(fakeChecksummer, nil, fakeGithubClient, nil, fakeLicenseRetriever, fakePURLGenerator,
+				dependency.WithCache(internal.NewCache(time.Minute)),
+			).NewDependency("tini")
+			require.NoError(err)
+		})
+
+		it("only fetches the releases once across calls", func() {
+			fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{
+				{
+					TagName:       "v2.0.0",
+					PublishedDate: time.Date(2020, 6, 28, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					TagName:       "v1.0.0",
+					PublishedDate: time.Date(2020, 6, 27, 0, 0, 0, 0, time.UTC),
+				},
+			}, nil)
+
+			versions, err := tini.GetAllVersionRefs()
+			require.NoError(err)
+			assert.Equal([]string{"v2.0.0", "v1.0.0"}, versions)
+
+			releaseDate, err := tini.GetReleaseDate("v1.0.0")
+			require.NoError(err)
+			assert.Equal("2020-06-27T00:00:00Z", releaseDate.Format(time.RFC3339))
+
+			releaseDate, err = tini.GetReleaseDate("v2.0.0")
+			require.NoError(err)
+			assert.Equal("2020-06-28T00:00:00Z", releaseDate.Format(time.RFC3339))
+
+			assert.Equal(1, fakeGithubClient.GetReleaseTagsCallCount())
+		})
+	})
 }
@@ -0,0 +1,104 @@
+package dependency
+
+import (
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
+)
+
+// URLPolicy limits the hosts and schemes a dependency may request, and
+// whether it may reach private networks. A request that is not allowed fails
+// with an errors.URLNotAllowedError.
+type URLPolicy = internal.URLPolicy
+
+var githubHosts = []string{
+	"github.com",
+	"api.github.com",
+	"codeload.github.com",
+	"*.githubusercontent.com",
+}
+
+// defaultURLPolicies are the policies used by factories created with
+// NewDependencyFactory, keyed by dependency name. Requests made through the
+// shared GitHub client are only subject to the factory-wide policy.
+var defaultURLPolicies = map[string]URLPolicy{
+	"apc":               {Hosts: []string{"pecl.php.net"}},
+	"apcu":              {Hosts: []string{"pecl.php.net"}},
+	"bundler":           {Hosts: []string{"rubygems.org", "*.rubygems.org"}},
+	"composer":          {Hosts: append([]string{"getcomposer.org", "keys.openpgp.org"}, githubHosts...)},
+	"curl":              {Hosts: []string{"curl.se", "daniel.haxx.se"}},
+	"dotnet-aspnetcore": {Hosts: dotnetHosts},
+	"dotnet-runtime":    {Hosts: dotnetHosts},
+	"dotnet-sdk":        {Hosts: dotnetHosts},
+	"go":                {Hosts: []string{"golang.org", "go.dev", "dl.google.com"}},
+	"httpd":             {Hosts: []string{"archive.apache.org"}},
+	"icu":               {Hosts: githubHosts},
+	"nginx":             {Hosts: append([]string{"nginx.org"}, githubHosts...)},
+	"node":              {Hosts: []string{"nodejs.org", "raw.githubusercontent.com"}},
+	"php":               {Hosts: append([]string{"php.net", "www.php.net", "secure.php.net", "museum.php.net"}, githubHosts...)},
+	"pip":               {Hosts: pypiHosts},
+	"pipenv":            {Hosts: pypiHosts},
+	"poetry":            {Hosts: pypiHosts},
+	"python":            {Hosts: []string{"python.org", "www.python.org"}},
+	"ruby":              {Hosts: []string{"www.ruby-lang.org", "cache.ruby-lang.org", "ftp.ruby-lang.org", "raw.githubusercontent.com"}},
+	"rust":              {Hosts: append([]string{"static.rust-lang.org"}, githubHosts...)},
+	"tini":              {Hosts: githubHosts},
+	"yarn":              {Hosts: append([]string{"dl.yarnpkg.com", "classic.yarnpkg.com", "registry.npmjs.org"}, githubHosts...)},
+}
+
+var dotnetHosts = []string{
+	"dotnetcli.blob.core.windows.net",
+	"dotnetcli.azureedge.net",
+	"dotnetbuilds.azureedge.net",
+	"builds.dotnet.microsoft.com",
+	"download.visualstudio.microsoft.com",
+	"download.microsoft.com",
+}
+
+var pypiHosts = []string{"pypi.org", "files.pythonhosted.org"}
+
+// WithURLPolicy replaces the URL policy of the named dependency, which
+// applies to the requests it makes and the artifacts it fetches.
+func WithURLPolicy(name string, policy URLPolicy) DepFactoryOption {
+	return func(d *DepFactory) {
+		urlPolicies := map[string]URLPolicy{}
+		for n, p := range d.urlPolicies {
+			urlPolicies[n] = p
+		}
+		urlPolicies[name] = policy
+		d.urlPolicies = urlPolicies
+	}
+}
+
+// webClientFor returns the web client for the named dependency, applying its
+// URL policy if it has one.
+func (d DepFactory) webClientFor(name string) WebClient {
+	policy, ok := d.urlPolicies[name]
+	if !ok {
+		return d.webClient
+	}
+
+	return urlPolicyWebClient{WebClient: d.webClient, policy: policy}
+}
+
+// urlPolicyWebClient makes every request with a URL policy.
+type urlPolicyWebClient struct {
+	WebClient
+	policy URLPolicy
+}
+
+func (u urlPolicyWebClient) Download(url, outputPath string, options ...internal.RequestOption) error {
+	return u.WebClient.Download(url, outputPath, append(options, internal.WithRequestURLPolicy(u.policy))...)
+}
+
+func (u urlPolicyWebClient) Get(url string, options ...internal.RequestOption) ([]byte, error) {
+	return u.WebClient.Get(url, append(options, internal.WithRequestURLPolicy(u.policy))...)
+}
+
+// urlPolicyArtifactStore fetches artifacts with a URL policy.
+type urlPolicyArtifactStore struct {
+	ArtifactStore
+	policy URLPolicy
+}
+
+func (u urlPolicyArtifactStore) Fetch(url string, options ...internal.RequestOption) (string, error) {
+	return u.ArtifactStore.Fetch(url, append(options, internal.WithRequestURLPolicy(u.policy))...)
+}
@@ -0,0 +1,62 @@
+package dependency
+
+import "fmt"
+
+// Verification methods recorded on a DepVersion. VerificationPinnedSHA256
+// means the artifact matched a sha256 pinned in the checksum exceptions and
+// VerificationNone means the checksums were computed from the download
+// without anything to check them against.
+const (
+	VerificationPGP          = "pgp"
+	VerificationSHA512       = "sha512"
+	VerificationSHA256       = "sha256"
+	VerificationSHA1         = "sha1"
+	VerificationMD5          = "md5"
+	VerificationPinnedSHA256 = "pinned-sha256"
+	VerificationNone         = "none"
+)
+
+// Verification records how the checksums of a DepVersion were established.
+// SourceURL is where the signature or upstream checksum was read from,
+// Fingerprint is the primary key fingerprint of a PGP signer and SkipReason
+// explains why the upstream signature or checksum was not used, including
+// when a checksum was only checked because the signature was missing.
+type Verification struct {
+	Method      string `json:"method"`
+	SourceURL   string `json:"source_url,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	SkipReason  string `json:"skip_reason,omitempty"`
+}
+
+// Verified reports whether the artifact was checked against a signature or
+// a checksum published by its upstream, or a checksum pinned in the checksum
+// exceptions. An artifact checked against a checksum only because its
+// upstream signature was skipped is not verified.
+func (v *Verification) Verified() bool {
+	if v == nil || v.Method == "" || v.Method == VerificationNone {
+		return false
+	}
+	return v.SkipReason == "" || v.Method == VerificationPinnedSHA256
+}
+
+func signatureVerification(sourceURL, fingerprint string) *Verification {
+	return &Verification{Method: VerificationPGP, SourceURL: sourceURL, Fingerprint: fingerprint}
+}
+
+func checksumVerification(method, sourceURL string) *Verification {
+	return &Verification{Method: method, SourceURL: sourceURL}
+}
+
+// fallbackVerification records an artifact checked against an upstream
+// checksum because its signature was skipped for reason.
+func fallbackVerification(method, sourceURL, reason string) *Verification {
+	return &Verification{Method: method, SourceURL: sourceURL, SkipReason: reason}
+}
+
+func skippedVerification(reason string) *Verification {
+	return &Verification{Method: VerificationNone, SkipReason: reason}
+}
+
+func releaseAssetURL(org, repo, tag, assetName string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/releases/download/%s/%s", org, repo, tag, assetName)
+}
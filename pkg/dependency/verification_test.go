@@ -0,0 +1,42 @@
+package dependency_test
+
+import (
+	"testing"
+
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerification(t *testing.T) {
+	spec.Run(t, "Verification", testVerification, spec.Report(report.Terminal{}))
+}
+
+func testVerification(t *testing.T, when spec.G, it spec.S) {
+	var assert = assert.New(t)
+
+	when("Verified", func() {
+		it("is true for signatures and upstream checksums", func() {
+			assert.True((&dependency.Verification{Method: dependency.VerificationPGP}).Verified())
+			assert.True((&dependency.Verification{Method: dependency.VerificationSHA256}).Verified())
+			assert.True((&dependency.Verification{Method: dependency.VerificationMD5}).Verified())
+		})
+
+		it("is true for checksums pinned in the checksum exceptions", func() {
+			assert.True((&dependency.Verification{Method: dependency.VerificationPinnedSHA256, SkipReason: "some-reason"}).Verified())
+		})
+
+		it("is false for checksums only checked because the signature was skipped", func() {
+			assert.False((&dependency.Verification{Method: dependency.VerificationSHA256, SkipReason: "some-reason"}).Verified())
+		})
+
+		it("is false when verification was skipped or not recorded", func() {
+			assert.False((&dependency.Verification{Method: dependency.VerificationNone, SkipReason: "some-reason"}).Verified())
+			assert.False((&dependency.Verification{}).Verified())
+
+			var verification *dependency.Verification
+			assert.False(verification.Verified())
+		})
+	})
+}
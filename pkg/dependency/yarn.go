@@ -4,16 +4,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
 
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/cpe"
 	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal/internal_errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 type Yarn struct {
@@ -23,6 +24,10 @@ type Yarn struct {
 	webClient        WebClient
 	licenseRetriever LicenseRetriever
 	purlGenerator    PURLGenerator
+	cache            Cache
+	artifactStore    ArtifactStore
+	tempDirs         internal.TempDirs
+	keyring          Keyring
 }
 
 type YarnRelease struct {
@@ -34,7 +39,7 @@ type Asset struct {
 }
 
 func (y Yarn) GetAllVersionRefs() ([]string, error) {
-	releases, err := y.githubClient.GetReleaseTags("yarnpkg", "yarn")
+	releases, err := cachedReleaseTags(y.cache, y.githubClient, "yarnpkg", "yarn")
 	if err != nil {
 		return nil, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -44,7 +49,7 @@ func (y Yarn) GetAllVersionRefs() ([]string, error) {
 		versionTagName := strings.TrimPrefix(release.TagName, "v")
 		version, err := semver.NewVersion(versionTagName)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse version: %w", err)
+			return nil, fmt.Errorf("failed to parse version: %w", depErrors.ParseError{Err: err})
 		}
 		/** Versions less than 0.7.0 does not have source code and the version tag does not contains the "v" at the start*/
 		if version.LessThan(semver.MustParse("0.7.0")) {
@@ -59,7 +64,7 @@ func (y Yarn) GetAllVersionRefs() ([]string, error) {
 }
 
 func (y Yarn) GetDependencyVersion(version string) (DepVersion, error) {
-	releases, err := y.githubClient.GetReleaseTags("yarnpkg", "yarn")
+	releases, err := cachedReleaseTags(y.cache, y.githubClient, "yarnpkg", "yarn")
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -74,11 +79,11 @@ func (y Yarn) GetDependencyVersion(version string) (DepVersion, error) {
 			return depVersion, nil
 		}
 	}
-	return DepVersion{}, fmt.Errorf("could not find yarn version %s", version)
+	return DepVersion{}, depErrors.VersionNotFoundError{Dependency: "yarn", Version: version}
 }
 
 func (y Yarn) GetReleaseDate(version string) (*time.Time, error) {
-	releases, err := y.githubClient.GetReleaseTags("yarnpkg", "yarn")
+	releases, err := cachedReleaseTags(y.cache, y.githubClient, "yarnpkg", "yarn")
 	if err != nil {
 		return nil, fmt.Errorf("could not get releases: %w", err)
 	}
@@ -89,20 +94,20 @@ func (y Yarn) GetReleaseDate(version string) (*time.Time, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("could not find release date for version %s", version)
+	return nil, fmt.Errorf("could not find release date: %w", depErrors.VersionNotFoundError{Version: version})
 }
 
 func (y Yarn) createDependencyVersion(version, tagName string, release internal.GithubRelease) (DepVersion, error) {
-	yarnGPGKey, err := y.webClient.Get("https://dl.yarnpkg.com/debian/pubkey.gpg")
+	keyring, err := fetchKeys(y.cache, y.webClient, y.keyring)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get yarn GPG key: %w", err)
 	}
 
-	releaseAssetDir, err := os.MkdirTemp("", "yarn")
+	releaseAssetDir, err := y.tempDirs.MkdirTemp("yarn")
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("failed to create temp directory: %w", err)
 	}
-	defer os.RemoveAll(releaseAssetDir)
+	defer y.tempDirs.Remove(releaseAssetDir)
 	releaseAssetPath := filepath.Join(releaseAssetDir, fmt.Sprintf("yarn-%s.tar.gz", tagName))
 
 	assetName := fmt.Sprintf("yarn-%s.tar.gz", tagName)
@@ -122,7 +127,7 @@ func (y Yarn) createDependencyVersion(version, tagName string, release internal.
 	asset := Asset{}
 	err = json.Unmarshal(assetContent, &asset)
 	if err != nil {
-		return DepVersion{}, fmt.Errorf("could not unmarshal asset url content: %w", err)
+		return DepVersion{}, fmt.Errorf("could not unmarshal asset url content: %w", depErrors.ParseError{Err: err})
 	}
 
 	assetName = fmt.Sprintf("yarn-%s.tar.gz.asc", tagName)
@@ -131,29 +136,37 @@ func (y Yarn) createDependencyVersion(version, tagName string, release internal.
 		return DepVersion{}, fmt.Errorf("could not get release artifact signature: %w", err)
 	}
 
-	err = y.checksummer.VerifyASC(string(releaseAssetSignature), releaseAssetPath, string(yarnGPGKey))
+	fingerprint, err := y.checksummer.VerifyASC(string(releaseAssetSignature), releaseAssetPath, keyring)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("release artifact signature verification failed: %w", err)
 	}
 
-	dependencySHA, err := y.checksummer.GetSHA256(releaseAssetPath)
+	checksums, err := y.checksummer.GetChecksums(releaseAssetPath, checksumAlgorithms...)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get SHA256: %w", err)
 	}
+	dependencySHA := checksums["sha256"]
 
-	licenses, err := y.licenseRetriever.LookupLicenses("yarn", asset.BrowserDownloadUrl)
+	licenses, licenseExpression, licenseSources, err := resolveLicenses(y.licenseRetriever, "yarn", releaseAssetPath,
+		npmLicenses(y.cache, y.webClient, "yarn", version),
+	)
 	if err != nil {
 		return DepVersion{}, fmt.Errorf("could not get retrieve licenses: %w", err)
 	}
 
 	return DepVersion{
-		Version:         version,
-		URI:             asset.BrowserDownloadUrl,
-		SHA256:          dependencySHA,
-		ReleaseDate:     &release.PublishedDate,
-		DeprecationDate: nil,
-		CPE:             fmt.Sprintf("cpe:2.3:a:yarnpkg:yarn:%s:*:*:*:*:*:*:*", version),
-		PURL:            y.purlGenerator.Generate("yarn", version, dependencySHA, asset.BrowserDownloadUrl),
-		Licenses:        licenses,
+		Version:           version,
+		URI:               asset.BrowserDownloadUrl,
+		SHA256:            dependencySHA,
+		Checksum:          prefixedChecksum(checksums),
+		Checksums:         checksums,
+		ReleaseDate:       &release.PublishedDate,
+		DeprecationDate:   nil,
+		CPE:               cpe.Application("yarnpkg", "yarn", version).String(),
+		PURL:              y.purlGenerator.Generate(purl.NPM("yarn", version).WithSource(dependencySHA, asset.BrowserDownloadUrl)),
+		Licenses:          licenses,
+		LicenseExpression: licenseExpression,
+		LicenseSources:    licenseSources,
+		Verification:      signatureVerification(releaseAssetURL("yarnpkg", "yarn", tagName, assetName), fingerprint),
 	}, nil
 }
@@ -15,6 +15,7 @@ import (
 	depErrors "github.com/paketo-buildpacks/dep-server/pkg/dependency/errors"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal"
 	"github.com/paketo-buildpacks/dep-server/pkg/dependency/internal/internal_errors"
+	"github.com/paketo-buildpacks/dep-server/pkg/dependency/purl"
 )
 
 func TestYarn(t *testing.T) {
@@ -36,6 +37,7 @@ func testYarn(t *testing.T, when spec.G, it spec.S) {
 
 	it.Before(func() {
 		fakeChecksummer = &dependencyfakes.FakeChecksummer{}
+		fakeChecksummer.VerifyASCReturns("some-fingerprint", nil)
 		fakeFileSystem = &dependencyfakes.FakeFileSystem{}
 		fakeGithubClient = &dependencyfakes.FakeGithubClient{}
 		fakeWebClient = &dependencyfakes.FakeWebClient{}
@@ -102,7 +104,7 @@ func testYarn(t *testing.T, when spec.G, it spec.S) {
 
 			fakeGithubClient.GetReleaseAssetReturns([]byte("some-signature"), nil)
 			fakeGithubClient.DownloadReleaseAssetReturns("some-asset-url", nil)
-			fakeChecksummer.GetSHA256Returns("some-source-sha", nil)
+			fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha", "sha512": "some-sha512"}, nil)
 			fakeLicenseRetriever.LookupLicensesReturns([]string{"MIT", "MIT-2"}, nil)
 			fakePURLGenerator.GenerateReturns("pkg:generic/yarn@1.0.0?checksum=some-source-sha&download_url=some-source-url")
 
@@ -111,16 +113,24 @@ func testYarn(t *testing.T, when spec.G, it spec.S) {
 
 			assert.Equal(1, fakeLicenseRetriever.LookupLicensesCallCount())
 			assert.Equal(1, fakePURLGenerator.GenerateCallCount())
+			assert.Equal(purl.NPM("yarn", "1.0.0").WithSource("some-source-sha", "some-source-url"), fakePURLGenerator.GenerateArgsForCall(0))
 			expectedReleaseDate := time.Date(2020, 6, 27, 0, 0, 0, 0, time.UTC)
 			expectedDep := dependency.DepVersion{
-				Version:         "1.0.0",
-				URI:             "some-source-url",
-				SHA256:          "some-source-sha",
+				Version:  "1.0.0",
+				URI:      "some-source-url",
+				SHA256:   "some-source-sha",
+				Checksum: "sha256:some-source-sha",
+				Checksums: map[string]string{
+					"sha256": "some-source-sha",
+					"sha512": "some-sha512",
+				},
 				ReleaseDate:     &expectedReleaseDate,
 				DeprecationDate: nil,
 				CPE:             "cpe:2.3:a:yarnpkg:yarn:1.0.0:*:*:*:*:*:*:*",
 				PURL:            "pkg:generic/yarn@1.0.0?checksum=some-source-sha&download_url=some-source-url",
 				Licenses:        []string{"MIT", "MIT-2"},
+				LicenseSources:  map[string]dependency.LicenseSource{"MIT": dependency.LicenseSourceFileScan, "MIT-2": dependency.LicenseSourceFileScan},
+				Verification:    &dependency.Verification{Method: dependency.VerificationPGP, SourceURL: "https://github.com/yarnpkg/yarn/releases/download/v1.0.0/yarn-v1.0.0.tar.gz.asc", Fingerprint: "some-fingerprint"},
 			}
 
 			assert.Equal(expectedDep, actualDep)
@@ -142,7 +152,28 @@ func testYarn(t *testing.T, when spec.G, it spec.S) {
 
 			releaseAssetSignatureArg, _, yarnGPGKeyArg := fakeChecksummer.VerifyASCArgsForCall(0)
 			assert.Equal("some-signature", releaseAssetSignatureArg)
-			assert.Equal([]string{"some-gpg-key"}, yarnGPGKeyArg)
+			assert.Equal([]string{"some-gpg-key"}, yarnGPGKeyArg.Keys)
+		})
+
+		when("the npm registry declares the license of the version", func() {
+			it("uses it instead of scanning the release", func() {
+				fakeGithubClient.GetReleaseTagsReturns([]internal.GithubRelease{{TagName: "v1.0.0"}}, nil)
+				fakeWebClient.GetReturnsOnCall(0, []byte("some-gpg-key"), nil)
+				fakeWebClient.GetReturnsOnCall(1, []byte(`{"browser_download_url":"some-source-url"}`), nil)
+				fakeWebClient.GetReturnsOnCall(2, []byte(`{"name": "yarn", "version": "1.0.0", "license": "BSD-2-Clause"}`), nil)
+				fakeChecksummer.GetChecksumsReturns(map[string]string{"sha256": "some-source-sha"}, nil)
+
+				actualDep, err := yarn.GetDependencyVersion("1.0.0")
+				require.NoError(err)
+
+				assert.Equal(0, fakeLicenseRetriever.LookupLicensesCallCount())
+				assert.Equal([]string{"BSD-2-Clause"}, actualDep.Licenses)
+				assert.Equal("BSD-2-Clause", actualDep.LicenseExpression)
+				assert.Equal(map[string]dependency.LicenseSource{"BSD-2-Clause": dependency.LicenseSourceNPM}, actualDep.LicenseSources)
+
+				url, _ := fakeWebClient.GetArgsForCall(2)
+				assert.Equal("https://registry.npmjs.org/yarn/1.0.0", url)
+			})
 		})
 
 		when("the asset cannot be found", func() {